@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+)
+
+const apiKeyUsage = `usage:
+  calendar [flags] apikey create -name <name> -scopes <scope>[,<scope>...]
+  calendar [flags] apikey list
+  calendar [flags] apikey revoke <id>
+
+scopes: `
+
+var ErrorInvalidAPIKeyCommand = errors.New("invalid apikey command")
+
+// runAPIKeyCommand выполняет подкоманду управления ключами API сервисных клиентов.
+// Выпущенный ключ выводится один раз, в хранилище остается только его хеш.
+func runAPIKeyCommand(ctx context.Context, calendar *app.Calendar, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return usageError(out)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		flags.SetOutput(out)
+		name := flags.String("name", "", "Name of the client the key is issued to")
+		scopes := flags.String("scopes", "", "Comma separated list of scopes")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		key, created, err := calendar.CreateAPIKey(ctx, *name, splitScopes(*scopes))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "id:     %s\nscopes: %s\nkey:    %s\n", created.ID, strings.Join(created.Scopes, ","), key)
+		fmt.Fprintln(out, "Store the key now, it cannot be shown again.")
+		return nil
+	case "list":
+		keys, err := calendar.SelectAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSCOPES\tCREATED\tREVOKED")
+		for _, key := range keys {
+			revoked := "-"
+			if key.Revoked() {
+				revoked = key.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				key.ID, key.Name, strings.Join(key.Scopes, ","), key.CreatedAt.Format(time.RFC3339), revoked)
+		}
+		return w.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return usageError(out)
+		}
+		if err := calendar.RevokeAPIKey(ctx, args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "API key %s revoked\n", args[1])
+		return nil
+	default:
+		return usageError(out)
+	}
+}
+
+// splitScopes разбирает список областей доступа через запятую.
+func splitScopes(value string) []string {
+	scopes := make([]string, 0)
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func usageError(out io.Writer) error {
+	fmt.Fprintln(out, apiKeyUsage+strings.Join(auth.Scopes, ", "))
+	return ErrorInvalidAPIKeyCommand
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	memorybroker "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker/memory"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// startDev запускает в процессе календаря планировщик, ретранслятор outbox и рассыльщик, связанные
+// брокером в памяти, вместо calendar_scheduler и calendar_sender с RabbitMQ. Уведомления по всем
+// каналам записываются в журнал. Процессы останавливаются после отмены ctx.
+func startDev(ctx context.Context, calendarApp *app.Calendar, conf *config.Config, log *logger.Logger) error {
+	if conf.RabbitMQ.Consume.Interval <= 0 {
+		return fmt.Errorf("consume.Interval must be positive")
+	}
+
+	broker := memorybroker.New()
+	if err := broker.QueueDeclare(*conf.RabbitMQ.Queue); err != nil {
+		return err
+	}
+
+	notifier := app.NewLogNotifier(log)
+	sender := app.NewSender(calendarApp, broker, map[model.Channel]app.Notifier{
+		model.ChannelEmail:   notifier,
+		model.ChannelWebhook: notifier,
+		model.ChannelLog:     notifier,
+	}, log)
+	scheduler := app.NewScheduler(calendarApp, log, conf.RabbitMQ.Consume.Interval)
+	scheduler.SetMaxLateness(conf.Scheduler.MaxLateness)
+	relay := app.NewRelay(calendarApp, broker, *conf.RabbitMQ.Publish, *conf.Relay, log)
+
+	processes := map[string]func(context.Context) error{
+		"scheduler": scheduler.Start,
+		"relay":     relay.Start,
+		"sender":    sender.Start,
+	}
+	for name, start := range processes {
+		go func(name string, start func(context.Context) error) {
+			if err := start(ctx); err != nil {
+				log.Error("%s error: %s", name, err)
+			}
+		}(name, start)
+	}
+	return nil
+}
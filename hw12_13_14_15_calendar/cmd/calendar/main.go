@@ -15,8 +15,10 @@ import (
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
 	servergrpc "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc"
 	serverhttp "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/http"
 	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
@@ -27,6 +29,7 @@ import (
 var (
 	configPath  string
 	storageType string
+	devMode     bool
 
 	ErrorInvalidStorageType = errors.New("invalid storage type")
 )
@@ -35,6 +38,7 @@ func init() {
 	defaultConfigPath := path.Join("config", "calendar_config.toml")
 	flag.StringVar(&configPath, "config", defaultConfigPath, "Path to configuration file")
 	flag.StringVar(&storageType, "storage", "sql", "Type of storage. Expected values: \"memory\" || \"sql\"")
+	flag.BoolVar(&devMode, "dev", false, "Run scheduler and sender in process with an in-memory broker")
 }
 
 func main() {
@@ -79,13 +83,57 @@ func main() {
 	}
 
 	calendarApp := app.New(storage, *log)
-	httpServer := serverhttp.NewServer(log, calendarApp, conf.HTTPServer)
-	grpcServer := servergrpc.NewServer(log, calendarApp, conf.GRPCServer)
+
+	if flag.Arg(0) == "apikey" {
+		if err := runAPIKeyCommand(context.Background(), calendarApp, flag.Args()[1:], os.Stdout); err != nil {
+			log.Error("apikey: " + err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Приглашения сохраняются в outbox хранилища, откуда их публикует calendar_scheduler
+	// или, в режиме разработки, ретранслятор в процессе календаря.
+	if storageType == "memory" && !devMode {
+		log.Warn("outbox of memory storage is not shared with calendar_scheduler, invitations are not sent")
+	}
+
+	var authenticator server.Authenticator
+	if conf.Auth.KeyFile != "" {
+		verifier, err := auth.NewVerifier(conf.Auth)
+		if err != nil {
+			log.Error("failed to load auth key: " + err.Error())
+			return
+		}
+		authenticator = auth.NewAuthenticator(verifier, calendarApp)
+	} else {
+		log.Warn("auth.KeyFile is not set, requests are not authenticated")
+	}
+
+	httpServer := serverhttp.NewServer(log, calendarApp, conf.HTTPServer, authenticator)
+	grpcServer := servergrpc.NewServer(log, calendarApp, conf.GRPCServer, authenticator)
 
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
+	gateway, err := servergrpc.NewGateway(ctx, conf.GRPCServer)
+	if err != nil {
+		log.Error("failed to create gRPC gateway: " + err.Error())
+		return
+	}
+	httpServer.Mount(servergrpc.GatewayPrefix, gateway)
+
+	go calendarApp.ListenChanges(ctx)
+
+	if devMode {
+		if err := startDev(ctx, calendarApp, conf, log); err != nil {
+			log.Error("failed to start dev mode: " + err.Error())
+			return
+		}
+		log.Warn("dev mode: notifications are delivered in process and written to the log")
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 
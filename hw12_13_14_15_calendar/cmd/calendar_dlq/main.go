@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker/rabbitmq"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+)
+
+var (
+	configPath string
+	limit      int
+)
+
+func init() {
+	defaultConfigPath := path.Join("config", "sender_config.toml")
+	flag.StringVar(&configPath, "config", defaultConfigPath, "Path to configuration file")
+	flag.IntVar(&limit, "limit", 10, "Maximum number of dead-lettered messages to inspect or replay")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] list|replay\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "  list    print dead-lettered notifications, leaving them in the queue")
+		fmt.Fprintln(flag.CommandLine.Output(), "  replay  move dead-lettered notifications back to the notification queue")
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 || limit < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := config.LoadConfig(configPath); err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+	conf := config.Get()
+	queue := *conf.RabbitMQ.Queue
+	if queue.DeadLetterExchange == "" {
+		log.Fatalf("Dead letter exchange is not configured for queue %s", queue.Name)
+	}
+
+	command := flag.Arg(0)
+	if command != "list" && command != "replay" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rabbit := rabbitmq.New(*conf.RabbitMQ.Connection)
+	if err := rabbit.Start(); err != nil {
+		log.Fatalf("Error connecting to RabbitMQ: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, &rabbit, queue, command)
+	cancel()
+
+	if stopErr := rabbit.Stop(); stopErr != nil {
+		log.Printf("Error stopping RabbitMQ: %v", stopErr)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run выполняет команду list или replay над очередью недоставленных сообщений queue.
+func run(ctx context.Context, rabbit *rabbitmq.BrokerRabbit, queue config.QueueConfig, command string) error {
+	if command == "list" {
+		letters, err := rabbit.InspectDeadLetters(queue, limit)
+		if err != nil {
+			return fmt.Errorf("error inspecting dead letters: %w", err)
+		}
+		for i, letter := range letters {
+			fmt.Printf("%d. %s, %s after %d attempts\n%s\n\n",
+				i+1, letter.Time.Format(time.RFC3339), letter.Reason, letter.Attempts, letter.Body)
+		}
+		fmt.Printf("%d dead-lettered notifications in %s\n", len(letters), rabbitmq.DeadLetterQueue(queue.Name))
+		return nil
+	}
+
+	replayed, err := rabbit.ReplayDeadLetters(ctx, queue, limit)
+	fmt.Printf("%d notifications moved to %s\n", replayed, queue.Name)
+	if err != nil {
+		return fmt.Errorf("error replaying dead letters: %w", err)
+	}
+	return nil
+}
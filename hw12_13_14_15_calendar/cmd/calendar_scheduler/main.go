@@ -93,7 +93,9 @@ func main() {
 
 	l.Info("Creating new calendar app...")
 	calendarApp := app.New(storage, *l)
-	scheduler := app.NewScheduler(calendarApp, &rabbit, l, conf.RabbitMQ.Consume.Interval)
+	scheduler := app.NewScheduler(calendarApp, l, conf.RabbitMQ.Consume.Interval)
+	scheduler.SetMaxLateness(conf.Scheduler.MaxLateness)
+	relay := app.NewRelay(calendarApp, &rabbit, *conf.RabbitMQ.Publish, *conf.Relay, l)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -106,6 +108,14 @@ func main() {
 		cancel()
 	}()
 
+	l.Info("Starting outbox relay...")
+	go func() {
+		if err := relay.Start(ctx); err != nil {
+			l.Error("Relay error: " + err.Error())
+			cancel()
+		}
+	}()
+
 	l.Info("Starting scheduler...")
 	if err := scheduler.Start(ctx); err != nil {
 		l.Error("Scheduler error: " + err.Error())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -13,6 +14,10 @@ import (
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker/rabbitmq"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/mailer"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	sqlstorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/sql"
 )
 
 var (
@@ -37,6 +42,27 @@ func main() {
 	conf := config.Get()
 	l := logger.New(conf.Logger)
 
+	// Хранилище нужно, чтобы находить получателей и их настройки уведомлений.
+	var storage app.Storage
+	switch storageType {
+	case "memory":
+		storage = memorystorage.New()
+	case "sql":
+		dbConn := conf.Database
+		connString := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+			dbConn.UserName, dbConn.Password, dbConn.Host, dbConn.Port, dbConn.DatabaseName)
+
+		var err error
+		storage, err = sqlstorage.New(connString)
+		if err != nil {
+			l.Error("Failed to create SQL storage: " + err.Error())
+			return
+		}
+	default:
+		l.Error(ErrorInvalidStorageType.Error())
+		os.Exit(1)
+	}
+
 	l.Info("Connecting to RabbitMQ...")
 	rabbit := rabbitmq.New(*conf.RabbitMQ.Connection)
 	if err := rabbit.Start(); err != nil {
@@ -50,12 +76,27 @@ func main() {
 		}
 	}()
 
+	l.Info("Declaring RabbitMQ queue...")
+	if err := rabbit.QueueDeclare(*conf.RabbitMQ.Queue); err != nil {
+		l.Error("Error declaring queue: " + err.Error())
+		return
+	}
+
 	l.Info("Starting to consume messages from RabbitMQ...")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	sender := app.NewSender(&rabbit, l)
+	notifiers := map[model.Channel]app.Notifier{
+		model.ChannelEmail: app.NewEmailNotifier(mailer.NewSMTP(*conf.SMTP)),
+		model.ChannelLog:   app.NewLogNotifier(l),
+	}
+	if conf.Webhook.Secret != "" {
+		notifiers[model.ChannelWebhook] = app.NewWebhookNotifier(*conf.Webhook)
+	} else {
+		l.Warn("Webhook secret is not configured, webhook channel is disabled")
+	}
+	sender := app.NewSender(app.New(storage, *l), &rabbit, notifiers, l)
 
 	go func() {
 		c := make(chan os.Signal, 1)
@@ -31,13 +31,8 @@ func setupRabbitMQ(t *testing.T, log *logger.Logger) *rabbitmq.BrokerRabbit {
 	require.NoError(t, err)
 	log.Info("RabbitMQ started")
 
-	queueConfig := config.QueueConfig{
-		Name:       "test_queue",
-		Durable:    false,
-		AutoDelete: false,
-		Exclusive:  false,
-		NoWait:     false,
-	}
+	// Очередь объявляется с настройками повторов из конфигурации, как ее объявляют планировщик и рассыльщик.
+	queueConfig := *config.Get().RabbitMQ.Queue
 	err = broker.QueueDeclare(queueConfig)
 	require.NoError(t, err)
 	log.Info("Queue declared: %v", queueConfig)
@@ -69,7 +64,7 @@ func TestScheduler(t *testing.T) {
 	}()
 	t.Log("RabbitMQ set up")
 
-	scheduler := app.NewScheduler(application, broker, log, 1*time.Second)
+	scheduler := app.NewScheduler(application, log, 1*time.Second)
 	t.Log("Scheduler created")
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -95,7 +90,7 @@ func TestScheduler(t *testing.T) {
 		Email:     "test@test.com",
 		Age:       30,
 	}
-	err = application.CreateUser(ctx, user)
+	_, err = application.CreateUser(ctx, user)
 	require.NoError(t, err)
 	t.Logf("User created: %v", user)
 
@@ -114,7 +109,7 @@ func TestScheduler(t *testing.T) {
 		Notification: now.Add(3*time.Hour + 5*time.Second),
 		UserID:       userID,
 	}
-	err = application.CreateEvent(ctx, event)
+	_, err = application.CreateEvent(ctx, event)
 	require.NoError(t, err)
 	log.Info("Event created: %v", event)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	servergrpc "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc"
 	serverhttp "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/http"
 	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
 	"github.com/stretchr/testify/require"
@@ -24,14 +25,14 @@ func userCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address stri
 	defer mutex.Unlock()
 
 	// Create user
-	userData := `{"name": "testuser"}`
+	userData := `{"firstName": "testuser"}`
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/user",
 		bytes.NewBuffer([]byte(userData)))
 	require.Nil(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	require.Nil(t, err)
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
 	resp.Body.Close()
 
 	// Select users
@@ -62,14 +63,14 @@ func eventCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address str
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	eventData := `{"title": "testevent", "date": "2023-01-01"}`
+	eventData := `{"title": "testevent", "beginning": "2023-01-01T10:00:00Z", "finish": "2023-01-01T11:00:00Z"}`
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
 		bytes.NewBuffer([]byte(eventData)))
 	require.Nil(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	require.Nil(t, err)
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
 	resp.Body.Close()
 
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, address+"/select/events", nil)
@@ -93,6 +94,21 @@ func eventCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address str
 	resp.Body.Close()
 }
 
+// mountGateway подключает к HTTP серверу шлюз gRPC, который обслуживает маршруты REST API,
+// и возвращает еще не запущенный gRPC сервер.
+func mountGateway(ctx context.Context, t *testing.T, log *logger.Logger, application *app.Calendar,
+	serv *serverhttp.Server,
+) *servergrpc.Server {
+	t.Helper()
+	grpcConfig := config.ServerConfig{Host: "localhost", Port: "50051"}
+	grpcServ := servergrpc.NewServer(log, application, &grpcConfig, nil)
+
+	gateway, err := servergrpc.NewGateway(ctx, &grpcConfig)
+	require.Nil(t, err)
+	serv.Mount(servergrpc.GatewayPrefix, gateway)
+	return grpcServ
+}
+
 func TestServer(t *testing.T) {
 	logConfig := config.LoggerConfig{
 		Level: "info",
@@ -109,11 +125,19 @@ func TestServer(t *testing.T) {
 		Port: port,
 	}
 
-	serv := serverhttp.NewServer(log, application, &servConfig)
+	serv := serverhttp.NewServer(log, application, &servConfig, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	grpcServ := mountGateway(ctx, t, log, application, serv)
 
 	wg := sync.WaitGroup{}
-	wg.Add(1)
+	wg.Add(2)
 
+	go func() {
+		defer wg.Done()
+		_ = grpcServ.Start()
+	}()
 	go func() {
 		defer wg.Done()
 		_ = serv.Start()
@@ -121,15 +145,14 @@ func TestServer(t *testing.T) {
 
 	mutex := sync.Mutex{}
 	address := "http://" + net.JoinHostPort(host, port)
-	ctx := context.Background()
 
 	time.Sleep(1 * time.Second)
 	userCase(ctx, t, &mutex, address)
 	time.Sleep(1 * time.Second)
 	eventCase(ctx, t, &mutex, address)
 
-	err := serv.Stop(ctx)
-	require.Nil(t, err)
+	require.Nil(t, serv.Stop(ctx))
+	require.Nil(t, grpcServ.Stop(ctx))
 
 	wg.Wait()
 }
@@ -143,14 +166,14 @@ func eventForPeriodCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, ad
 
 	eventData := `{"title": "testevent", "description": "this is a test event", 
 "beginning": "2024-06-09T12:00:00Z", "finish": "2024-06-09T14:00:00Z", "notification": "2024-06-09T11:00:00Z", 
-"userid": "1"}`
+"userId": "1"}`
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
 		bytes.NewBuffer([]byte(eventData)))
 	require.Nil(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	require.Nil(t, err)
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
 	resp.Body.Close()
 
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, address+endpoint+"?"+queryParam, nil)
@@ -190,11 +213,19 @@ func TestServerTwo(t *testing.T) {
 		Port: port,
 	}
 
-	serv := serverhttp.NewServer(log, application, &servConfig)
+	serv := serverhttp.NewServer(log, application, &servConfig, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	grpcServ := mountGateway(ctx, t, log, application, serv)
 
 	wg := sync.WaitGroup{}
-	wg.Add(1)
+	wg.Add(2)
 
+	go func() {
+		defer wg.Done()
+		_ = grpcServ.Start()
+	}()
 	go func() {
 		defer wg.Done()
 		_ = serv.Start()
@@ -202,7 +233,6 @@ func TestServerTwo(t *testing.T) {
 
 	mutex := sync.Mutex{}
 	address := "http://" + net.JoinHostPort(host, port)
-	ctx := context.Background()
 
 	time.Sleep(1 * time.Second)
 	userCase(ctx, t, &mutex, address)
@@ -216,8 +246,8 @@ func TestServerTwo(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	eventForPeriodCase(ctx, t, &mutex, address, "/select/events/month", "startDate=2024-06-01")
 
-	err := serv.Stop(ctx)
-	require.Nil(t, err)
+	require.Nil(t, serv.Stop(ctx))
+	require.Nil(t, grpcServ.Stop(ctx))
 
 	wg.Wait()
 }
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// CreateAPIKey выпускает ключ API сервисного клиента с областями доступа scopes.
+// Возвращает ключ, который больше нигде не сохраняется, и запись о ключе с его хешем.
+// Некорректные имя и области доступа возвращаются в *ValidationError.
+func (calendar *Calendar) CreateAPIKey(ctx context.Context, name string, scopes []string) (
+	string, model.APIKey, error,
+) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return "", model.APIKey{}, err
+	}
+	if err := validateAPIKey(name, scopes); err != nil {
+		return "", model.APIKey{}, err
+	}
+
+	key, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", model.APIKey{}, err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	created, err := calendar.storage.CreateAPIKey(ctx, model.APIKey{
+		Name:   strings.TrimSpace(name),
+		Hash:   auth.HashAPIKey(key),
+		Scopes: scopes,
+	})
+	if err != nil {
+		return "", model.APIKey{}, err
+	}
+	return key, created, nil
+}
+
+// SelectAPIKeys получение выпущенных ключей API, включая отозванные.
+func (calendar *Calendar) SelectAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SelectAPIKeys(ctx)
+}
+
+// RevokeAPIKey отзыв ключа API. Если ключа нет, возвращает model.ErrAPIKeyNotFound.
+func (calendar *Calendar) RevokeAPIKey(ctx context.Context, id string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.RevokeAPIKey(ctx, id)
+}
+
+// AuthenticateAPIKey проверяет ключ API и возвращает сервисного клиента с областями доступа ключа.
+// Сервисному клиенту доступны данные всех пользователей в пределах его областей доступа.
+func (calendar *Calendar) AuthenticateAPIKey(ctx context.Context, key string) (auth.Principal, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	apiKey, err := calendar.storage.SelectAPIKeyByHash(ctx, auth.HashAPIKey(key))
+	if errors.Is(err, model.ErrAPIKeyNotFound) {
+		return auth.Principal{}, fmt.Errorf("%w: unknown api key", auth.ErrUnauthenticated)
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	if apiKey.Revoked() {
+		return auth.Principal{}, fmt.Errorf("%w: api key %s is revoked", auth.ErrUnauthenticated, apiKey.ID)
+	}
+
+	scopes := append(make([]string, 0, len(apiKey.Scopes)), apiKey.Scopes...)
+	return auth.Principal{KeyID: apiKey.ID, Admin: true, Scopes: scopes}, nil
+}
+
+// validateAPIKey проверяет имя и области доступа нового ключа API.
+func validateAPIKey(name string, scopes []string) error {
+	v := &ValidationError{}
+	if strings.TrimSpace(name) == "" {
+		v.add("name", "must not be blank")
+	}
+	if len(scopes) == 0 {
+		v.add("scopes", "must not be empty")
+	}
+	for i, scope := range scopes {
+		if !knownScope(scope) {
+			v.add(fmt.Sprintf("scopes[%d]", i), fmt.Sprintf("unknown scope %q", scope))
+		}
+	}
+	return v.err()
+}
+
+func knownScope(scope string) bool {
+	for _, known := range auth.Scopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarAPIKeys(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	ctx := context.Background()
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, _, err := calendar.CreateAPIKey(ctx, " ", nil)
+		require.Equal(t, []string{"name", "scopes"}, fieldNames(t, err))
+		_, _, err = calendar.CreateAPIKey(ctx, "batch", []string{auth.ScopeEventsRead, "events:delete"})
+		require.Equal(t, []string{"scopes[1]"}, fieldNames(t, err))
+	})
+
+	t.Run("only admin manages keys", func(t *testing.T) {
+		user := auth.WithPrincipal(ctx, auth.Principal{UserID: "alice"})
+		_, _, err := calendar.CreateAPIKey(user, "batch", []string{auth.ScopeEventsRead})
+		require.ErrorIs(t, err, ErrForbidden)
+		_, err = calendar.SelectAPIKeys(user)
+		require.ErrorIs(t, err, ErrForbidden)
+		require.ErrorIs(t, calendar.RevokeAPIKey(user, "id"), ErrForbidden)
+	})
+
+	t.Run("create, authenticate and revoke", func(t *testing.T) {
+		scopes := []string{auth.ScopeEventsRead, auth.ScopeEventsWrite}
+		key, created, err := calendar.CreateAPIKey(ctx, "batch", scopes)
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, auth.HashAPIKey(key), created.Hash)
+
+		principal, err := calendar.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, auth.Principal{KeyID: created.ID, Admin: true, Scopes: scopes}, principal)
+
+		_, err = calendar.AuthenticateAPIKey(ctx, key+"x")
+		require.ErrorIs(t, err, auth.ErrUnauthenticated)
+
+		require.NoError(t, calendar.RevokeAPIKey(ctx, created.ID))
+		_, err = calendar.AuthenticateAPIKey(ctx, key)
+		require.ErrorIs(t, err, auth.ErrUnauthenticated)
+		require.ErrorIs(t, calendar.RevokeAPIKey(ctx, "unknown"), model.ErrAPIKeyNotFound)
+
+		keys, err := calendar.SelectAPIKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.True(t, keys[0].Revoked())
+	})
+
+	t.Run("api key acts on behalf of any user", func(t *testing.T) {
+		key, _, err := calendar.CreateAPIKey(ctx, "import", []string{auth.ScopeEventsWrite})
+		require.NoError(t, err)
+		principal, err := calendar.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err)
+		service := auth.WithPrincipal(ctx, principal)
+
+		count, _, err := calendar.ImportEvents(service, "alice", []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
@@ -2,33 +2,136 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/ical"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 )
 
+// changesRetryDelay пауза перед повторной подпиской на изменения в хранилище после ошибки.
+const changesRetryDelay = time.Second
+
 type Calendar struct {
 	storage Storage
 	logger  logger.Logger
 	mutex   sync.RWMutex
+	changes *ChangeFeed
 }
 
 type Storage interface {
-	CreateUser(ctx context.Context, User model.User) error
+	CreateUser(ctx context.Context, User model.User) (model.User, error)
 	SelectUsers(ctx context.Context) ([]model.User, error)
+	// ListUsers возвращает страницу пользователей и последнего пользователя страницы,
+	// если есть следующая страница, иначе nil.
+	ListUsers(ctx context.Context, query model.UserQuery) ([]model.User, *model.User, error)
 	DeleteUser(ctx context.Context, id string) error
 
-	CreateEvent(ctx context.Context, Event model.Event) error
+	CreateEvent(ctx context.Context, Event model.Event) (model.Event, error)
+	// ImportEvents создает события в одной транзакции: при ошибке не создается ни одно.
+	// Событие, UID которого совпадает с UID или идентификатором события того же пользователя,
+	// пропускается. Возвращает созданные события и число пропущенных.
+	ImportEvents(ctx context.Context, events []model.Event) ([]model.Event, int, error)
 	SelectEvents(ctx context.Context) ([]model.Event, error)
+	SelectEvent(ctx context.Context, id string) (model.Event, error)
+	// ListEvents возвращает страницу событий и последнее событие страницы, если есть следующая страница,
+	// иначе nil. С интервалом времени повторяющиеся события разворачиваются в повторения.
+	ListEvents(ctx context.Context, query model.EventQuery) ([]model.Event, *model.Event, error)
+	// SearchEvents возвращает события, содержащие все слова запроса, в порядке убывания релевантности.
+	SearchEvents(ctx context.Context, query model.SearchQuery) ([]model.SearchResult, error)
 	UpdateEvent(ctx context.Context, Event model.Event) error
 	DeleteEvent(ctx context.Context, id string) error
 
 	SelectEventsByTime(context.Context, time.Time) ([]model.Event, error)
+	// SelectDueNotifications возвращает повторения событий, время уведомления о которых наступило
+	// к моменту now, а уведомление еще не отправлено и не устарело к since
+	// (см. model.Event.PendingNotifications). События без таких уведомлений не загружаются целиком.
+	SelectDueNotifications(ctx context.Context, now, since time.Time) ([]model.Event, error)
+	// MarkNotified отмечает отправленными уведомления события со временем не позже notification
+	// и в той же транзакции добавляет в outbox сообщения outbox. Отметка не сдвигается назад
+	// и не возвращает ошибку, если события уже нет, сообщения при этом не добавляются.
+	MarkNotified(ctx context.Context, eventID string, notification time.Time, outbox ...model.OutboxMessage) error
 	SelectEventsForDay(ctx context.Context, date time.Time) ([]model.Event, error)
 	SelectEventsForWeek(ctx context.Context, startDate time.Time) ([]model.Event, error)
 	SelectEventsForMonth(ctx context.Context, startDate time.Time) ([]model.Event, error)
+
+	CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+	SelectAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	SelectAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+
+	// CreateCalendar сохраняет календарь без ACL, возвращает model.ErrUserNotFound, если владельца нет.
+	CreateCalendar(ctx context.Context, calendar model.Calendar) (model.Calendar, error)
+	// SelectCalendar возвращает календарь вместе с ACL.
+	SelectCalendar(ctx context.Context, id string) (model.Calendar, error)
+	// SelectCalendars возвращает календари вместе с ACL, которыми владеет пользователь userID
+	// или к которым ему выдан доступ, в порядке названия. Пустой userID - все календари.
+	SelectCalendars(ctx context.Context, userID string) ([]model.Calendar, error)
+	// UpdateCalendar изменяет название, часовой пояс и цвет календаря.
+	UpdateCalendar(ctx context.Context, calendar model.Calendar) error
+	// DeleteCalendar удаляет календарь вместе с его событиями и ACL.
+	DeleteCalendar(ctx context.Context, id string) error
+	// SetAccess выдает пользователю доступ к календарю или меняет его роль.
+	SetAccess(ctx context.Context, entry model.ACLEntry) error
+	// RevokeAccess отзывает доступ, возвращает model.ErrAccessNotFound, если доступа не было.
+	RevokeAccess(ctx context.Context, calendarID, userID string) error
+
+	// SelectAttendees возвращает участников события, model.ErrEventNotFound, если события нет.
+	SelectAttendees(ctx context.Context, eventID string) ([]model.Attendee, error)
+	// SelectAttendances возвращает участие пользователя в событиях.
+	SelectAttendances(ctx context.Context, userID string) ([]model.Attendee, error)
+	// SetAttendee добавляет участника события или меняет его статус
+	// и в той же транзакции добавляет в outbox сообщения outbox.
+	SetAttendee(ctx context.Context, attendee model.Attendee, outbox ...model.OutboxMessage) error
+	// DeleteAttendee удаляет участника, возвращает model.ErrAttendeeNotFound, если его не было.
+	DeleteAttendee(ctx context.Context, eventID, userID string) error
+
+	// SelectBusy возвращает интервалы занятости пользователей в [from, to) с развернутыми повторениями,
+	// model.ErrUserNotFound, если одного из пользователей нет.
+	SelectBusy(ctx context.Context, userIDs []string, from, to time.Time) ([]model.BusyInterval, error)
+	// SelectWorkingHours возвращает рабочее время тех пользователей из userIDs, у которых оно задано.
+	SelectWorkingHours(ctx context.Context, userIDs []string) ([]model.WorkingHours, error)
+	// SetWorkingHours задает или заменяет рабочее время, model.ErrUserNotFound, если пользователя нет.
+	SetWorkingHours(ctx context.Context, hours model.WorkingHours) error
+	// DeleteWorkingHours удаляет рабочее время, model.ErrWorkingHoursNotFound, если его не было.
+	DeleteWorkingHours(ctx context.Context, userID string) error
+
+	// SelectNotificationSettings возвращает настройки уведомлений пользователя,
+	// model.ErrNotificationSettingsNotFound, если они не заданы.
+	SelectNotificationSettings(ctx context.Context, userID string) (model.NotificationSettings, error)
+	// SetNotificationSettings задает или заменяет настройки уведомлений, model.ErrUserNotFound, если пользователя нет.
+	SetNotificationSettings(ctx context.Context, settings model.NotificationSettings) error
+	// DeleteNotificationSettings удаляет настройки уведомлений,
+	// model.ErrNotificationSettingsNotFound, если их не было.
+	DeleteNotificationSettings(ctx context.Context, userID string) error
+
+	// SelectDueReminders возвращает напоминания со временем FireAt не позже t вместе с их событиями.
+	SelectDueReminders(ctx context.Context, t time.Time) ([]model.DueReminder, error)
+	// ClaimReminder заменяет напоминание current на next, если его FireAt не изменился,
+	// и сообщает, удалось ли это. Так напоминание отправляет только один планировщик.
+	// Если напоминание забрано, в той же транзакции в outbox добавляются сообщения outbox.
+	ClaimReminder(ctx context.Context, current, next model.Reminder, outbox ...model.OutboxMessage) (bool, error)
+
+	// RelayOutbox передает publish до limit первых сообщений outbox в порядке добавления и удаляет
+	// опубликованные. Останавливается на первой ошибке publish и возвращает ее вместе с числом
+	// опубликованных сообщений. Сообщения, которые передает другой ретранслятор, пропускаются.
+	RelayOutbox(ctx context.Context, limit int, publish func(model.OutboxMessage) error) (int, error)
+	// SelectDeliveredChannels возвращает каналы, по которым доставлено сообщение с ключом key.
+	SelectDeliveredChannels(ctx context.Context, key string) ([]model.Channel, error)
+	// MarkDelivered запоминает доставку сообщения с ключом key по каналу channel в момент at.
+	MarkDelivered(ctx context.Context, key string, channel model.Channel, at time.Time) error
+	// DeleteDeliveries забывает доставки, сделанные раньше before.
+	DeleteDeliveries(ctx context.Context, before time.Time) error
+}
+
+// ChangeNotifier хранилище, которое само сообщает об изменениях событий,
+// в том числе сделанных другими экземплярами сервиса.
+type ChangeNotifier interface {
+	// ListenEventChanges передает изменения в handle до отмены ctx или ошибки соединения.
+	ListenEventChanges(ctx context.Context, handle func(model.EventChange)) error
 }
 
 func New(storage Storage, l logger.Logger) *Calendar {
@@ -36,11 +139,76 @@ func New(storage Storage, l logger.Logger) *Calendar {
 		storage: storage,
 		logger:  l,
 		mutex:   sync.RWMutex{},
+		changes: NewChangeFeed(),
+	}
+}
+
+// ListenChanges пересылает в ленту изменений уведомления хранилища, реализующего ChangeNotifier,
+// и переподключается после ошибок. Блокируется до отмены ctx.
+// Для остальных хранилищ изменения публикуются самим Calendar, и метод сразу возвращается.
+func (calendar *Calendar) ListenChanges(ctx context.Context) {
+	notifier, ok := calendar.storage.(ChangeNotifier)
+	if !ok {
+		return
+	}
+
+	for {
+		err := notifier.ListenEventChanges(ctx, calendar.changes.Publish)
+		if ctx.Err() != nil {
+			return
+		}
+		calendar.logger.Error("failed to listen event changes: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(changesRetryDelay):
+		}
+	}
+}
+
+// WatchEvents подписка на изменения событий пользователя userID (пустой - всех доступных событий),
+// которые начинаются в период [from, to). Нулевые границы снимают ограничение по времени.
+// Непустой lastChangeID возобновляет подписку после изменения с этим идентификатором.
+// Канал закрывается при отмене ctx или если подписчик не успевает читать изменения.
+func (calendar *Calendar) WatchEvents(ctx context.Context, userID string, from, to time.Time, lastChangeID string) (
+	<-chan model.EventChange, error,
+) {
+	v := &ValidationError{}
+	if from.IsZero() != to.IsZero() {
+		v.add("from", "must be set together with to")
+	} else if !from.IsZero() && !from.Before(to) {
+		v.add("to", "must be after from")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	visibility, err := calendar.eventVisibility(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ChangeFilter{UserID: userID, From: from, To: to, Visibility: visibility}
+	return calendar.changes.Subscribe(ctx, filter, lastChangeID), nil
+}
+
+// publishChange публикует изменение события, если хранилище не сообщает об изменениях само.
+func (calendar *Calendar) publishChange(changeType model.ChangeType, event model.Event) {
+	if _, ok := calendar.storage.(ChangeNotifier); ok {
+		return
 	}
+	calendar.changes.Publish(model.EventChange{Type: changeType, Event: event})
 }
 
-// CreateUser создание пользователя.
-func (calendar *Calendar) CreateUser(ctx context.Context, user model.IUser) error {
+// CreateUser создание пользователя, возвращает сохраненного пользователя с идентификатором.
+// Создавать пользователей может только администратор.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+func (calendar *Calendar) CreateUser(ctx context.Context, user model.IUser) (model.IUser, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	calendar.mutex.Lock()
 	defer calendar.mutex.Unlock()
 
@@ -50,12 +218,21 @@ func (calendar *Calendar) CreateUser(ctx context.Context, user model.IUser) erro
 		LastName:  user.GetLastName(),
 		Email:     user.GetEmail(),
 		Age:       user.GetAge(),
+		TimeZone:  user.GetTimeZone(),
+	}
+
+	if err := ValidateUser(storageUser); err != nil {
+		return nil, err
 	}
 
-	return calendar.storage.CreateUser(ctx, storageUser)
+	created, err := calendar.storage.CreateUser(ctx, storageUser)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
-// SelectUsers получение пользователей.
+// SelectUsers получение пользователей. Обычному пользователю возвращается только его учетная запись.
 func (calendar *Calendar) SelectUsers(ctx context.Context) ([]model.IUser, error) {
 	calendar.mutex.RLock()
 	defer calendar.mutex.RUnlock()
@@ -67,7 +244,11 @@ func (calendar *Calendar) SelectUsers(ctx context.Context) ([]model.IUser, error
 		return users, err
 	}
 
+	principal, restrict := restricted(ctx)
 	for _, storageUser := range storageUsers {
+		if restrict && storageUser.ID != principal.UserID {
+			continue
+		}
 		user := storageUser
 		users = append(users, &user)
 	}
@@ -75,16 +256,54 @@ func (calendar *Calendar) SelectUsers(ctx context.Context) ([]model.IUser, error
 	return users, nil
 }
 
-// DeleteUser удаление пользователя.
+// SelectUser возвращает пользователя по идентификатору или model.ErrUserNotFound.
+// Обычному пользователю доступна только его учетная запись.
+func (calendar *Calendar) SelectUser(ctx context.Context, id string) (model.IUser, error) {
+	if err := authorize(ctx, id); err != nil {
+		return nil, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	user, err := calendar.selectUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// selectUser возвращает пользователя из хранилища или model.ErrUserNotFound.
+func (calendar *Calendar) selectUser(ctx context.Context, id string) (model.User, error) {
+	users, _, err := calendar.storage.ListUsers(ctx, model.UserQuery{UserID: id, PageSize: 1})
+	if err != nil {
+		return model.User{}, err
+	}
+	if len(users) == 0 {
+		return model.User{}, model.ErrUserNotFound
+	}
+	return users[0], nil
+}
+
+// DeleteUser удаление пользователя. Обычный пользователь может удалить только себя.
 func (calendar *Calendar) DeleteUser(ctx context.Context, id string) error {
+	if err := authorize(ctx, id); err != nil {
+		return err
+	}
+
 	calendar.mutex.Lock()
 	defer calendar.mutex.Unlock()
 
 	return calendar.storage.DeleteUser(ctx, id)
 }
 
-// CreateEvent создание события.
-func (calendar *Calendar) CreateEvent(ctx context.Context, event model.IEvent) error {
+// CreateEvent создание события, возвращает сохраненное событие с идентификатором.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+// Если время события пересекается с другим событием пользователя, возвращает model.ErrDateBusy.
+// Событие без владельца создается от имени пользователя из контекста.
+// Для создания события в календаре нужна роль model.RoleWrite.
+// Событие без часового пояса получает часовой пояс календаря, а личное событие - часовой пояс владельца.
+func (calendar *Calendar) CreateEvent(ctx context.Context, event model.IEvent) (model.IEvent, error) {
 	calendar.mutex.Lock()
 	defer calendar.mutex.Unlock()
 
@@ -96,12 +315,62 @@ func (calendar *Calendar) CreateEvent(ctx context.Context, event model.IEvent) e
 		Beginning:    event.GetBeginning(),
 		Finish:       event.GetFinish(),
 		Notification: event.GetNotification(),
+		Recurrence:   event.GetRecurrence(),
+		ExDates:      event.GetExDates(),
+		CalendarID:   event.GetCalendarID(),
+		TimeZone:     event.GetTimeZone(),
+		Reminders:    event.GetReminders(),
+	}
+
+	if storageEvent.UserID == "" {
+		if principal, ok := auth.FromContext(ctx); ok && principal.UserID != "" {
+			storageEvent.UserID = principal.UserID
+		}
+	}
+	if err := authorize(ctx, storageEvent.UserID); err != nil {
+		return nil, err
 	}
 
-	return calendar.storage.CreateEvent(ctx, storageEvent)
+	if err := ValidateEvent(storageEvent, false); err != nil {
+		return nil, err
+	}
+
+	if storageEvent.CalendarID != "" {
+		eventCalendar, err := calendar.authorizeCalendar(ctx, storageEvent.CalendarID, model.RoleWrite)
+		if err != nil {
+			return nil, err
+		}
+		if storageEvent.TimeZone == "" {
+			storageEvent.TimeZone = eventCalendar.TimeZone
+		}
+	}
+	if storageEvent.TimeZone == "" {
+		timeZone, err := calendar.userTimeZone(ctx, storageEvent.UserID)
+		if err != nil {
+			return nil, err
+		}
+		storageEvent.TimeZone = timeZone
+	}
+	now := time.Now()
+	if err := scheduleReminders(&storageEvent, model.Event{}, now); err != nil {
+		return nil, err
+	}
+	storageEvent.Notified = storageEvent.InitialNotified(now)
+
+	created, err := calendar.storage.CreateEvent(ctx, storageEvent)
+	if err != nil {
+		return nil, err
+	}
+	calendar.publishChange(model.ChangeCreated, created)
+	return &created, nil
 }
 
-// UpdateEvent обновление события.
+// UpdateEvent обновление события. Обычный пользователь может изменять свои события и события
+// календарей с ролью model.RoleWrite, но не может передать событие другому пользователю.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+// Если время события пересекается с другим событием пользователя, возвращает model.ErrDateBusy.
+// Напоминания заменяются переданными. Если время и повторение события не изменились, уже
+// запланированные напоминания сохраняются, остальные планируются от текущего момента.
 func (calendar *Calendar) UpdateEvent(ctx context.Context, event model.IEvent) error {
 	calendar.mutex.Lock()
 	defer calendar.mutex.Unlock()
@@ -114,97 +383,218 @@ func (calendar *Calendar) UpdateEvent(ctx context.Context, event model.IEvent) e
 		Beginning:    event.GetBeginning(),
 		Finish:       event.GetFinish(),
 		Notification: event.GetNotification(),
+		Recurrence:   event.GetRecurrence(),
+		ExDates:      event.GetExDates(),
+		CalendarID:   event.GetCalendarID(),
+		TimeZone:     event.GetTimeZone(),
+		Reminders:    event.GetReminders(),
+	}
+
+	if err := ValidateEvent(storageEvent, true); err != nil {
+		return err
+	}
+
+	current, err := calendar.storage.SelectEvent(ctx, storageEvent.ID)
+	if err != nil {
+		return err
+	}
+	if _, ok := restricted(ctx); ok {
+		if err := calendar.authorizeEvent(ctx, current, model.RoleWrite); err != nil {
+			return err
+		}
+		if storageEvent.UserID != current.UserID {
+			return ErrForbidden
+		}
+		if storageEvent.CalendarID != "" && storageEvent.CalendarID != current.CalendarID {
+			if _, err := calendar.authorizeCalendar(ctx, storageEvent.CalendarID, model.RoleWrite); err != nil {
+				return err
+			}
+		}
 	}
 
-	return calendar.storage.UpdateEvent(ctx, storageEvent)
+	now := time.Now()
+	if err := scheduleReminders(&storageEvent, current, now); err != nil {
+		return err
+	}
+	// Хранилище оставляет прежнюю отметку, если время и повторение события не изменились.
+	storageEvent.Notified = storageEvent.InitialNotified(now)
+
+	if err := calendar.storage.UpdateEvent(ctx, storageEvent); err != nil {
+		return err
+	}
+	calendar.publishChange(model.ChangeUpdated, storageEvent)
+	return nil
 }
 
-// DeleteEvent удаление события.
+// DeleteEvent удаление события. Обычный пользователь может удалять свои события
+// и события календарей с ролью model.RoleWrite.
 func (calendar *Calendar) DeleteEvent(ctx context.Context, id string) error {
 	calendar.mutex.Lock()
 	defer calendar.mutex.Unlock()
 
-	return calendar.storage.DeleteEvent(ctx, id)
+	// Подписчики фильтруют удаления по владельцу и времени, поэтому нужно последнее состояние события.
+	event, err := calendar.storage.SelectEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := calendar.authorizeEvent(ctx, event, model.RoleWrite); err != nil {
+		return err
+	}
+
+	if err := calendar.storage.DeleteEvent(ctx, id); err != nil {
+		return err
+	}
+	calendar.publishChange(model.ChangeDeleted, event)
+	return nil
 }
 
-// SelectEvents получение событий.
+// SelectEvents получение событий, доступных пользователю из контекста.
 func (calendar *Calendar) SelectEvents(ctx context.Context) ([]model.IEvent, error) {
 	calendar.mutex.RLock()
 	defer calendar.mutex.RUnlock()
 
-	events := make([]model.IEvent, 0)
+	visibility, err := calendar.visibility(ctx)
+	if err != nil {
+		return make([]model.IEvent, 0), err
+	}
 
 	storageEvents, err := calendar.storage.SelectEvents(ctx)
 	if err != nil {
-		return events, err
+		return make([]model.IEvent, 0), err
+	}
+
+	return visibleEvents(visibility, storageEvents), nil
+}
+
+// SelectEvent получение события по идентификатору.
+// Если события нет, возвращает model.ErrEventNotFound, если событие недоступно - ErrForbidden.
+func (calendar *Calendar) SelectEvent(ctx context.Context, id string) (model.IEvent, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	event, err := calendar.storage.SelectEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := calendar.authorizeEvent(ctx, event, model.RoleRead); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// ExportEvents выгрузка событий пользователя в формате iCalendar.
+func (calendar *Calendar) ExportEvents(ctx context.Context, userID string) ([]byte, error) {
+	if err := authorize(ctx, userID); err != nil {
+		return nil, err
 	}
 
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	storageEvents, err := calendar.storage.SelectEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]model.Event, 0)
 	for _, storageEvent := range storageEvents {
-		event := storageEvent
-		events = append(events, &event)
+		if storageEvent.UserID == userID {
+			events = append(events, storageEvent)
+		}
 	}
 
-	return events, nil
+	return ical.Marshal(events), nil
+}
+
+// ImportEvents загрузка событий из iCalendar от имени пользователя, возвращает число созданных
+// и пропущенных событий. События создаются либо все, либо ни одно. Событие, уже загруженное
+// из календаря с тем же UID или выгруженное из этого календаря, пропускается, поэтому
+// повторная загрузка того же файла не создает дубликатов.
+func (calendar *Calendar) ImportEvents(ctx context.Context, userID string, data []byte) (int, int, error) {
+	if err := authorize(ctx, userID); err != nil {
+		return 0, 0, err
+	}
+
+	events, err := ical.Unmarshal(data)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := time.Now()
+	for i := range events {
+		events[i].UserID = userID
+		events[i].Notified = events[i].InitialNotified(now)
+	}
+	if err := ValidateEvents(events); err != nil {
+		return 0, 0, err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	created, skipped, err := calendar.storage.ImportEvents(ctx, events)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, event := range created {
+		calendar.publishChange(model.ChangeCreated, event)
+	}
+	return len(created), skipped, nil
 }
 
-// SelectEventsForDay получение событий на указанный день.
+// SelectEventsForDay получение доступных событий на день, в который попадает date в своем часовом поясе.
+// Часовой пояс запроса определяет Location.
 func (calendar *Calendar) SelectEventsForDay(ctx context.Context, date time.Time) ([]model.IEvent, error) {
 	calendar.mutex.RLock()
 	defer calendar.mutex.RUnlock()
 
-	events := make([]model.IEvent, 0)
-
-	storageEvents, err := calendar.storage.SelectEventsForDay(ctx, date)
+	visibility, err := calendar.visibility(ctx)
 	if err != nil {
-		return events, err
+		return make([]model.IEvent, 0), err
 	}
 
-	for _, storageEvent := range storageEvents {
-		event := storageEvent
-		events = append(events, &event)
+	storageEvents, err := calendar.storage.SelectEventsForDay(ctx, date)
+	if err != nil {
+		return make([]model.IEvent, 0), err
 	}
 
-	return events, nil
+	return visibleEvents(visibility, storageEvents), nil
 }
 
-// SelectEventsForWeek получение событий на указанную неделю.
+// SelectEventsForWeek получение доступных событий на неделю, начиная с startDate.
 func (calendar *Calendar) SelectEventsForWeek(ctx context.Context, startDate time.Time) ([]model.IEvent, error) {
 	calendar.mutex.RLock()
 	defer calendar.mutex.RUnlock()
 
-	events := make([]model.IEvent, 0)
-
-	storageEvents, err := calendar.storage.SelectEventsForWeek(ctx, startDate)
+	visibility, err := calendar.visibility(ctx)
 	if err != nil {
-		return events, err
+		return make([]model.IEvent, 0), err
 	}
 
-	for _, storageEvent := range storageEvents {
-		event := storageEvent
-		events = append(events, &event)
+	storageEvents, err := calendar.storage.SelectEventsForWeek(ctx, startDate)
+	if err != nil {
+		return make([]model.IEvent, 0), err
 	}
 
-	return events, nil
+	return visibleEvents(visibility, storageEvents), nil
 }
 
-// SelectEventsForMonth получение событий на указанный месяц.
+// SelectEventsForMonth получение доступных событий на месяц, начиная с startDate.
 func (calendar *Calendar) SelectEventsForMonth(ctx context.Context, startDate time.Time) ([]model.IEvent, error) {
 	calendar.mutex.RLock()
 	defer calendar.mutex.RUnlock()
 
-	events := make([]model.IEvent, 0)
-
-	storageEvents, err := calendar.storage.SelectEventsForMonth(ctx, startDate)
+	visibility, err := calendar.visibility(ctx)
 	if err != nil {
-		return events, err
+		return make([]model.IEvent, 0), err
 	}
 
-	for _, storageEvent := range storageEvents {
-		event := storageEvent
-		events = append(events, &event)
+	storageEvents, err := calendar.storage.SelectEventsForMonth(ctx, startDate)
+	if err != nil {
+		return make([]model.IEvent, 0), err
 	}
 
-	return events, nil
+	return visibleEvents(visibility, storageEvents), nil
 }
 
 // SelectEventsByTime возвращает список событий, которые должны быть уведомлены в указанное время.
@@ -228,3 +618,37 @@ func (calendar *Calendar) SelectEventsByTime(ctx context.Context, t time.Time) (
 	calendar.logger.Info("Events selected by time: %d", len(events))
 	return events, nil
 }
+
+// DueNotifications возвращает повторения событий, уведомления о которых наступили к моменту now,
+// но еще не отправлены. Уведомления не позже since устарели и пропускаются, нулевой since
+// не ограничивает опоздание. Уведомление выбирается повторно, пока не отмечено методом EnqueueNotification.
+func (calendar *Calendar) DueNotifications(ctx context.Context, now, since time.Time) ([]model.Event, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SelectDueNotifications(ctx, now, since)
+}
+
+// EnqueueNotification отмечает отправленным уведомление о повторении события occurrence
+// и в той же транзакции добавляет его в outbox, откуда его публикует Relay.
+func (calendar *Calendar) EnqueueNotification(ctx context.Context, occurrence model.Event) error {
+	body, err := json.Marshal(model.Event{
+		ID:           occurrence.ID,
+		Title:        occurrence.Title,
+		Description:  occurrence.Description,
+		Beginning:    occurrence.Beginning,
+		Finish:       occurrence.Finish,
+		Notification: occurrence.Notification,
+		UserID:       occurrence.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.MarkNotified(ctx, occurrence.ID, occurrence.Notification, model.OutboxMessage{
+		Key: model.NotificationKey(occurrence), Body: body,
+	})
+}
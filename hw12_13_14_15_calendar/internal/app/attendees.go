@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectAttendees получение участников события, требуется доступ к событию на чтение.
+func (calendar *Calendar) SelectAttendees(ctx context.Context, eventID string) ([]model.Attendee, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	event, err := calendar.storage.SelectEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := calendar.authorizeEvent(ctx, event, model.RoleRead); err != nil {
+		return nil, err
+	}
+	return calendar.storage.SelectAttendees(ctx, eventID)
+}
+
+// InviteAttendee приглашает пользователя userID на событие, требуется доступ к событию на запись.
+// Новый участник получает статус model.StatusNeedsAction, и вместе с ним в outbox сохраняется приглашение.
+// Для уже приглашенного пользователя возвращается его текущее участие без повторного приглашения.
+func (calendar *Calendar) InviteAttendee(ctx context.Context, eventID, userID string) (model.Attendee, error) {
+	v := &ValidationError{}
+	if strings.TrimSpace(userID) == "" {
+		v.add("userId", "must not be blank")
+	}
+	if err := v.err(); err != nil {
+		return model.Attendee{}, err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	event, err := calendar.storage.SelectEvent(ctx, eventID)
+	if err != nil {
+		return model.Attendee{}, err
+	}
+	if err := calendar.authorizeEvent(ctx, event, model.RoleWrite); err != nil {
+		return model.Attendee{}, err
+	}
+	if userID == event.UserID {
+		v.add("userId", "must not be the event organizer")
+		return model.Attendee{}, v.err()
+	}
+
+	attendees, err := calendar.storage.SelectAttendees(ctx, eventID)
+	if err != nil {
+		return model.Attendee{}, err
+	}
+	for _, attendee := range attendees {
+		if attendee.UserID == userID {
+			return attendee, nil
+		}
+	}
+
+	invitation, err := newInvitation(event, userID)
+	if err != nil {
+		return model.Attendee{}, err
+	}
+	attendee := model.Attendee{EventID: eventID, UserID: userID, Status: model.StatusNeedsAction}
+	if err := calendar.storage.SetAttendee(ctx, attendee, invitation); err != nil {
+		return model.Attendee{}, err
+	}
+	return attendee, nil
+}
+
+// RemoveAttendee удаляет участника события. Требуется доступ к событию на запись,
+// но участник может удалить себя сам.
+func (calendar *Calendar) RemoveAttendee(ctx context.Context, eventID, userID string) error {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	if principal, ok := restricted(ctx); ok && principal.UserID != userID {
+		event, err := calendar.storage.SelectEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := calendar.authorizeEvent(ctx, event, model.RoleWrite); err != nil {
+			return err
+		}
+	}
+
+	return calendar.storage.DeleteAttendee(ctx, eventID, userID)
+}
+
+// RespondToInvitation сохраняет ответ участника userID на приглашение. Обычный пользователь
+// отвечает только за себя. Если пользователь не приглашен, возвращает model.ErrAttendeeNotFound.
+func (calendar *Calendar) RespondToInvitation(ctx context.Context, eventID, userID string,
+	status model.AttendeeStatus,
+) error {
+	v := &ValidationError{}
+	if !status.IsResponse() {
+		v.add("status", "must be one of accepted, declined, tentative")
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	if err := authorize(ctx, userID); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	attending, err := calendar.attending(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if !attending {
+		return model.ErrAttendeeNotFound
+	}
+
+	return calendar.storage.SetAttendee(ctx, model.Attendee{EventID: eventID, UserID: userID, Status: status})
+}
+
+// newInvitation возвращает сообщение outbox с приглашением участника attendeeID на событие.
+// Каждое приглашение получает свой ключ, поэтому повторное приглашение после удаления участника
+// не отбрасывается рассыльщиком.
+func newInvitation(event model.Event, attendeeID string) (model.OutboxMessage, error) {
+	body, err := json.Marshal(model.Invitation{
+		Type:        model.InvitationMessage,
+		EventID:     event.ID,
+		Title:       event.Title,
+		Beginning:   event.Beginning,
+		Finish:      event.Finish,
+		OrganizerID: event.UserID,
+		AttendeeID:  attendeeID,
+	})
+	if err != nil {
+		return model.OutboxMessage{}, err
+	}
+	return model.OutboxMessage{Key: "invitation:" + uuid.New().String(), Body: body}, nil
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBroker запоминает опубликованные и отложенные для повтора сообщения.
+type fakeBroker struct {
+	mu        sync.Mutex
+	published [][]byte
+	keys      []string
+	retried   []broker.Message
+	err       error
+}
+
+func (b *fakeBroker) Start() error                            { return nil }
+func (b *fakeBroker) Stop() error                             { return nil }
+func (b *fakeBroker) QueueDeclare(_ config.QueueConfig) error { return nil }
+
+func (b *fakeBroker) Consume(_ config.ConsumeConfig) (<-chan broker.Message, error) {
+	return nil, errors.New("not supported")
+}
+
+func (b *fakeBroker) PublishWithContext(_ context.Context, _ config.PublishConfig, body []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, body)
+	return nil
+}
+
+func (b *fakeBroker) PublishWithKey(_ context.Context, _ config.PublishConfig, key string, body []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, body)
+	b.keys = append(b.keys, key)
+	return nil
+}
+
+func (b *fakeBroker) Retry(_ context.Context, _ config.QueueConfig, msg broker.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.retried = append(b.retried, msg)
+	return nil
+}
+
+// newTestRelay создает ретранслятор, публикующий outbox календаря в broker.
+func newTestRelay(calendar *Calendar, broker *fakeBroker) *Relay {
+	return NewRelay(calendar, broker, config.PublishConfig{Exchange: "calendar"},
+		config.RelayConfig{Interval: time.Minute, BatchSize: 10}, logger.New(&config.LoggerConfig{Level: "error"}))
+}
+
+func TestCalendarAttendees(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	broker := &fakeBroker{}
+	relay := newTestRelay(calendar, broker)
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	internal := context.Background()
+
+	newUser := func() string {
+		user, err := calendar.CreateUser(internal, &model.User{FirstName: "Алиса"})
+		require.NoError(t, err)
+		return user.GetID()
+	}
+	aliceID, bobID, carolID := newUser(), newUser(), newUser()
+	alice := auth.WithPrincipal(internal, auth.Principal{UserID: aliceID})
+	bob := auth.WithPrincipal(internal, auth.Principal{UserID: bobID})
+	carol := auth.WithPrincipal(internal, auth.Principal{UserID: carolID})
+
+	created, err := calendar.CreateEvent(alice, &model.Event{
+		Title: "Планерка", Beginning: day.Add(10 * time.Hour), Finish: day.Add(11 * time.Hour),
+	})
+	require.NoError(t, err)
+	event := created.(*model.Event)
+
+	forDay := func(ctx context.Context) []model.IEvent {
+		events, err := calendar.SelectEventsForDay(ctx, day)
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("invite", func(t *testing.T) {
+		require.Empty(t, forDay(bob))
+		_, err := calendar.SelectEvent(bob, event.ID)
+		require.ErrorIs(t, err, ErrForbidden)
+
+		_, err = calendar.InviteAttendee(bob, event.ID, bobID)
+		require.ErrorIs(t, err, ErrForbidden)
+		var validationErr *ValidationError
+		_, err = calendar.InviteAttendee(alice, event.ID, aliceID)
+		require.ErrorAs(t, err, &validationErr)
+		_, err = calendar.InviteAttendee(alice, event.ID, "unknown")
+		require.ErrorIs(t, err, model.ErrUserNotFound)
+
+		attendee, err := calendar.InviteAttendee(alice, event.ID, bobID)
+		require.NoError(t, err)
+		require.Equal(t, model.Attendee{EventID: event.ID, UserID: bobID, Status: model.StatusNeedsAction}, attendee)
+
+		_, err = relay.Drain(internal)
+		require.NoError(t, err)
+		require.Len(t, broker.published, 1)
+		var invitation model.Invitation
+		require.NoError(t, json.Unmarshal(broker.published[0], &invitation))
+		require.Equal(t, model.Invitation{
+			Type: model.InvitationMessage, EventID: event.ID, Title: "Планерка",
+			Beginning: event.Beginning, Finish: event.Finish, OrganizerID: aliceID, AttendeeID: bobID,
+		}, invitation)
+
+		// Повторное приглашение не отправляется.
+		_, err = calendar.InviteAttendee(alice, event.ID, bobID)
+		require.NoError(t, err)
+		_, err = relay.Drain(internal)
+		require.NoError(t, err)
+		require.Len(t, broker.published, 1)
+
+		events := forDay(bob)
+		require.Len(t, events, 1)
+		require.Equal(t, event.ID, events[0].GetID())
+		_, err = calendar.SelectEvent(bob, event.ID)
+		require.NoError(t, err)
+
+		// Участник видит событие, но не может его менять.
+		changed := *event
+		changed.Title = "Захват"
+		require.ErrorIs(t, calendar.UpdateEvent(bob, &changed), ErrForbidden)
+		_, err = calendar.InviteAttendee(bob, event.ID, carolID)
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("respond", func(t *testing.T) {
+		var validationErr *ValidationError
+		require.ErrorAs(t, calendar.RespondToInvitation(bob, event.ID, bobID, model.StatusNeedsAction), &validationErr)
+		require.ErrorIs(t, calendar.RespondToInvitation(alice, event.ID, bobID, model.StatusAccepted), ErrForbidden)
+		require.ErrorIs(t, calendar.RespondToInvitation(carol, event.ID, carolID, model.StatusAccepted),
+			model.ErrAttendeeNotFound)
+
+		require.NoError(t, calendar.RespondToInvitation(bob, event.ID, bobID, model.StatusTentative))
+		attendees, err := calendar.SelectAttendees(alice, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, []model.Attendee{{EventID: event.ID, UserID: bobID, Status: model.StatusTentative}}, attendees)
+
+		// Отклоненное событие пропадает из списков, но остается доступным по идентификатору.
+		require.NoError(t, calendar.RespondToInvitation(bob, event.ID, bobID, model.StatusDeclined))
+		require.Empty(t, forDay(bob))
+		_, err = calendar.SelectEvent(bob, event.ID)
+		require.NoError(t, err)
+		require.NoError(t, calendar.RespondToInvitation(bob, event.ID, bobID, model.StatusAccepted))
+		require.Len(t, forDay(bob), 1)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		_, err := calendar.InviteAttendee(alice, event.ID, carolID)
+		require.NoError(t, err)
+		require.ErrorIs(t, calendar.RemoveAttendee(bob, event.ID, carolID), ErrForbidden)
+
+		// Участник может удалить себя сам.
+		require.NoError(t, calendar.RemoveAttendee(carol, event.ID, carolID))
+		require.NoError(t, calendar.RemoveAttendee(alice, event.ID, bobID))
+		require.ErrorIs(t, calendar.RemoveAttendee(alice, event.ID, bobID), model.ErrAttendeeNotFound)
+		require.Empty(t, forDay(bob))
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		_, err := relay.Drain(internal)
+		require.NoError(t, err)
+		broker.err = errors.New("connection closed")
+		_, err = calendar.InviteAttendee(alice, event.ID, bobID)
+		require.NoError(t, err)
+		require.Len(t, forDay(bob), 1)
+		_, err = relay.Drain(internal)
+		require.Error(t, err)
+
+		// Приглашение осталось в outbox и публикуется, когда брокер снова доступен.
+		broker.err = nil
+		relayed, err := relay.Drain(internal)
+		require.NoError(t, err)
+		require.Equal(t, 1, relayed)
+	})
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// ErrForbidden у аутентифицированного пользователя нет доступа к данным другого пользователя.
+var ErrForbidden = errors.New("forbidden")
+
+// Правила доступа: контекст без auth.Principal - внутренний вызов (планировщик или отключенная
+// аутентификация) без ограничений. Администратору доступны данные всех пользователей,
+// остальным - собственная учетная запись, собственные события и календари, календари других
+// пользователей и их события в соответствии с ролью в ACL календаря, а также чтение событий,
+// на которые пользователь приглашен.
+
+// restricted возвращает пользователя, доступ которого ограничен его собственными данными.
+func restricted(ctx context.Context) (auth.Principal, bool) {
+	principal, ok := auth.FromContext(ctx)
+	return principal, ok && !principal.Admin
+}
+
+// authorize проверяет доступ к данным пользователя userID.
+func authorize(ctx context.Context, userID string) error {
+	if principal, ok := restricted(ctx); ok && principal.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// authorizeAdmin проверяет, что вызов внутренний или выполнен администратором.
+func authorizeAdmin(ctx context.Context) error {
+	if _, ok := restricted(ctx); ok {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// visibility возвращает ограничение видимости событий для пользователя из контекста.
+func (calendar *Calendar) visibility(ctx context.Context) (model.Visibility, error) {
+	principal, ok := restricted(ctx)
+	if !ok {
+		return model.Visibility{}, nil
+	}
+
+	calendars, err := calendar.storage.SelectCalendars(ctx, principal.UserID)
+	if err != nil {
+		return model.Visibility{}, err
+	}
+	attendances, err := calendar.storage.SelectAttendances(ctx, principal.UserID)
+	if err != nil {
+		return model.Visibility{}, err
+	}
+
+	visibility := model.Visibility{
+		ReaderID:    principal.UserID,
+		CalendarIDs: make([]string, 0, len(calendars)),
+		EventIDs:    make([]string, 0, len(attendances)),
+	}
+	for _, c := range calendars {
+		visibility.CalendarIDs = append(visibility.CalendarIDs, c.ID)
+	}
+	// Отклоненные приглашения не попадают в списки событий, но событие остается доступным по идентификатору.
+	for _, attendance := range attendances {
+		if attendance.Status != model.StatusDeclined {
+			visibility.EventIDs = append(visibility.EventIDs, attendance.EventID)
+		}
+	}
+	return visibility, nil
+}
+
+// eventVisibility проверяет фильтр по владельцу событий и возвращает ограничение видимости.
+// Обычному пользователю разрешен только фильтр по себе, ErrForbidden для фильтра по другому пользователю.
+func (calendar *Calendar) eventVisibility(ctx context.Context, userID string) (model.Visibility, error) {
+	if principal, ok := restricted(ctx); ok && userID != "" && userID != principal.UserID {
+		return model.Visibility{}, ErrForbidden
+	}
+	return calendar.visibility(ctx)
+}
+
+// authorizeCalendar проверяет, что роль пользователя из контекста в календаре id не ниже required,
+// и возвращает календарь. Внутреннему вызову и администратору доступно все.
+func (calendar *Calendar) authorizeCalendar(ctx context.Context, id string, required model.Role) (
+	model.Calendar, error,
+) {
+	c, err := calendar.storage.SelectCalendar(ctx, id)
+	if err != nil {
+		return model.Calendar{}, err
+	}
+	if principal, ok := restricted(ctx); ok && !c.RoleOf(principal.UserID).Allows(required) {
+		return model.Calendar{}, ErrForbidden
+	}
+	return c, nil
+}
+
+// authorizeEvent проверяет доступ к событию: владельцу события доступно все,
+// остальным - по роли не ниже required в календаре события. Участнику доступно чтение события.
+func (calendar *Calendar) authorizeEvent(ctx context.Context, event model.Event, required model.Role) error {
+	principal, ok := restricted(ctx)
+	if !ok || event.UserID == principal.UserID {
+		return nil
+	}
+	if required == model.RoleRead {
+		attending, err := calendar.attending(ctx, event.ID, principal.UserID)
+		if err != nil || attending {
+			return err
+		}
+	}
+	if event.CalendarID == "" {
+		return ErrForbidden
+	}
+	_, err := calendar.authorizeCalendar(ctx, event.CalendarID, required)
+	return err
+}
+
+// attending проверяет, что пользователь userID участвует в событии eventID.
+func (calendar *Calendar) attending(ctx context.Context, eventID, userID string) (bool, error) {
+	attendees, err := calendar.storage.SelectAttendees(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+	for _, attendee := range attendees {
+		if attendee.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// visibleEvents оставляет события, доступные с ограничением visibility.
+func visibleEvents(visibility model.Visibility, storageEvents []model.Event) []model.IEvent {
+	events := make([]model.IEvent, 0, len(storageEvents))
+	for _, storageEvent := range storageEvents {
+		if !visibility.Allows(storageEvent) {
+			continue
+		}
+		event := storageEvent
+		events = append(events, &event)
+	}
+	return events
+}
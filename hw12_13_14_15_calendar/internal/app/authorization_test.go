@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarAuthorization(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	internal := context.Background()
+	admin := auth.WithPrincipal(internal, auth.Principal{UserID: "root", Admin: true})
+
+	newUser := func(ctx context.Context) (model.IUser, error) {
+		return calendar.CreateUser(ctx, &model.User{FirstName: "Алиса", LastName: "Иванова", Email: "a@b.c", Age: 30})
+	}
+	aliceUser, err := newUser(admin)
+	require.NoError(t, err)
+	bobUser, err := newUser(internal)
+	require.NoError(t, err)
+
+	alice := auth.WithPrincipal(internal, auth.Principal{UserID: aliceUser.GetID()})
+	bob := auth.WithPrincipal(internal, auth.Principal{UserID: bobUser.GetID()})
+
+	// Владелец события по умолчанию - пользователь из контекста.
+	created, err := calendar.CreateEvent(alice, &model.Event{
+		Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, aliceUser.GetID(), created.GetUserID())
+	event := created.(*model.Event)
+
+	t.Run("users", func(t *testing.T) {
+		_, err := newUser(alice)
+		require.ErrorIs(t, err, ErrForbidden)
+
+		users, err := calendar.SelectUsers(alice)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, aliceUser.GetID(), users[0].GetID())
+
+		users, err = calendar.SelectUsers(admin)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+
+		require.ErrorIs(t, calendar.DeleteUser(bob, aliceUser.GetID()), ErrForbidden)
+	})
+
+	t.Run("other user cannot access event", func(t *testing.T) {
+		_, err := calendar.SelectEvent(bob, event.ID)
+		require.ErrorIs(t, err, ErrForbidden)
+
+		changed := *event
+		changed.Title = "Захват"
+		require.ErrorIs(t, calendar.UpdateEvent(bob, &changed), ErrForbidden)
+		require.ErrorIs(t, calendar.DeleteEvent(bob, event.ID), ErrForbidden)
+
+		changed.UserID = bobUser.GetID()
+		require.ErrorIs(t, calendar.UpdateEvent(bob, &changed), ErrForbidden)
+
+		_, err = calendar.CreateEvent(bob, &model.Event{
+			Title: "Чужое", UserID: aliceUser.GetID(), Beginning: beginning, Finish: beginning.Add(time.Hour),
+		})
+		require.ErrorIs(t, err, ErrForbidden)
+
+		_, err = calendar.ExportEvents(bob, aliceUser.GetID())
+		require.ErrorIs(t, err, ErrForbidden)
+		_, _, err = calendar.ImportEvents(bob, aliceUser.GetID(), nil)
+		require.ErrorIs(t, err, ErrForbidden)
+		_, _, err = calendar.ListEvents(bob, model.EventFilter{UserID: aliceUser.GetID()}, model.PageRequest{})
+		require.ErrorIs(t, err, ErrForbidden)
+		_, err = calendar.WatchEvents(bob, aliceUser.GetID(), time.Time{}, time.Time{}, "")
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("lists contain only own events", func(t *testing.T) {
+		_, err := calendar.CreateEvent(bob, &model.Event{
+			Title: "Обед", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		for ctx, expected := range map[context.Context]int{alice: 1, bob: 1, admin: 2, internal: 2} {
+			events, err := calendar.SelectEvents(ctx)
+			require.NoError(t, err)
+			require.Len(t, events, expected)
+
+			events, err = calendar.SelectEventsForDay(ctx, beginning)
+			require.NoError(t, err)
+			require.Len(t, events, expected)
+
+			events, _, err = calendar.ListEvents(ctx, model.EventFilter{}, model.PageRequest{})
+			require.NoError(t, err)
+			require.Len(t, events, expected)
+		}
+	})
+
+	t.Run("owner and admin can modify event", func(t *testing.T) {
+		changed := *event
+		changed.Title = "Ретро"
+		require.NoError(t, calendar.UpdateEvent(alice, &changed))
+
+		selected, err := calendar.SelectEvent(admin, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Ретро", selected.GetTitle())
+
+		require.NoError(t, calendar.DeleteEvent(admin, event.ID))
+		require.NoError(t, calendar.DeleteUser(bob, bobUser.GetID()))
+	})
+}
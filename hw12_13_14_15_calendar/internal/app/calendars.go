@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// defaultTimeZone часовой пояс календаря, если он не указан.
+const defaultTimeZone = "UTC"
+
+// CreateCalendar создание календаря, возвращает сохраненный календарь с идентификатором.
+// Календарь без владельца создается от имени пользователя из контекста, обычный пользователь
+// может создать календарь только для себя. ACL из запроса не сохраняется, доступ выдает ShareCalendar.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+func (calendar *Calendar) CreateCalendar(ctx context.Context, c model.Calendar) (model.Calendar, error) {
+	if c.OwnerID == "" {
+		if principal, ok := auth.FromContext(ctx); ok {
+			c.OwnerID = principal.UserID
+		}
+	}
+	if c.TimeZone == "" {
+		c.TimeZone = defaultTimeZone
+	}
+	c.ACL = nil
+
+	if err := authorize(ctx, c.OwnerID); err != nil {
+		return model.Calendar{}, err
+	}
+	if err := ValidateCalendar(c, false); err != nil {
+		return model.Calendar{}, err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.CreateCalendar(ctx, c)
+}
+
+// SelectCalendars получение календарей. Обычному пользователю возвращаются его календари
+// и календари, к которым ему выдан доступ. ACL видна только с ролью model.RoleOwner.
+func (calendar *Calendar) SelectCalendars(ctx context.Context) ([]model.Calendar, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	principal, ok := restricted(ctx)
+	if !ok {
+		return calendar.storage.SelectCalendars(ctx, "")
+	}
+
+	calendars, err := calendar.storage.SelectCalendars(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range calendars {
+		calendars[i] = hideACL(calendars[i], principal.UserID)
+	}
+	return calendars, nil
+}
+
+// SelectCalendar получение календаря по идентификатору, требуется роль model.RoleRead.
+// Если календаря нет, возвращает model.ErrCalendarNotFound, если доступа нет - ErrForbidden.
+func (calendar *Calendar) SelectCalendar(ctx context.Context, id string) (model.Calendar, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	c, err := calendar.authorizeCalendar(ctx, id, model.RoleRead)
+	if err != nil {
+		return model.Calendar{}, err
+	}
+	if principal, ok := restricted(ctx); ok {
+		c = hideACL(c, principal.UserID)
+	}
+	return c, nil
+}
+
+// UpdateCalendar изменение названия, часового пояса и цвета календаря, требуется роль model.RoleOwner.
+// Владелец календаря и ACL не меняются.
+func (calendar *Calendar) UpdateCalendar(ctx context.Context, c model.Calendar) error {
+	if c.TimeZone == "" {
+		c.TimeZone = defaultTimeZone
+	}
+	v := &ValidationError{}
+	if strings.TrimSpace(c.ID) == "" {
+		v.add("id", "must not be blank")
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	current, err := calendar.authorizeCalendar(ctx, c.ID, model.RoleOwner)
+	if err != nil {
+		return err
+	}
+	c.OwnerID = current.OwnerID
+	if err := ValidateCalendar(c, true); err != nil {
+		return err
+	}
+
+	return calendar.storage.UpdateCalendar(ctx, c)
+}
+
+// DeleteCalendar удаление календаря вместе с его событиями, требуется роль model.RoleOwner.
+func (calendar *Calendar) DeleteCalendar(ctx context.Context, id string) error {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	if _, err := calendar.authorizeCalendar(ctx, id, model.RoleOwner); err != nil {
+		return err
+	}
+
+	// Удаление событий каскадом не проходит через DeleteEvent, поэтому подписчикам
+	// хранилища без уведомлений об изменениях удаления публикуются здесь.
+	var deleted []model.Event
+	if _, ok := calendar.storage.(ChangeNotifier); !ok {
+		events, err := calendar.storage.SelectEvents(ctx)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.CalendarID == id {
+				deleted = append(deleted, event)
+			}
+		}
+	}
+
+	if err := calendar.storage.DeleteCalendar(ctx, id); err != nil {
+		return err
+	}
+	for _, event := range deleted {
+		calendar.publishChange(model.ChangeDeleted, event)
+	}
+	return nil
+}
+
+// ShareCalendar выдает пользователю userID роль role в календаре или меняет его роль,
+// требуется роль model.RoleOwner. Владельцу календаря доступ не выдается.
+func (calendar *Calendar) ShareCalendar(ctx context.Context, calendarID, userID string, role model.Role) error {
+	v := &ValidationError{}
+	if strings.TrimSpace(userID) == "" {
+		v.add("userId", "must not be blank")
+	}
+	if !role.Allows(model.RoleRead) {
+		v.add("role", "must be one of read, write, owner")
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	c, err := calendar.authorizeCalendar(ctx, calendarID, model.RoleOwner)
+	if err != nil {
+		return err
+	}
+	if userID == c.OwnerID {
+		v.add("userId", "must not be the calendar owner")
+		return v.err()
+	}
+
+	return calendar.storage.SetAccess(ctx, model.ACLEntry{CalendarID: calendarID, UserID: userID, Role: role})
+}
+
+// UnshareCalendar отзывает доступ пользователя userID к календарю. Требуется роль model.RoleOwner,
+// но пользователь может отказаться от собственного доступа сам.
+func (calendar *Calendar) UnshareCalendar(ctx context.Context, calendarID, userID string) error {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	if principal, ok := restricted(ctx); !ok || principal.UserID != userID {
+		if _, err := calendar.authorizeCalendar(ctx, calendarID, model.RoleOwner); err != nil {
+			return err
+		}
+	}
+
+	return calendar.storage.RevokeAccess(ctx, calendarID, userID)
+}
+
+// hideACL скрывает ACL календаря от пользователя без роли model.RoleOwner.
+func hideACL(c model.Calendar, userID string) model.Calendar {
+	if !c.RoleOf(userID).Allows(model.RoleOwner) {
+		c.ACL = nil
+	}
+	return c
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarSharing(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	internal := context.Background()
+
+	newUser := func() string {
+		user, err := calendar.CreateUser(internal, &model.User{FirstName: "Алиса", LastName: "Иванова"})
+		require.NoError(t, err)
+		return user.GetID()
+	}
+	aliceID, bobID := newUser(), newUser()
+	alice := auth.WithPrincipal(internal, auth.Principal{UserID: aliceID})
+	bob := auth.WithPrincipal(internal, auth.Principal{UserID: bobID})
+
+	team, err := calendar.CreateCalendar(alice, model.Calendar{Name: "Команда", Color: "#00aa00"})
+	require.NoError(t, err)
+	require.Equal(t, aliceID, team.OwnerID)
+	require.Equal(t, "UTC", team.TimeZone)
+
+	created, err := calendar.CreateEvent(alice, &model.Event{
+		Title: "Планерка", CalendarID: team.ID, Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	event := created.(*model.Event)
+	require.Equal(t, team.ID, event.CalendarID)
+
+	newEvent := func(ctx context.Context, title string) error {
+		_, err := calendar.CreateEvent(ctx, &model.Event{
+			Title: title, CalendarID: team.ID, Beginning: beginning.Add(2 * time.Hour), Finish: beginning.Add(3 * time.Hour),
+		})
+		return err
+	}
+	listTeam := func(ctx context.Context) ([]model.IEvent, error) {
+		events, _, err := calendar.ListEvents(ctx, model.EventFilter{CalendarID: team.ID}, model.PageRequest{})
+		return events, err
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := calendar.CreateCalendar(alice, model.Calendar{Name: " ", TimeZone: "Mars/Olympus", Color: "red"})
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, []FieldError{
+			{Field: "name", Message: "must not be blank"},
+			{Field: "timeZone", Message: "must be an IANA time zone"},
+			{Field: "color", Message: "must be in #rrggbb format"},
+		}, validationErr.Fields)
+
+		_, err = calendar.CreateCalendar(bob, model.Calendar{Name: "Чужой", OwnerID: aliceID})
+		require.ErrorIs(t, err, ErrForbidden)
+
+		require.ErrorAs(t, calendar.ShareCalendar(alice, team.ID, bobID, "admin"), &validationErr)
+		require.ErrorAs(t, calendar.ShareCalendar(alice, team.ID, aliceID, model.RoleRead), &validationErr)
+		require.Equal(t, "userId", validationErr.Fields[0].Field)
+	})
+
+	t.Run("no access", func(t *testing.T) {
+		_, err := calendar.SelectCalendar(bob, team.ID)
+		require.ErrorIs(t, err, ErrForbidden)
+		_, err = calendar.SelectEvent(bob, event.ID)
+		require.ErrorIs(t, err, ErrForbidden)
+		_, err = listTeam(bob)
+		require.ErrorIs(t, err, ErrForbidden)
+		require.ErrorIs(t, newEvent(bob, "Чужое"), ErrForbidden)
+
+		calendars, err := calendar.SelectCalendars(bob)
+		require.NoError(t, err)
+		require.Empty(t, calendars)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		require.NoError(t, calendar.ShareCalendar(alice, team.ID, bobID, model.RoleRead))
+
+		selected, err := calendar.SelectEvent(bob, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, event.ID, selected.GetID())
+		events, err := listTeam(bob)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		results, err := calendar.SearchEvents(bob, "планерка", "", 0)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		// ACL видна только владельцу.
+		calendars, err := calendar.SelectCalendars(bob)
+		require.NoError(t, err)
+		require.Len(t, calendars, 1)
+		require.Empty(t, calendars[0].ACL)
+		shared, err := calendar.SelectCalendar(alice, team.ID)
+		require.NoError(t, err)
+		require.Equal(t, []model.ACLEntry{{CalendarID: team.ID, UserID: bobID, Role: model.RoleRead}}, shared.ACL)
+
+		changed := *event
+		changed.Title = "Захват"
+		require.ErrorIs(t, calendar.UpdateEvent(bob, &changed), ErrForbidden)
+		require.ErrorIs(t, calendar.DeleteEvent(bob, event.ID), ErrForbidden)
+		require.ErrorIs(t, newEvent(bob, "Ретро"), ErrForbidden)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		require.NoError(t, calendar.ShareCalendar(alice, team.ID, bobID, model.RoleWrite))
+
+		changed := *event
+		changed.Title = "Планерка команды"
+		require.NoError(t, calendar.UpdateEvent(bob, &changed))
+		require.NoError(t, newEvent(bob, "Ретро"))
+
+		// Событие календаря остается у владельца, перенос в календарь без доступа запрещен.
+		changed.UserID = bobID
+		require.ErrorIs(t, calendar.UpdateEvent(bob, &changed), ErrForbidden)
+
+		events, err := listTeam(alice)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		team.Name = "Переименован"
+		require.ErrorIs(t, calendar.UpdateCalendar(bob, team), ErrForbidden)
+		require.ErrorIs(t, calendar.ShareCalendar(bob, team.ID, bobID, model.RoleOwner), ErrForbidden)
+		require.ErrorIs(t, calendar.DeleteCalendar(bob, team.ID), ErrForbidden)
+	})
+
+	t.Run("unshare", func(t *testing.T) {
+		// Пользователь может сам отказаться от доступа.
+		require.NoError(t, calendar.UnshareCalendar(bob, team.ID, bobID))
+		require.ErrorIs(t, calendar.UnshareCalendar(alice, team.ID, bobID), model.ErrAccessNotFound)
+
+		_, err := calendar.SelectEvent(bob, event.ID)
+		require.ErrorIs(t, err, ErrForbidden)
+		results, err := calendar.SearchEvents(bob, "планерка", "", 0)
+		require.NoError(t, err)
+		require.Empty(t, results)
+
+		// События, созданные в календаре, остаются доступны их владельцу.
+		events, err := calendar.SelectEvents(bob)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, "Ретро", events[0].GetTitle())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		team.Name = "Команда разработки"
+		team.TimeZone = "Europe/Moscow"
+		require.NoError(t, calendar.UpdateCalendar(alice, team))
+		updated, err := calendar.SelectCalendar(alice, team.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Moscow", updated.TimeZone)
+
+		require.NoError(t, calendar.DeleteCalendar(alice, team.ID))
+		_, err = calendar.SelectEvent(alice, event.ID)
+		require.ErrorIs(t, err, model.ErrEventNotFound)
+		events, err := calendar.SelectEvents(bob)
+		require.NoError(t, err)
+		require.Empty(t, events)
+		_, err = calendar.SelectCalendar(alice, team.ID)
+		require.ErrorIs(t, err, model.ErrCalendarNotFound)
+	})
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+const (
+	// changeBufferSize число непрочитанных изменений, после которого подписчик отключается.
+	changeBufferSize = 64
+	// changeHistorySize число последних изменений, по которым можно возобновить подписку.
+	changeHistorySize = 1024
+)
+
+// ChangeFilter условие подписки на изменения событий.
+type ChangeFilter struct {
+	// UserID владелец событий, пустое значение - события всех пользователей.
+	UserID string
+	// From и To интервал [From, To), в котором начинается событие или одно из его повторений.
+	// Нулевые границы снимают ограничение по времени.
+	From time.Time
+	To   time.Time
+	// Visibility доступ подписчика к событиям на момент подписки.
+	model.Visibility
+}
+
+// Match проверяет, подходит ли событие под условие подписки.
+func (f ChangeFilter) Match(event model.Event) bool {
+	if f.UserID != "" && event.UserID != f.UserID {
+		return false
+	}
+	if !f.Allows(event) {
+		return false
+	}
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+
+	occurrences, err := event.Occurrences(f.From, f.To)
+	return err == nil && len(occurrences) > 0
+}
+
+// ChangeFeed лента изменений событий внутри процесса.
+// Изменения получают идентификаторы вида <эпоха>-<номер>, где эпоха отличает запуски процесса.
+// Последние changeHistorySize изменений хранятся, чтобы подписку можно было возобновить.
+// Подписчик, который не успевает читать изменения, отключается, и его канал закрывается.
+type ChangeFeed struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	history     []model.EventChange
+	subscribers map[chan model.EventChange]ChangeFilter
+}
+
+// NewChangeFeed создает пустую ленту изменений.
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: make(map[chan model.EventChange]ChangeFilter),
+	}
+}
+
+// Subscribe подписывает на изменения, подходящие под фильтр. Канал закрывается при отмене ctx.
+// Если указан lastID, сначала передаются подходящие изменения после него. Если они уже недоступны,
+// первым передается изменение model.ChangeReset.
+func (f *ChangeFeed) Subscribe(ctx context.Context, filter ChangeFilter, lastID string) <-chan model.EventChange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var replay []model.EventChange
+	if lastID != "" {
+		missed, ok := f.since(lastID)
+		if !ok {
+			missed = []model.EventChange{{ID: f.id(f.seq), Type: model.ChangeReset}}
+		}
+		for _, change := range missed {
+			if change.Type == model.ChangeReset || filter.Match(change.Event) {
+				replay = append(replay, change)
+			}
+		}
+	}
+
+	ch := make(chan model.EventChange, changeBufferSize+len(replay))
+	for _, change := range replay {
+		ch <- change
+	}
+	f.subscribers[ch] = filter
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// Publish назначает изменению идентификатор и рассылает его подписчикам,
+// под фильтр которых подходит событие.
+func (f *ChangeFeed) Publish(change model.EventChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	change.ID = f.id(f.seq)
+
+	f.history = append(f.history, change)
+	if len(f.history) > changeHistorySize {
+		f.history = append([]model.EventChange(nil), f.history[len(f.history)-changeHistorySize:]...)
+	}
+
+	for ch, filter := range f.subscribers {
+		if !filter.Match(change.Event) {
+			continue
+		}
+		select {
+		case ch <- change:
+		default:
+			f.unsubscribe(ch)
+		}
+	}
+}
+
+// since возвращает сохраненные изменения после lastID.
+// Возвращает false, если lastID выдан другим запуском или часть изменений после него уже вытеснена.
+func (f *ChangeFeed) since(lastID string) ([]model.EventChange, bool) {
+	epoch, seqValue, ok := strings.Cut(lastID, "-")
+	if !ok || epoch != f.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseUint(seqValue, 10, 64)
+	if err != nil || seq > f.seq || f.seq-seq > uint64(len(f.history)) {
+		return nil, false
+	}
+
+	return append([]model.EventChange(nil), f.history[len(f.history)-int(f.seq-seq):]...), true
+}
+
+func (f *ChangeFeed) id(seq uint64) string {
+	return fmt.Sprintf("%s-%d", f.epoch, seq)
+}
+
+// unsubscribe удаляет подписчика и закрывает его канал. Вызывается под f.mu.
+func (f *ChangeFeed) unsubscribe(ch chan model.EventChange) {
+	if _, ok := f.subscribers[ch]; ok {
+		delete(f.subscribers, ch)
+		close(ch)
+	}
+}
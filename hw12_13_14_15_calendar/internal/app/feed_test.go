@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFilter(t *testing.T) {
+	monday := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := model.Event{UserID: "alice", Beginning: monday, Finish: monday.Add(time.Hour)}
+	weekly := model.Event{UserID: "alice", Beginning: monday, Finish: monday.Add(time.Hour), Recurrence: "FREQ=WEEKLY"}
+
+	for name, tc := range map[string]struct {
+		filter ChangeFilter
+		event  model.Event
+		match  bool
+	}{
+		"no filter":             {ChangeFilter{}, event, true},
+		"same user":             {ChangeFilter{UserID: "alice"}, event, true},
+		"other user":            {ChangeFilter{UserID: "bob"}, event, false},
+		"inside window":         {ChangeFilter{From: monday, To: monday.AddDate(0, 0, 1)}, event, true},
+		"outside window":        {ChangeFilter{From: monday.AddDate(0, 0, 1), To: monday.AddDate(0, 0, 7)}, event, false},
+		"occurrence in window":  {ChangeFilter{From: monday.AddDate(0, 1, 0), To: monday.AddDate(0, 1, 7)}, weekly, true},
+		"window before series":  {ChangeFilter{From: monday.AddDate(0, -1, 0), To: monday}, weekly, false},
+		"user and window match": {ChangeFilter{UserID: "alice", From: monday, To: monday.Add(time.Hour)}, event, true},
+	} {
+		require.Equal(t, tc.match, tc.filter.Match(tc.event), name)
+	}
+}
+
+func TestChangeFeed(t *testing.T) {
+	created := model.EventChange{Type: model.ChangeCreated, Event: model.Event{ID: "1", UserID: "alice"}}
+
+	t.Run("subscriber receives matching changes", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		alice := feed.Subscribe(ctx, ChangeFilter{UserID: "alice"}, "")
+		bob := feed.Subscribe(ctx, ChangeFilter{UserID: "bob"}, "")
+		feed.Publish(created)
+
+		change := <-alice
+		require.NotEmpty(t, change.ID)
+		require.Equal(t, created.Event, change.Event)
+		require.Empty(t, bob)
+	})
+
+	t.Run("channel is closed on cancel", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		changes := feed.Subscribe(ctx, ChangeFilter{}, "")
+		cancel()
+
+		_, ok := <-changes
+		require.False(t, ok)
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		changes := feed.Subscribe(ctx, ChangeFilter{}, "")
+		for i := 0; i <= changeBufferSize; i++ {
+			feed.Publish(created)
+		}
+
+		received := 0
+		for range changes {
+			received++
+		}
+		require.Equal(t, changeBufferSize, received)
+	})
+
+	t.Run("subscription is resumed after last ID", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first := feed.Subscribe(ctx, ChangeFilter{UserID: "alice"}, "")
+		feed.Publish(created)
+		feed.Publish(model.EventChange{Type: model.ChangeCreated, Event: model.Event{ID: "2", UserID: "bob"}})
+		feed.Publish(model.EventChange{Type: model.ChangeDeleted, Event: created.Event})
+		lastID := (<-first).ID
+
+		resumed := feed.Subscribe(ctx, ChangeFilter{UserID: "alice"}, lastID)
+		change := <-resumed
+		require.Equal(t, model.ChangeDeleted, change.Type)
+		require.Equal(t, (<-first).ID, change.ID)
+		require.Empty(t, resumed)
+
+		// Последнее изменение: пропущенных нет.
+		upToDate := feed.Subscribe(ctx, ChangeFilter{}, change.ID)
+		require.Empty(t, upToDate)
+	})
+
+	t.Run("lost history is reported with reset", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first := feed.Subscribe(ctx, ChangeFilter{}, "")
+		feed.Publish(created)
+		evictedID := (<-first).ID
+		// Изменение сразу после evictedID вытесняется из истории.
+		for i := 0; i <= changeHistorySize; i++ {
+			feed.Publish(created)
+		}
+
+		for _, lastID := range []string{evictedID, "otherepoch-1", "garbage"} {
+			change := <-feed.Subscribe(ctx, ChangeFilter{UserID: "bob"}, lastID)
+			require.Equal(t, model.ChangeReset, change.Type, lastID)
+			require.Equal(t, feed.id(changeHistorySize+2), change.ID)
+		}
+	})
+}
+
+func TestCalendarWatchEvents(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := calendar.WatchEvents(ctx, "", beginning, time.Time{}, "")
+	require.Equal(t, []string{"from"}, fieldNames(t, err))
+	_, err = calendar.WatchEvents(ctx, "", beginning, beginning, "")
+	require.Equal(t, []string{"to"}, fieldNames(t, err))
+
+	changes, err := calendar.WatchEvents(ctx, "alice", beginning, beginning.AddDate(0, 0, 1), "")
+	require.NoError(t, err)
+
+	event := &model.Event{Title: "Планерка", UserID: "alice", Beginning: beginning, Finish: beginning.Add(time.Hour)}
+	created, err := calendar.CreateEvent(ctx, event)
+	require.NoError(t, err)
+
+	// Событие другого пользователя подписчик не получает.
+	_, err = calendar.CreateEvent(ctx, &model.Event{
+		Title: "Обед", UserID: "bob", Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	event.ID = created.GetID()
+	event.Title = "Ретро"
+	require.NoError(t, calendar.UpdateEvent(ctx, event))
+	require.NoError(t, calendar.DeleteEvent(ctx, event.ID))
+
+	for _, expected := range []model.ChangeType{model.ChangeCreated, model.ChangeUpdated, model.ChangeDeleted} {
+		change := <-changes
+		require.Equal(t, expected, change.Type)
+		require.Equal(t, event.ID, change.Event.ID)
+	}
+	require.Empty(t, changes)
+}
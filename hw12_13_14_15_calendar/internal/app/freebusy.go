@@ -0,0 +1,165 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// FreeBusy возвращает занятость пользователей query.UserIDs в интервале [From, To) и первые
+// query.Limit (0 - model.DefaultSlotLimit) слотов длительностью Duration, в которые свободны все
+// пользователи в пределах своего рабочего времени. Занятость раскрывает только интервалы без
+// подробностей событий, поэтому доступна любому пользователю. Повторы в UserIDs игнорируются,
+// интервалы возвращаются в UTC.
+func (calendar *Calendar) FreeBusy(ctx context.Context, query model.FreeBusyQuery) (model.FreeBusy, error) {
+	query.UserIDs = distinct(query.UserIDs)
+	if query.Limit == 0 {
+		query.Limit = model.DefaultSlotLimit
+	}
+	if err := validateFreeBusy(query); err != nil {
+		return model.FreeBusy{}, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	busy, err := calendar.storage.SelectBusy(ctx, query.UserIDs, query.From, query.To)
+	if err != nil {
+		return model.FreeBusy{}, err
+	}
+	hours, err := calendar.storage.SelectWorkingHours(ctx, query.UserIDs)
+	if err != nil {
+		return model.FreeBusy{}, err
+	}
+
+	byUser := make(map[string][]model.Interval, len(query.UserIDs))
+	for _, interval := range busy {
+		byUser[interval.UserID] = append(byUser[interval.UserID], interval.Interval)
+	}
+	available := make(map[string][]model.Interval, len(hours))
+	for _, h := range hours {
+		if available[h.UserID], err = h.Intervals(query.From, query.To); err != nil {
+			return model.FreeBusy{}, err
+		}
+	}
+
+	result := model.FreeBusy{Users: make([]model.UserBusy, 0, len(query.UserIDs))}
+	free := []model.Interval{{Start: query.From, End: query.To}}
+	for _, userID := range query.UserIDs {
+		userBusy := model.MergeIntervals(byUser[userID])
+		result.Users = append(result.Users, model.UserBusy{UserID: userID, Busy: inUTC(userBusy)})
+
+		userAvailable, ok := available[userID]
+		if !ok {
+			userAvailable = []model.Interval{{Start: query.From, End: query.To}}
+		}
+		free = model.Intersect(free, model.Subtract(userAvailable, userBusy))
+	}
+	result.Slots = inUTC(model.Slots(free, query.Duration, query.Limit))
+	return result, nil
+}
+
+// SelectWorkingHours получение рабочего времени пользователя userID.
+// Возвращает model.ErrWorkingHoursNotFound, если оно не задано.
+func (calendar *Calendar) SelectWorkingHours(ctx context.Context, userID string) (model.WorkingHours, error) {
+	if err := authorize(ctx, userID); err != nil {
+		return model.WorkingHours{}, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	hours, err := calendar.storage.SelectWorkingHours(ctx, []string{userID})
+	if err != nil {
+		return model.WorkingHours{}, err
+	}
+	if len(hours) == 0 {
+		return model.WorkingHours{}, model.ErrWorkingHoursNotFound
+	}
+	return hours[0], nil
+}
+
+// SetWorkingHours задает или заменяет рабочее время пользователя.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+func (calendar *Calendar) SetWorkingHours(ctx context.Context, hours model.WorkingHours) error {
+	if err := authorize(ctx, hours.UserID); err != nil {
+		return err
+	}
+	if err := ValidateWorkingHours(hours); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.SetWorkingHours(ctx, hours)
+}
+
+// DeleteWorkingHours удаляет рабочее время пользователя, после чего он доступен в любое время.
+func (calendar *Calendar) DeleteWorkingHours(ctx context.Context, userID string) error {
+	if err := authorize(ctx, userID); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.DeleteWorkingHours(ctx, userID)
+}
+
+// validateFreeBusy проверяет параметры запроса занятости.
+func validateFreeBusy(query model.FreeBusyQuery) error {
+	v := &ValidationError{}
+	if len(query.UserIDs) == 0 || len(query.UserIDs) > model.MaxFreeBusyUsers {
+		v.add("userIds", "must contain from 1 to "+strconv.Itoa(model.MaxFreeBusyUsers)+" users")
+	}
+	for _, userID := range query.UserIDs {
+		if strings.TrimSpace(userID) == "" {
+			v.add("userIds", "must not contain blank ids")
+			break
+		}
+	}
+	switch {
+	case query.From.IsZero():
+		v.add("from", "is required")
+	case query.To.IsZero():
+		v.add("to", "is required")
+	case !query.From.Before(query.To):
+		v.add("to", "must be after from")
+	case query.To.Sub(query.From) > model.MaxFreeBusyWindow:
+		v.add("to", "must be at most "+strconv.Itoa(int(model.MaxFreeBusyWindow.Hours()/24))+" days after from")
+	}
+	if query.Duration <= 0 {
+		v.add("duration", "must be positive")
+	} else if !query.From.IsZero() && query.Duration > query.To.Sub(query.From) {
+		v.add("duration", "must fit between from and to")
+	}
+	if query.Limit < 1 || query.Limit > model.MaxSlotLimit {
+		v.add("limit", "must be between 1 and "+strconv.Itoa(model.MaxSlotLimit))
+	}
+	return v.err()
+}
+
+// inUTC переводит границы интервалов в UTC.
+func inUTC(intervals []model.Interval) []model.Interval {
+	utc := make([]model.Interval, len(intervals))
+	for i, interval := range intervals {
+		utc[i] = model.Interval{Start: interval.Start.UTC(), End: interval.End.UTC()}
+	}
+	return utc
+}
+
+// distinct возвращает значения без повторов в порядке первого появления.
+func distinct(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
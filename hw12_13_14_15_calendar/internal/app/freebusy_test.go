@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFreeBusy(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	internal := context.Background()
+	// Понедельник.
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	newUser := func() string {
+		user, err := calendar.CreateUser(internal, &model.User{FirstName: "Алиса"})
+		require.NoError(t, err)
+		return user.GetID()
+	}
+	aliceID, bobID, carolID := newUser(), newUser(), newUser()
+	alice := auth.WithPrincipal(internal, auth.Principal{UserID: aliceID})
+
+	newEvent := func(userID string, beginning, finish time.Time) string {
+		event, err := calendar.CreateEvent(internal, &model.Event{
+			Title: "Встреча", UserID: userID, Beginning: beginning, Finish: finish,
+		})
+		require.NoError(t, err)
+		return event.GetID()
+	}
+	newEvent(aliceID, at(10, 0), at(11, 0))
+	newEvent(bobID, at(11, 30), at(12, 0))
+	review := newEvent(aliceID, at(14, 0), at(14, 30))
+	lunch := newEvent(carolID, at(12, 0), at(13, 0))
+	for eventID, status := range map[string]model.AttendeeStatus{
+		review: model.StatusAccepted, lunch: model.StatusDeclined,
+	} {
+		_, err := calendar.InviteAttendee(internal, eventID, bobID)
+		require.NoError(t, err)
+		require.NoError(t, calendar.RespondToInvitation(internal, eventID, bobID, status))
+	}
+
+	// Рабочий день Алисы 06:00-15:00 UTC, Боба 10:00-19:00 UTC.
+	require.NoError(t, calendar.SetWorkingHours(alice, model.WorkingHours{
+		UserID: aliceID, TimeZone: "Europe/Moscow", Start: "09:00", End: "18:00", Days: []int{1, 2, 3, 4, 5},
+	}))
+	require.NoError(t, calendar.SetWorkingHours(internal, model.WorkingHours{
+		UserID: bobID, TimeZone: "UTC", Start: "10:00", End: "19:00", Days: []int{1, 2, 3, 4, 5},
+	}))
+
+	query := model.FreeBusyQuery{
+		UserIDs: []string{aliceID, bobID, aliceID}, From: day, To: day.AddDate(0, 0, 1), Duration: time.Hour,
+	}
+
+	t.Run("busy intervals and slots", func(t *testing.T) {
+		freeBusy, err := calendar.FreeBusy(alice, query)
+		require.NoError(t, err)
+		require.Equal(t, []model.UserBusy{
+			{UserID: aliceID, Busy: []model.Interval{{Start: at(10, 0), End: at(11, 0)}, {Start: at(14, 0), End: at(14, 30)}}},
+			{UserID: bobID, Busy: []model.Interval{{Start: at(11, 30), End: at(12, 0)}, {Start: at(14, 0), End: at(14, 30)}}},
+		}, freeBusy.Users)
+		require.Equal(t, []model.Interval{
+			{Start: at(12, 0), End: at(13, 0)}, {Start: at(13, 0), End: at(14, 0)},
+		}, freeBusy.Slots)
+	})
+
+	t.Run("slot limit", func(t *testing.T) {
+		short := query
+		short.Duration, short.Limit = 30*time.Minute, 3
+		freeBusy, err := calendar.FreeBusy(alice, short)
+		require.NoError(t, err)
+		require.Equal(t, []model.Interval{
+			{Start: at(11, 0), End: at(11, 30)}, {Start: at(12, 0), End: at(12, 30)}, {Start: at(12, 30), End: at(13, 0)},
+		}, freeBusy.Slots)
+	})
+
+	t.Run("user without working hours is available any time", func(t *testing.T) {
+		require.NoError(t, calendar.DeleteWorkingHours(internal, bobID))
+		freeBusy, err := calendar.FreeBusy(alice, model.FreeBusyQuery{
+			UserIDs: []string{bobID}, From: at(11, 0), To: at(13, 0), Duration: time.Hour, Limit: 5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []model.Interval{{Start: at(12, 0), End: at(13, 0)}}, freeBusy.Slots)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := calendar.FreeBusy(alice, model.FreeBusyQuery{
+			From: day, To: day.Add(-time.Hour), Limit: model.MaxSlotLimit + 1,
+		})
+		require.Equal(t, []string{"userIds", "to", "duration", "limit"}, fieldNames(t, err))
+
+		_, err = calendar.FreeBusy(alice, model.FreeBusyQuery{
+			UserIDs: []string{aliceID}, From: day, To: day.Add(model.MaxFreeBusyWindow + time.Hour), Duration: time.Hour,
+		})
+		require.Equal(t, []string{"to"}, fieldNames(t, err))
+
+		_, err = calendar.FreeBusy(alice, model.FreeBusyQuery{
+			UserIDs: []string{"unknown"}, From: day, To: day.AddDate(0, 0, 1), Duration: time.Hour,
+		})
+		require.True(t, errors.Is(err, model.ErrUserNotFound))
+	})
+
+	t.Run("working hours", func(t *testing.T) {
+		hours, err := calendar.SelectWorkingHours(alice, aliceID)
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Moscow", hours.TimeZone)
+
+		_, err = calendar.SelectWorkingHours(internal, bobID)
+		require.True(t, errors.Is(err, model.ErrWorkingHoursNotFound))
+		_, err = calendar.SelectWorkingHours(alice, bobID)
+		require.True(t, errors.Is(err, ErrForbidden))
+		require.True(t, errors.Is(calendar.DeleteWorkingHours(alice, bobID), ErrForbidden))
+
+		err = calendar.SetWorkingHours(alice, model.WorkingHours{
+			UserID: aliceID, TimeZone: "Local", Start: "18:00", End: "09:00", Days: []int{1, 1, 8},
+		})
+		require.Equal(t, []string{"timeZone", "end", "days"}, fieldNames(t, err))
+	})
+}
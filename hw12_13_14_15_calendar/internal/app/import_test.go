@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarImportEvents(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	ctx := context.Background()
+
+	vevent := func(uid, start, end, summary string) string {
+		return strings.Join([]string{
+			"BEGIN:VEVENT", "UID:" + uid, "DTSTART:" + start, "DTEND:" + end, "SUMMARY:" + summary, "END:VEVENT",
+		}, "\r\n")
+	}
+	vcalendar := func(events ...string) []byte {
+		return []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "\r\n") + "\r\nEND:VCALENDAR\r\n")
+	}
+	titles := func() []string {
+		events, err := calendar.SelectEvents(ctx)
+		require.NoError(t, err)
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.GetTitle())
+		}
+		return result
+	}
+
+	beginning := time.Date(2030, time.June, 3, 12, 0, 0, 0, time.UTC)
+	_, err := calendar.CreateEvent(ctx, &model.Event{
+		Title: "Обед", UserID: "alice", Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	t.Run("partial failure", func(t *testing.T) {
+		// Второе событие пересекается с обедом: не создается ни одно.
+		_, _, err := calendar.ImportEvents(ctx, "alice", vcalendar(
+			vevent("standup@example.com", "20300603T090000Z", "20300603T093000Z", "Планерка"),
+			vevent("review@example.com", "20300603T123000Z", "20300603T133000Z", "Ревью"),
+		))
+		require.ErrorIs(t, err, model.ErrDateBusy)
+		require.Equal(t, []string{"Обед"}, titles())
+	})
+
+	t.Run("reimport", func(t *testing.T) {
+		data := vcalendar(
+			vevent("standup@example.com", "20300603T090000Z", "20300603T093000Z", "Планерка"),
+			vevent("retro@example.com", "20300603T150000Z", "20300603T160000Z", "Ретро"),
+		)
+		imported, skipped, err := calendar.ImportEvents(ctx, "alice", data)
+		require.NoError(t, err)
+		require.Equal(t, 2, imported)
+		require.Zero(t, skipped)
+
+		imported, skipped, err = calendar.ImportEvents(ctx, "alice", data)
+		require.NoError(t, err)
+		require.Zero(t, imported)
+		require.Equal(t, 2, skipped)
+		require.Equal(t, []string{"Планерка", "Обед", "Ретро"}, titles())
+
+		// Выгруженные события не дублируются при загрузке обратно.
+		exported, err := calendar.ExportEvents(ctx, "alice")
+		require.NoError(t, err)
+		imported, skipped, err = calendar.ImportEvents(ctx, "alice", exported)
+		require.NoError(t, err)
+		require.Zero(t, imported)
+		require.Equal(t, 3, skipped)
+
+		// UID другого пользователя не мешает загрузке.
+		imported, _, err = calendar.ImportEvents(ctx, "bob", data)
+		require.NoError(t, err)
+		require.Equal(t, 2, imported)
+	})
+}
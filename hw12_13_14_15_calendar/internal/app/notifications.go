@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectNotificationSettings получение настроек уведомлений пользователя userID.
+// Возвращает model.ErrNotificationSettingsNotFound, если они не заданы.
+func (calendar *Calendar) SelectNotificationSettings(
+	ctx context.Context, userID string,
+) (model.NotificationSettings, error) {
+	if err := authorize(ctx, userID); err != nil {
+		return model.NotificationSettings{}, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SelectNotificationSettings(ctx, userID)
+}
+
+// SetNotificationSettings задает или заменяет настройки уведомлений пользователя.
+// Некорректные поля возвращаются списком в *ValidationError до обращения к хранилищу.
+func (calendar *Calendar) SetNotificationSettings(ctx context.Context, settings model.NotificationSettings) error {
+	if err := authorize(ctx, settings.UserID); err != nil {
+		return err
+	}
+	if err := ValidateNotificationSettings(settings); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.SetNotificationSettings(ctx, settings)
+}
+
+// DeleteNotificationSettings удаляет настройки уведомлений пользователя,
+// после чего сообщения доставляются ему по каналам model.DefaultChannels.
+func (calendar *Calendar) DeleteNotificationSettings(ctx context.Context, userID string) error {
+	if err := authorize(ctx, userID); err != nil {
+		return err
+	}
+
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.DeleteNotificationSettings(ctx, userID)
+}
+
+// SelectRecipient возвращает получателя сообщения вместе с его настройками уведомлений.
+// У пользователя без настроек Settings содержит только UserID. Возвращает model.ErrUserNotFound,
+// если пользователя нет.
+func (calendar *Calendar) SelectRecipient(ctx context.Context, userID string) (model.Recipient, error) {
+	if err := authorize(ctx, userID); err != nil {
+		return model.Recipient{}, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	user, err := calendar.selectUser(ctx, userID)
+	if err != nil {
+		return model.Recipient{}, err
+	}
+	settings, err := calendar.storage.SelectNotificationSettings(ctx, userID)
+	if errors.Is(err, model.ErrNotificationSettingsNotFound) {
+		settings, err = model.NotificationSettings{UserID: userID}, nil
+	}
+	if err != nil {
+		return model.Recipient{}, err
+	}
+	return model.Recipient{User: user, Settings: settings}, nil
+}
+
+// DeliveredChannels возвращает каналы, по которым уже доставлено сообщение с ключом идемпотентности key.
+func (calendar *Calendar) DeliveredChannels(ctx context.Context, key string) ([]model.Channel, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SelectDeliveredChannels(ctx, key)
+}
+
+// MarkDelivered отмечает доставку сообщения с ключом идемпотентности key по каналу channel.
+func (calendar *Calendar) MarkDelivered(ctx context.Context, key string, channel model.Channel) error {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.MarkDelivered(ctx, key, channel, time.Now())
+}
+
+// PurgeDeliveries удаляет отметки о доставках, сделанных раньше before. Повтор сообщения,
+// доставленного раньше before, уже не будет распознан.
+func (calendar *Calendar) PurgeDeliveries(ctx context.Context, before time.Time) error {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	return calendar.storage.DeleteDeliveries(ctx, before)
+}
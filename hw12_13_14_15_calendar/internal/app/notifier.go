@@ -0,0 +1,234 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/mailer"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+const (
+	// WebhookSignatureHeader заголовок с подписью запроса: "sha256=" и HMAC-SHA256 в шестнадцатеричном виде.
+	WebhookSignatureHeader = "X-Calendar-Signature"
+	// WebhookTimestampHeader заголовок со временем отправки запроса в секундах Unix, входит в подпись.
+	WebhookTimestampHeader = "X-Calendar-Timestamp"
+)
+
+var (
+	// ErrNoEmail у получателя сообщения не указан адрес электронной почты.
+	ErrNoEmail = errors.New("recipient has no email")
+	// ErrNoWebhook у получателя сообщения не указан адрес для канала webhook.
+	ErrNoWebhook = errors.New("recipient has no webhook url")
+	// ErrWebhookStatus адрес получателя ответил статусом, отличным от 2xx.
+	ErrWebhookStatus = errors.New("webhook responded with unexpected status")
+	// ErrWebhookAddress адрес получателя не https или указывает на внутренний адрес.
+	ErrWebhookAddress = errors.New("webhook address is not allowed")
+)
+
+// Notifier доставляет сообщение получателю по одному каналу.
+type Notifier interface {
+	Notify(ctx context.Context, notice model.Notice, recipient model.Recipient) error
+}
+
+// Mailer отправляет письма.
+type Mailer interface {
+	Send(ctx context.Context, message mailer.Message) error
+}
+
+// EmailNotifier доставляет сообщения письмами, сформированными по шаблону.
+type EmailNotifier struct {
+	mailer Mailer
+}
+
+func NewEmailNotifier(mailer Mailer) *EmailNotifier {
+	return &EmailNotifier{mailer: mailer}
+}
+
+// Notify отправляет письмо на адрес получателя. Возвращает ErrNoEmail, если у получателя нет адреса.
+func (n *EmailNotifier) Notify(ctx context.Context, notice model.Notice, recipient model.Recipient) error {
+	if recipient.User.Email == "" {
+		return fmt.Errorf("%w: %s", ErrNoEmail, recipient.User.ID)
+	}
+	message, err := mailer.Render(notice, &recipient.User)
+	if err != nil {
+		return fmt.Errorf("failed to render message: %w", err)
+	}
+	return n.mailer.Send(ctx, message)
+}
+
+// WebhookNotifier доставляет сообщения POST-запросом с телом model.Notice в JSON на адрес из настроек
+// получателя. Запрос подписывается ключом secret, см. SignWebhook.
+// Адрес задает пользователь, поэтому запросы отправляются только по https, без перенаправлений
+// и только на публичные IP-адреса, кроме узлов из config.WebhookConfig.AllowedHosts.
+type WebhookNotifier struct {
+	client *http.Client
+	secret []byte
+	now    func() time.Time
+}
+
+func NewWebhookNotifier(config config.WebhookConfig) *WebhookNotifier {
+	allowed := make(map[string]bool, len(config.AllowedHosts))
+	for _, host := range config.AllowedHosts {
+		allowed[strings.ToLower(host)] = true
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	public := &net.Dialer{Timeout: 30 * time.Second, Control: checkWebhookAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Через прокси соединение устанавливается с ним, и адрес получателя не проверяется.
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(address); err == nil && allowed[strings.ToLower(host)] {
+			return dialer.DialContext(ctx, network, address)
+		}
+		return public.DialContext(ctx, network, address)
+	}
+
+	return &WebhookNotifier{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secret: []byte(config.Secret),
+		now:    time.Now,
+	}
+}
+
+// specialPrefixes диапазоны специального назначения из реестров IANA, которые не являются публичными адресами
+// в интернете или указывают на другие адреса: общие адреса провайдера, документация, тестирование,
+// трансляция IPv4 и IPv6, а также устаревшие и зарезервированные диапазоны.
+var specialPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.88.99.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("3fff::/20"),
+	netip.MustParsePrefix("5f00::/16"),
+	netip.MustParsePrefix("fec0::/10"),
+}
+
+// checkWebhookAddress запрещает соединение с IP-адресом, который не является публичным: адресом
+// локальной сети, loopback, link-local, включая адрес метаданных облака 169.254.169.254, multicast
+// или адресом из диапазонов специального назначения. Адрес IPv4, записанный как IPv6, проверяется как IPv4.
+// Проверяется адрес, с которым действительно устанавливается соединение, после разрешения имени.
+func checkWebhookAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !publicAddress(ip.Unmap()) {
+		return fmt.Errorf("%w: %s", ErrWebhookAddress, host)
+	}
+	return nil
+}
+
+// publicAddress сообщает, является ли ip публичным адресом unicast.
+func publicAddress(ip netip.Addr) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range specialPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Notify отправляет сообщение на адрес получателя. Возвращает ErrNoWebhook, если адрес не задан,
+// ErrWebhookAddress, если адрес не https или указывает на внутренний адрес,
+// и ErrWebhookStatus, если адрес ответил статусом, отличным от 2xx, в том числе перенаправлением.
+func (n *WebhookNotifier) Notify(ctx context.Context, notice model.Notice, recipient model.Recipient) error {
+	if recipient.Settings.WebhookURL == "" {
+		return fmt.Errorf("%w: %s", ErrNoWebhook, recipient.User.ID)
+	}
+	// Адреса, сохраненные до того, как стал обязателен https, не используются.
+	if u, err := url.Parse(recipient.Settings.WebhookURL); err != nil || u.Scheme != "https" {
+		return fmt.Errorf("%w: %s", ErrWebhookAddress, recipient.Settings.WebhookURL)
+	}
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient.Settings.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(n.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(n.secret, timestamp, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s", ErrWebhookStatus, resp.Status)
+	}
+	return nil
+}
+
+// SignWebhook возвращает значение заголовка WebhookSignatureHeader: HMAC-SHA256 с ключом secret
+// от времени отправки timestamp и тела запроса body, соединенных точкой. Получатель проверяет подпись,
+// вычисляя ее так же, и отклоняет запросы со старым timestamp.
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// LogNotifier записывает сообщения в журнал вместо доставки, используется при отладке.
+type LogNotifier struct {
+	logger *logger.Logger
+}
+
+func NewLogNotifier(logger *logger.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify записывает сообщение в журнал.
+func (n *LogNotifier) Notify(_ context.Context, notice model.Notice, recipient model.Recipient) error {
+	n.logger.Info("Notice %q about event %s for %s: %s at %s", notice.Type, notice.EventID, recipient.User.ID,
+		notice.Title, notice.Beginning.Format(time.RFC3339))
+	return nil
+}
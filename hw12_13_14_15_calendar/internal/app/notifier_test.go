@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifierAddress(t *testing.T) {
+	requests := 0
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "https://169.254.169.254/latest/meta-data/", http.StatusFound)
+		}
+	}))
+	defer webhook.Close()
+
+	ctx := context.Background()
+	notify := func(notifier *WebhookNotifier, url string) error {
+		return notifier.Notify(ctx, model.Notice{EventID: "1"}, model.Recipient{
+			User:     model.User{ID: "1"},
+			Settings: model.NotificationSettings{WebhookURL: url},
+		})
+	}
+
+	// Адрес, который разрешается в loopback, запрещен, пока узел не разрешен явно.
+	public := NewWebhookNotifier(config.WebhookConfig{Secret: "secret", Timeout: 5 * time.Second})
+	trust(public, webhook)
+	require.True(t, errors.Is(notify(public, webhook.URL), ErrWebhookAddress))
+	require.True(t, errors.Is(notify(public, "https://169.254.169.254/latest/meta-data/"), ErrWebhookAddress))
+	require.True(t, errors.Is(notify(public, "https://10.0.0.1/hook"), ErrWebhookAddress))
+	require.Zero(t, requests)
+
+	allowed := NewWebhookNotifier(config.WebhookConfig{
+		Secret: "secret", Timeout: 5 * time.Second, AllowedHosts: []string{"127.0.0.1"},
+	})
+	trust(allowed, webhook)
+	require.NoError(t, notify(allowed, webhook.URL))
+	require.Equal(t, 1, requests)
+
+	// Только https, перенаправления не выполняются.
+	require.True(t, errors.Is(notify(allowed, "http"+webhook.URL[len("https"):]), ErrWebhookAddress))
+	require.True(t, errors.Is(notify(allowed, webhook.URL+"/redirect"), ErrWebhookStatus))
+	require.Equal(t, 2, requests)
+	require.False(t, retryable(notify(public, webhook.URL)))
+}
+
+func TestCheckWebhookAddress(t *testing.T) {
+	for _, host := range []string{
+		"0.0.0.0", "0.1.2.3", "10.1.2.3", "100.64.0.1", "100.127.255.254", "127.0.0.1", "169.254.169.254",
+		"172.16.0.1", "192.0.0.8", "192.0.2.1", "192.88.99.1", "192.168.1.1", "198.18.0.1", "198.19.255.255",
+		"198.51.100.1", "203.0.113.1", "224.0.0.1", "240.0.0.1", "255.255.255.255",
+		"::", "::1", "::ffff:10.0.0.1", "::ffff:100.64.0.1", "64:ff9b::a00:1", "100::1", "2001::1",
+		"2001:db8::1", "2002:a00:1::1", "fc00::1", "fd12:3456::1", "fe80::1", "fec0::1", "ff02::1",
+		"localhost", "",
+	} {
+		err := checkWebhookAddress("tcp", net.JoinHostPort(host, "443"), nil)
+		require.True(t, errors.Is(err, ErrWebhookAddress), host)
+	}
+
+	for _, host := range []string{"8.8.8.8", "100.128.0.1", "198.20.0.1", "::ffff:8.8.8.8", "2a00:1450:4001::1"} {
+		require.NoError(t, checkWebhookAddress("tcp", net.JoinHostPort(host, "443"), nil), host)
+	}
+}
+
+// trust добавляет сертификат тестового сервера в доверенные сертификаты notifier.
+func trust(notifier *WebhookNotifier, server *httptest.Server) {
+	trusted := server.Client().Transport.(*http.Transport).TLSClientConfig
+	notifier.client.Transport.(*http.Transport).TLSClientConfig = trusted
+}
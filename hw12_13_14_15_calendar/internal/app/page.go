@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// pageToken содержимое токена следующей страницы: ключ сортировки последнего элемента страницы
+// и отпечаток запроса, чтобы токен нельзя было применить к другому фильтру или порядку.
+type pageToken struct {
+	Query     string    `json:"q"`
+	ID        string    `json:"id"`
+	Beginning time.Time `json:"b"`
+	Value     string    `json:"v,omitempty"`
+}
+
+// encode кодирует токен в непрозрачную строку для клиента.
+func (t pageToken) encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken разбирает токен страницы, выданный для запроса с отпечатком query.
+func decodePageToken(v *ValidationError, token, query string) *pageToken {
+	if token == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	t := &pageToken{}
+	if err != nil || json.Unmarshal(data, t) != nil || t.ID == "" {
+		v.add("pageToken", "is invalid")
+		return nil
+	}
+	if t.Query != query {
+		v.add("pageToken", "does not match the query")
+		return nil
+	}
+	return t
+}
+
+// fingerprint возвращает отпечаток параметров запроса, которые должны совпадать на всех страницах.
+func fingerprint(values ...interface{}) string {
+	data, _ := json.Marshal(values)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// validatePage проверяет размер страницы и порядок сортировки и подставляет значения по умолчанию.
+func validatePage(v *ValidationError, page model.PageRequest, orders []string) (string, int) {
+	pageSize := page.PageSize
+	if pageSize == 0 {
+		pageSize = model.DefaultPageSize
+	}
+	if pageSize < 1 || pageSize > model.MaxPageSize {
+		v.add("pageSize", "must be between 1 and "+strconv.Itoa(model.MaxPageSize))
+	}
+
+	orderBy := page.OrderBy
+	if orderBy == "" {
+		orderBy = orders[0]
+	}
+	if !contains(orders, orderBy) {
+		v.add("orderBy", "must be one of "+strings.Join(orders, ", "))
+	}
+	return orderBy, pageSize
+}
+
+// ListEvents получение страницы событий, подходящих под фильтр, и токена следующей страницы
+// (пустой на последней странице). Обычному пользователю возвращаются только доступные ему события.
+// Некорректные параметры возвращаются списком в *ValidationError.
+func (calendar *Calendar) ListEvents(ctx context.Context, filter model.EventFilter, page model.PageRequest) (
+	[]model.IEvent, string, error,
+) {
+	v := &ValidationError{}
+	if filter.From.IsZero() != filter.To.IsZero() {
+		v.add("from", "must be set together with to")
+	} else if filter.Period() && !filter.From.Before(filter.To) {
+		v.add("to", "must be after from")
+	}
+	orderBy, pageSize := validatePage(v, page, model.EventOrders)
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	visibility, err := calendar.eventVisibility(ctx, filter.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.Visibility = visibility
+	if filter.CalendarID != "" {
+		if _, err := calendar.authorizeCalendar(ctx, filter.CalendarID, model.RoleRead); err != nil {
+			return nil, "", err
+		}
+	}
+
+	query := model.EventQuery{EventFilter: filter, OrderBy: orderBy, PageSize: pageSize}
+	queryFingerprint := fingerprint(filter.UserID, filter.CalendarID, filter.Title, filter.From, filter.To, orderBy)
+	if token := decodePageToken(v, page.PageToken, queryFingerprint); token != nil {
+		query.After = &model.Event{ID: token.ID, Beginning: token.Beginning, Title: token.Value}
+	}
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	storageEvents, last, err := calendar.storage.ListEvents(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	events := make([]model.IEvent, 0, len(storageEvents))
+	for _, storageEvent := range storageEvents {
+		event := storageEvent
+		events = append(events, &event)
+	}
+
+	if last == nil {
+		return events, "", nil
+	}
+	next := pageToken{Query: queryFingerprint, ID: last.ID, Beginning: last.Beginning}
+	if strings.TrimPrefix(orderBy, "-") == model.OrderByTitle {
+		next.Value = last.Title
+	}
+	return events, next.encode(), nil
+}
+
+// ListUsers получение страницы пользователей и токена следующей страницы (пустой на последней странице).
+// Обычному пользователю возвращается только его учетная запись.
+func (calendar *Calendar) ListUsers(ctx context.Context, page model.PageRequest) ([]model.IUser, string, error) {
+	v := &ValidationError{}
+	orderBy, pageSize := validatePage(v, page, model.UserOrders)
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	query := model.UserQuery{OrderBy: orderBy, PageSize: pageSize}
+	if principal, ok := restricted(ctx); ok {
+		query.UserID = principal.UserID
+	}
+
+	queryFingerprint := fingerprint(query.UserID, orderBy)
+	if token := decodePageToken(v, page.PageToken, queryFingerprint); token != nil {
+		query.After = &model.User{ID: token.ID, LastName: token.Value, Email: token.Value}
+	}
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	storageUsers, last, err := calendar.storage.ListUsers(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	users := make([]model.IUser, 0, len(storageUsers))
+	for _, storageUser := range storageUsers {
+		user := storageUser
+		users = append(users, &user)
+	}
+
+	if last == nil {
+		return users, "", nil
+	}
+	next := pageToken{Query: queryFingerprint, ID: last.ID, Value: last.LastName}
+	if strings.TrimPrefix(orderBy, "-") == model.OrderByEmail {
+		next.Value = last.Email
+	}
+	return users, next.encode(), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
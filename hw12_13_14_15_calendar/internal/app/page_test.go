@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarListEvents(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	ctx := context.Background()
+
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		beginning := day.Add(time.Duration(9+i) * time.Hour)
+		_, err := calendar.CreateEvent(ctx, &model.Event{
+			Title: fmt.Sprintf("Встреча %d", i), UserID: "alice", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	_, err := calendar.CreateEvent(ctx, &model.Event{
+		Title: "Обед", UserID: "bob", Beginning: day.Add(13 * time.Hour), Finish: day.Add(14 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	t.Run("pages follow each other", func(t *testing.T) {
+		filter := model.EventFilter{Title: "встреча"}
+		page := model.PageRequest{OrderBy: model.OrderByBeginningDesc, PageSize: 2}
+
+		var titles []string
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+			events, next, err := calendar.ListEvents(ctx, filter, page)
+			require.NoError(t, err)
+			for _, event := range events {
+				titles = append(titles, event.GetTitle())
+			}
+			if next == "" {
+				break
+			}
+			page.PageToken = next
+		}
+		require.Equal(t, []string{"Встреча 4", "Встреча 3", "Встреча 2", "Встреча 1", "Встреча 0"}, titles)
+	})
+
+	t.Run("token is bound to query", func(t *testing.T) {
+		_, next, err := calendar.ListEvents(ctx, model.EventFilter{}, model.PageRequest{PageSize: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, next)
+
+		bob := model.EventFilter{UserID: "bob"}
+		_, _, err = calendar.ListEvents(ctx, bob, model.PageRequest{PageSize: 1, PageToken: next})
+		require.Equal(t, []string{"pageToken"}, fieldNames(t, err))
+		_, _, err = calendar.ListEvents(ctx, model.EventFilter{}, model.PageRequest{OrderBy: "title", PageToken: next})
+		require.Equal(t, []string{"pageToken"}, fieldNames(t, err))
+		_, _, err = calendar.ListEvents(ctx, model.EventFilter{}, model.PageRequest{PageToken: "garbage"})
+		require.Equal(t, []string{"pageToken"}, fieldNames(t, err))
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, _, err := calendar.ListEvents(ctx, model.EventFilter{From: day}, model.PageRequest{
+			OrderBy: "finish", PageSize: model.MaxPageSize + 1,
+		})
+		require.Equal(t, []string{"from", "pageSize", "orderBy"}, fieldNames(t, err))
+
+		_, _, err = calendar.ListEvents(ctx, model.EventFilter{From: day, To: day}, model.PageRequest{PageSize: -1})
+		require.Equal(t, []string{"to", "pageSize"}, fieldNames(t, err))
+	})
+
+	t.Run("user sees only own events", func(t *testing.T) {
+		bob := auth.WithPrincipal(ctx, auth.Principal{UserID: "bob"})
+		events, next, err := calendar.ListEvents(bob, model.EventFilter{}, model.PageRequest{})
+		require.NoError(t, err)
+		require.Empty(t, next)
+		require.Len(t, events, 1)
+		require.Equal(t, "Обед", events[0].GetTitle())
+	})
+}
+
+func TestCalendarListUsers(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	ctx := context.Background()
+
+	ids := make([]string, 0)
+	for _, lastName := range []string{"Петров", "Иванов", "Сидоров"} {
+		created, err := calendar.CreateUser(ctx, &model.User{LastName: lastName})
+		require.NoError(t, err)
+		ids = append(ids, created.GetID())
+	}
+
+	users, next, err := calendar.ListUsers(ctx, model.PageRequest{OrderBy: model.OrderByLastNameDesc, PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Сидоров", "Петров"}, []string{users[0].GetLastName(), users[1].GetLastName()})
+
+	users, next, err = calendar.ListUsers(ctx, model.PageRequest{
+		OrderBy: model.OrderByLastNameDesc, PageSize: 2, PageToken: next,
+	})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, users, 1)
+	require.Equal(t, "Иванов", users[0].GetLastName())
+
+	_, _, err = calendar.ListUsers(ctx, model.PageRequest{OrderBy: "age"})
+	require.Equal(t, []string{"orderBy"}, fieldNames(t, err))
+
+	alice := auth.WithPrincipal(ctx, auth.Principal{UserID: ids[0]})
+	users, _, err = calendar.ListUsers(alice, model.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, ids[0], users[0].GetID())
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	memorybroker "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker/memory"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// channelNotifier передает доставленные сообщения в канал.
+type channelNotifier chan model.Notice
+
+func (n channelNotifier) Notify(_ context.Context, notice model.Notice, _ model.Recipient) error {
+	n <- notice
+	return nil
+}
+
+func TestPipeline(t *testing.T) {
+	require.NoError(t, config.LoadConfig("../../config/sender_config.toml"))
+	conf := config.Get()
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	broker := memorybroker.New()
+	require.NoError(t, broker.QueueDeclare(*conf.RabbitMQ.Queue))
+	publish := config.PublishConfig{ContentType: "application/json"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notices := make(channelNotifier, 10)
+	sender := NewSender(calendar, broker, map[model.Channel]Notifier{model.ChannelEmail: notices}, log)
+	stopped := make(chan error)
+	go func() {
+		stopped <- sender.Start(ctx)
+	}()
+	receive := func() model.Notice {
+		select {
+		case notice := <-notices:
+			return notice
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "notification is not delivered")
+			return model.Notice{}
+		}
+	}
+
+	user, err := calendar.CreateUser(ctx, &model.User{FirstName: "Алиса", Email: "alice@example.com"})
+	require.NoError(t, err)
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	notification := beginning.Add(-15 * time.Minute)
+	created, err := calendar.CreateEvent(ctx, &model.Event{
+		Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		Notification: notification, UserID: user.GetID(),
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(calendar, log, time.Minute)
+	scheduler.SetClock(func() time.Time { return notification.Add(30 * time.Second) })
+	scheduler.HandleNotifications(ctx)
+	relayed, err := NewRelay(calendar, broker, publish,
+		config.RelayConfig{Interval: time.Minute, BatchSize: 10}, log).Drain(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, relayed)
+
+	notice := receive()
+	require.Equal(t, created.GetID(), notice.EventID)
+	require.Equal(t, user.GetID(), notice.RecipientID)
+	require.Equal(t, beginning, notice.Beginning)
+
+	// Повторная публикация с тем же ключом не доставляется: следующим приходит другое сообщение.
+	occurrence := model.Event{
+		ID: created.GetID(), Title: "Планерка", Beginning: beginning, Notification: notification, UserID: user.GetID(),
+	}
+	body, err := json.Marshal(occurrence)
+	require.NoError(t, err)
+	require.NoError(t, broker.PublishWithKey(ctx, publish, model.NotificationKey(occurrence), body))
+	occurrence.Title = "Ретро"
+	body, err = json.Marshal(occurrence)
+	require.NoError(t, err)
+	require.NoError(t, broker.PublishWithKey(ctx, publish, "notification:other", body))
+	require.Equal(t, "Ретро", receive().Title)
+
+	cancel()
+	require.NoError(t, <-stopped)
+	require.Equal(t, map[model.Channel]ChannelStats{model.ChannelEmail: {Delivered: 2}}, sender.Stats())
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// Relay публикует в брокер сообщения outbox: уведомления и напоминания планировщика и приглашения
+// участникам. Сообщение удаляется из outbox после публикации, поэтому при сбое оно публикуется
+// повторно с тем же ключом идемпотентности, и повтор отбрасывает рассыльщик.
+type Relay struct {
+	app      *Calendar
+	broker   broker.Broker
+	publish  config.PublishConfig
+	logger   *logger.Logger
+	config   config.RelayConfig
+	stopChan chan struct{}
+}
+
+func NewRelay(
+	app *Calendar, broker broker.Broker, publish config.PublishConfig, relay config.RelayConfig, logger *logger.Logger,
+) *Relay {
+	return &Relay{
+		app:      app,
+		broker:   broker,
+		publish:  publish,
+		logger:   logger,
+		config:   relay,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start публикует сообщения outbox, пока он не опустеет, и затем проверяет его с интервалом Interval.
+func (r *Relay) Start(ctx context.Context) error {
+	if r.config.Interval <= 0 || r.config.BatchSize <= 0 {
+		return fmt.Errorf("relay interval and batch size must be positive")
+	}
+	r.logger.Info("Relay started")
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			relayed, err := r.Drain(ctx)
+			if err != nil {
+				r.logger.Error("Error relaying outbox: %v", err)
+			}
+			if err != nil || relayed < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			r.logger.Info("Relay stopped")
+			return nil
+		case <-r.stopChan:
+			r.logger.Info("Relay stop signal received")
+			return nil
+		}
+	}
+}
+
+// Drain публикует до BatchSize первых сообщений outbox и возвращает число опубликованных.
+func (r *Relay) Drain(ctx context.Context) (int, error) {
+	relayed, err := r.app.RelayOutbox(ctx, r.config.BatchSize, func(message model.OutboxMessage) error {
+		return r.broker.PublishWithKey(ctx, r.publish, message.Key, message.Body)
+	})
+	if relayed > 0 {
+		r.logger.Debug("Relayed %d outbox messages", relayed)
+	}
+	return relayed, err
+}
+
+// Stop останавливает ретранслятор.
+func (r *Relay) Stop() {
+	close(r.stopChan)
+}
+
+// RelayOutbox передает publish до limit первых сообщений outbox и удаляет опубликованные.
+// Хранилище само исключает одновременную передачу одного сообщения, поэтому блокировка
+// Calendar не удерживается на время публикации.
+func (calendar *Calendar) RelayOutbox(
+	ctx context.Context, limit int, publish func(model.OutboxMessage) error,
+) (int, error) {
+	return calendar.storage.RelayOutbox(ctx, limit, publish)
+}
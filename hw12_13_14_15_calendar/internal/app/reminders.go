@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// scheduleReminders планирует каждое напоминание события на первое повторение, которое еще не началось к now.
+// Если время и повторение события не изменились по сравнению с сохраненной версией current, напоминание
+// с теми же MinutesBefore и Channel остается прежним вместе с идентификатором, расписанием и временем
+// отправки, поэтому изменение события не отправляет напоминание повторно. Для нового события current пустое.
+// Напоминания упорядочиваются от самого раннего, как их возвращает хранилище.
+func scheduleReminders(event *model.Event, current model.Event, now time.Time) error {
+	if len(event.Reminders) == 0 {
+		return nil
+	}
+	kept := make(map[model.Reminder]model.Reminder)
+	if current.ID == event.ID && current.SameSchedule(*event) {
+		for _, reminder := range current.Reminders {
+			kept[model.Reminder{MinutesBefore: reminder.MinutesBefore, Channel: reminder.Channel}] = reminder
+		}
+	}
+
+	reminders := make([]model.Reminder, 0, len(event.Reminders))
+	for _, reminder := range event.Reminders {
+		if existing, ok := kept[model.Reminder{MinutesBefore: reminder.MinutesBefore, Channel: reminder.Channel}]; ok {
+			reminders = append(reminders, existing)
+			continue
+		}
+		scheduled, err := event.ScheduleReminder(model.Reminder{
+			MinutesBefore: reminder.MinutesBefore,
+			Channel:       reminder.Channel,
+		}, now)
+		if err != nil {
+			return err
+		}
+		reminders = append(reminders, scheduled)
+	}
+	sort.SliceStable(reminders, func(i, j int) bool {
+		if reminders[i].MinutesBefore != reminders[j].MinutesBefore {
+			return reminders[i].MinutesBefore > reminders[j].MinutesBefore
+		}
+		return reminders[i].Channel < reminders[j].Channel
+	})
+	event.Reminders = reminders
+	return nil
+}
+
+// DueReminders возвращает напоминания, время которых наступило к моменту t, вместе с событиями.
+func (calendar *Calendar) DueReminders(ctx context.Context, t time.Time) ([]model.DueReminder, error) {
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SelectDueReminders(ctx, t)
+}
+
+// ClaimReminder отмечает напоминание отправленным в момент now, переносит его на следующее
+// еще не начавшееся повторение события и в той же транзакции добавляет напоминание в outbox.
+// Возвращает false, если напоминание уже забрал другой планировщик или событие изменилось.
+// Напоминание о повторении, которое уже началось, забирается, но не отправляется, и send возвращает false.
+func (calendar *Calendar) ClaimReminder(ctx context.Context, due model.DueReminder, now time.Time) (
+	claimed, send bool, err error,
+) {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+
+	from := due.Reminder.Occurrence.Add(time.Second)
+	if now.After(from) {
+		from = now
+	}
+	next, err := due.Event.ScheduleReminder(due.Reminder, from)
+	if err != nil {
+		return false, false, err
+	}
+	next.SentAt = now
+
+	var outbox []model.OutboxMessage
+	send = due.Reminder.Occurrence.After(now)
+	if send {
+		body, err := json.Marshal(due.Notice())
+		if err != nil {
+			return false, false, err
+		}
+		outbox = append(outbox, model.OutboxMessage{Key: model.ReminderKey(due), Body: body})
+	}
+
+	claimed, err = calendar.storage.ClaimReminder(ctx, due.Reminder, next, outbox...)
+	if err != nil || !claimed {
+		return false, false, err
+	}
+	return true, send, nil
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarReminders(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	ctx := context.Background()
+
+	user, err := calendar.CreateUser(ctx, &model.User{FirstName: "Алиса"})
+	require.NoError(t, err)
+	now := time.Now().UTC().Truncate(time.Second)
+	beginning := now.Add(10 * time.Minute)
+
+	t.Run("validation", func(t *testing.T) {
+		event := &model.Event{
+			Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour), UserID: user.GetID(),
+			Reminders: []model.Reminder{
+				{MinutesBefore: -1},
+				{MinutesBefore: 40321},
+				{MinutesBefore: 15, Channel: "sms"},
+				{MinutesBefore: 5},
+				{MinutesBefore: 5},
+			},
+		}
+		_, err := calendar.CreateEvent(ctx, event)
+		require.Equal(t, []string{
+			"reminders[0].minutesBefore", "reminders[1].minutesBefore", "reminders[2].channel",
+			"reminders[4].minutesBefore",
+		}, fieldNames(t, err))
+
+		event.Reminders = make([]model.Reminder, model.MaxReminders+1)
+		for i := range event.Reminders {
+			event.Reminders[i].MinutesBefore = i
+		}
+		_, err = calendar.CreateEvent(ctx, event)
+		require.Equal(t, []string{"reminders"}, fieldNames(t, err))
+	})
+
+	created, err := calendar.CreateEvent(ctx, &model.Event{
+		Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour), UserID: user.GetID(),
+		Recurrence: "FREQ=WEEKLY",
+		Reminders: []model.Reminder{
+			{MinutesBefore: 15, Channel: model.ChannelEmail},
+			{MinutesBefore: 5, FireAt: now.AddDate(1, 0, 0), SentAt: now},
+		},
+	})
+	require.NoError(t, err)
+	event := created.(*model.Event)
+
+	t.Run("scheduled on create", func(t *testing.T) {
+		require.Len(t, event.Reminders, 2)
+		require.NotEqual(t, event.Reminders[0].ID, event.Reminders[1].ID)
+		require.Equal(t, beginning.Add(-15*time.Minute), event.Reminders[0].FireAt)
+		require.Equal(t, beginning.Add(-5*time.Minute), event.Reminders[1].FireAt)
+		require.True(t, event.Reminders[1].SentAt.IsZero())
+
+		due, err := calendar.DueReminders(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, event.Reminders[0].ID, due[0].Reminder.ID)
+		require.Equal(t, event.ID, due[0].Event.ID)
+	})
+
+	t.Run("scheduler sends once", func(t *testing.T) {
+		require.NoError(t, config.LoadConfig("../../config/scheduler_config.toml"))
+		broker := &fakeBroker{}
+		scheduler := NewScheduler(calendar, log, time.Minute)
+
+		scheduler.HandleNotifications(ctx)
+		scheduler.HandleNotifications(ctx)
+		_, err := newTestRelay(calendar, broker).Drain(ctx)
+		require.NoError(t, err)
+		require.Len(t, broker.published, 1)
+
+		var notice model.ReminderNotice
+		require.NoError(t, json.Unmarshal(broker.published[0], &notice))
+		require.Equal(t, model.ReminderNotice{
+			Type: model.ReminderMessage, ReminderID: event.Reminders[0].ID, EventID: event.ID, Title: "Планерка",
+			Beginning: beginning, Finish: beginning.Add(time.Hour), UserID: user.GetID(),
+			MinutesBefore: 15, Channel: model.ChannelEmail,
+		}, notice)
+
+		// Напоминание перенесено на следующее повторение и отмечено отправленным.
+		stored, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		reminder := stored.GetReminders()[0]
+		require.Equal(t, beginning.AddDate(0, 0, 7).Add(-15*time.Minute), reminder.FireAt)
+		require.False(t, reminder.SentAt.IsZero())
+	})
+
+	t.Run("kept on update", func(t *testing.T) {
+		stored, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		renamed := *stored.(*model.Event)
+		renamed.Title = "Еженедельная планерка"
+		require.NoError(t, calendar.UpdateEvent(ctx, &renamed))
+
+		// Отправленное напоминание не планируется заново и не отправляется повторно.
+		updated, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Equal(t, stored.GetReminders(), updated.GetReminders())
+
+		broker := &fakeBroker{}
+		NewScheduler(calendar, log, time.Minute).HandleNotifications(ctx)
+		relayed, err := newTestRelay(calendar, broker).Drain(ctx)
+		require.NoError(t, err)
+		require.Zero(t, relayed)
+	})
+
+	t.Run("claimed once", func(t *testing.T) {
+		stored, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		later := beginning.AddDate(0, 0, 7)
+		due, err := calendar.DueReminders(ctx, later)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		require.Equal(t, event.Reminders[1].ID, due[0].Reminder.ID)
+
+		// Повторение уже началось: напоминание забирается, но не отправляется.
+		claimed, send, err := calendar.ClaimReminder(ctx, due[0], later.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.False(t, send)
+		claimed, _, err = calendar.ClaimReminder(ctx, due[0], later.Add(time.Minute))
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		// Ранее выданная копия события не меняется.
+		require.Equal(t, beginning.Add(-5*time.Minute), stored.GetReminders()[1].FireAt)
+		claimed, send, err = calendar.ClaimReminder(ctx, due[1], later.Add(-time.Minute))
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.True(t, send)
+	})
+
+	t.Run("recomputed on update", func(t *testing.T) {
+		stored, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		moved := *stored.(*model.Event)
+		moved.Beginning = beginning.Add(24 * time.Hour)
+		moved.Finish = moved.Beginning.Add(time.Hour)
+		require.NoError(t, calendar.UpdateEvent(ctx, &moved))
+
+		updated, err := calendar.SelectEvent(ctx, event.ID)
+		require.NoError(t, err)
+		reminders := updated.GetReminders()
+		require.Len(t, reminders, 2)
+		require.Equal(t, moved.Beginning.Add(-15*time.Minute), reminders[0].FireAt)
+		require.Equal(t, moved.Beginning.Add(-5*time.Minute), reminders[1].FireAt)
+		require.True(t, reminders[0].SentAt.IsZero())
+
+		moved.Reminders = nil
+		require.NoError(t, calendar.UpdateEvent(ctx, &moved))
+		due, err := calendar.DueReminders(ctx, now.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+}
@@ -2,38 +2,51 @@ package app
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
-	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
-	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 )
 
 // Scheduler отвечает за периодическое сканирование базы данных,
-// отправку уведомлений через RabbitMQ и очистку старых событий.
+// постановку уведомлений в outbox и очистку старых событий.
 type Scheduler struct {
 	app      *Calendar
-	broker   broker.Broker
 	logger   *logger.Logger
 	interval time.Duration
-	stopChan chan struct{}
+	// maxLateness опоздание, после которого уведомление устаревает и пропускается, 0 - без ограничения.
+	maxLateness time.Duration
+	now         func() time.Time
+	stopChan    chan struct{}
 }
 
-func NewScheduler(app *Calendar, broker broker.Broker, logger *logger.Logger, interval time.Duration) *Scheduler {
+func NewScheduler(app *Calendar, logger *logger.Logger, interval time.Duration) *Scheduler {
 	return &Scheduler{
 		app:      app,
-		broker:   broker,
 		logger:   logger,
 		interval: interval,
+		now:      time.Now,
 		stopChan: make(chan struct{}),
 	}
 }
 
+// SetClock заменяет источник текущего времени планировщика, по умолчанию time.Now.
+func (s *Scheduler) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// SetMaxLateness задает опоздание, после которого уведомление не отправляется, например если планировщик
+// был остановлен. По умолчанию 0: отправляются все пропущенные уведомления.
+func (s *Scheduler) SetMaxLateness(maxLateness time.Duration) {
+	s.maxLateness = maxLateness
+}
+
 // Start запускает процесс планировщика, который выполняется с заданным интервалом.
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.logger.Info("Scheduler started")
+	if s.maxLateness > 0 {
+		s.logger.Info("Notifications late by more than %s are skipped", s.maxLateness)
+	}
 	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 
@@ -55,54 +68,103 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	}
 }
 
-// HandleNotifications обрабатывает уведомления, выбирая события из базы данных
-// и отправляя их через RabbitMQ.
+// HandleNotifications ставит в outbox уведомления о событиях, время которых наступило
+// к текущему моменту, включая пропущенные из-за опоздавших тактов или перезапуска планировщика,
+// если они опоздали не больше чем на maxLateness.
+// Для повторяющихся событий уведомление ставится по каждому повторению. Уведомление отмечается
+// отправленным в одной транзакции с записью в outbox, поэтому оно не теряется и не дублируется
+// при сбоях, а публикует его Relay. Затем в outbox ставятся наступившие напоминания о событиях.
 func (s *Scheduler) HandleNotifications(ctx context.Context) {
-	now := time.Now().Truncate(time.Second)
-	formattedTime := now.Format("2006-01-02 15:04:05")
-	t, err := time.Parse("2006-01-02 15:04:05", formattedTime)
+	now := s.now().UTC().Truncate(time.Second)
+	defer s.handleReminders(ctx, now)
+
+	var since time.Time
+	if s.maxLateness > 0 {
+		since = now.Add(-s.maxLateness)
+	}
+	events, err := s.app.DueNotifications(ctx, now, since)
 	if err != nil {
-		s.logger.Error("Error formatting time: %v", err)
+		s.logger.Error("Error getting due notifications: %v", err)
 		return
 	}
 
-	events, err := s.app.SelectEventsByTime(ctx, t)
+	// После ошибки следующие повторения серии не отмечаются, чтобы не потерять пропущенное.
+	failed := make(map[string]struct{})
+	for _, event := range events {
+		if _, ok := failed[event.ID]; ok {
+			continue
+		}
+		if err := s.app.EnqueueNotification(ctx, event); err != nil {
+			s.logger.Error("Error enqueueing notification of event %s: %v", event.ID, err)
+			failed[event.ID] = struct{}{}
+		}
+	}
+}
+
+// handleReminders ставит в outbox напоминания, время которых наступило к моменту now.
+// Напоминание забирается в хранилище вместе с записью в outbox, поэтому оно ставится ровно один раз,
+// даже если планировщиков несколько.
+func (s *Scheduler) handleReminders(ctx context.Context, now time.Time) {
+	reminders, err := s.app.DueReminders(ctx, now)
 	if err != nil {
-		s.logger.Error("Error getting upcoming events: %v", err)
+		s.logger.Error("Error getting due reminders: %v", err)
 		return
 	}
 
-	for _, event := range events {
-		notify := model.Event{
-			ID:           event.GetID(),
-			Title:        event.GetTitle(),
-			Description:  event.GetDescription(),
-			Notification: event.GetNotification(),
-		}
-		body, err := json.Marshal(notify)
+	for _, due := range reminders {
+		claimed, send, err := s.app.ClaimReminder(ctx, due, now)
 		if err != nil {
-			s.logger.Error("Error marshalling notification: %v", err)
+			s.logger.Error("Error claiming reminder %s: %v", due.Reminder.ID, err)
 			continue
 		}
-
-		if err := s.broker.PublishWithContext(ctx, *config.Get().RabbitMQ.Publish, body); err != nil {
-			s.logger.Error("Error publishing message: %v", err)
+		if claimed && !send {
+			s.logger.Info("Skipping stale reminder %s of event %s", due.Reminder.ID, due.Event.ID)
 		}
 	}
 }
 
 // cleanupOldEvents удаляет события, которые произошли более года назад.
+// Повторяющиеся события удаляются, только если у серии не осталось повторений.
 func (s *Scheduler) cleanupOldEvents(ctx context.Context) {
-	oldEvents, err := s.app.SelectEventsForMonth(ctx, time.Now().AddDate(-1, 0, 0))
+	yearAgo := s.now().AddDate(-1, 0, 0)
+	oldEvents, err := s.app.SelectEventsForMonth(ctx, yearAgo)
 	if err != nil {
 		s.logger.Error("Error selecting old events: %v", err)
 		return
 	}
+	deleted := make(map[string]struct{})
 	for _, event := range oldEvents {
+		if _, ok := deleted[event.GetID()]; ok {
+			continue
+		}
+		if event.GetRecurrence() != "" && hasOccurrencesAfter(event, yearAgo) {
+			continue
+		}
 		if err := s.app.DeleteEvent(ctx, event.GetID()); err != nil {
 			s.logger.Error("Error deleting old event: %v", err)
 		}
+		deleted[event.GetID()] = struct{}{}
+	}
+}
+
+// hasOccurrencesAfter проверяет, есть ли у серии повторения позже месяца, начинающегося в from.
+// Отсчет COUNT ведется от переданного повторения, поэтому проверка может лишь сохранить серию дольше нужного.
+func hasOccurrencesAfter(event model.IEvent, from time.Time) bool {
+	rule, err := model.ParseRecurrence(event.GetRecurrence())
+	if err != nil || rule == nil {
+		return false
+	}
+	if rule.Count == 0 && rule.Until.IsZero() {
+		return true
+	}
+
+	series := model.Event{
+		Beginning:  event.GetBeginning(),
+		Finish:     event.GetFinish(),
+		Recurrence: event.GetRecurrence(),
 	}
+	occurrences, err := series.Occurrences(from.AddDate(0, 1, 0), from.AddDate(100, 0, 0))
+	return err == nil && len(occurrences) > 0
 }
 
 // Stop останавливает планировщик, посылая сигнал остановки.
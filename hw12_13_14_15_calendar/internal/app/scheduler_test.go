@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerNotifications(t *testing.T) {
+	require.NoError(t, config.LoadConfig("../../config/scheduler_config.toml"))
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	broker := &fakeBroker{}
+	relay := newTestRelay(calendar, broker)
+	ctx := context.Background()
+	relayAll := func() int {
+		relayed, err := relay.Drain(ctx)
+		require.NoError(t, err)
+		return relayed
+	}
+
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	created, err := calendar.CreateEvent(ctx, &model.Event{
+		Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		Notification: beginning.Add(-15 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	now := beginning.Add(-time.Hour)
+	newScheduler := func() *Scheduler {
+		scheduler := NewScheduler(calendar, log, time.Minute)
+		scheduler.SetClock(func() time.Time { return now })
+		return scheduler
+	}
+	scheduler := newScheduler()
+
+	scheduler.HandleNotifications(ctx)
+	require.Zero(t, relayAll())
+
+	// Такт опоздал на полминуты и все равно отправляет уведомление.
+	now = beginning.Add(-15*time.Minute + 30*time.Second)
+	scheduler.HandleNotifications(ctx)
+	require.Equal(t, 1, relayAll())
+	var notified model.Event
+	require.NoError(t, json.Unmarshal(broker.published[0], &notified))
+	require.Equal(t, created.GetID(), notified.ID)
+	require.Equal(t, beginning.Add(-15*time.Minute), notified.Notification)
+	require.Equal(t, []string{model.NotificationKey(notified)}, broker.keys)
+
+	// Отправленное уведомление не повторяется ни на следующем такте, ни после перезапуска.
+	now = now.Add(time.Minute)
+	scheduler.HandleNotifications(ctx)
+	newScheduler().HandleNotifications(ctx)
+	require.Zero(t, relayAll())
+
+	t.Run("publish failure", func(t *testing.T) {
+		event := *created.(*model.Event)
+		event.Beginning = beginning.Add(24 * time.Hour)
+		event.Finish = event.Beginning.Add(time.Hour)
+		event.Recurrence = "FREQ=DAILY"
+		// Уведомления за двое суток: наступили уведомления о двух повторениях.
+		event.Notification = event.Beginning.Add(-48 * time.Hour)
+		require.NoError(t, calendar.UpdateEvent(ctx, &event))
+		now = event.Beginning.Add(-time.Hour)
+
+		broker.err = errors.New("unavailable")
+		scheduler.HandleNotifications(ctx)
+		_, err := relay.Drain(ctx)
+		require.Error(t, err)
+		broker.err = nil
+		require.Len(t, broker.published, 1)
+
+		// Уведомления остались в outbox и публикуются, когда брокер снова доступен.
+		scheduler.HandleNotifications(ctx)
+		require.Equal(t, 2, relayAll())
+		require.Len(t, broker.published, 3)
+		var first, second model.Event
+		require.NoError(t, json.Unmarshal(broker.published[1], &first))
+		require.NoError(t, json.Unmarshal(broker.published[2], &second))
+		require.Equal(t, event.Beginning, first.Beginning)
+		require.Equal(t, event.Beginning.AddDate(0, 0, 1), second.Beginning)
+
+		scheduler.HandleNotifications(ctx)
+		require.Zero(t, relayAll())
+	})
+}
+
+func TestSchedulerMaxLateness(t *testing.T) {
+	require.NoError(t, config.LoadConfig("../../config/scheduler_config.toml"))
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	broker := &fakeBroker{}
+	relay := newTestRelay(calendar, broker)
+	ctx := context.Background()
+
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	for i, title := range []string{"Планерка", "Ретро"} {
+		_, err := calendar.CreateEvent(ctx, &model.Event{
+			Title:        title,
+			Beginning:    beginning.Add(time.Duration(i) * 3 * time.Hour),
+			Finish:       beginning.Add(time.Duration(i)*3*time.Hour + time.Hour),
+			Notification: beginning.Add(time.Duration(i)*3*time.Hour - 15*time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	// Планировщик был остановлен, и оба события начались: уведомление, опоздавшее больше
+	// чем на maxLateness, пропускается, а остальное отправляется, хотя событие уже идет.
+	scheduler := NewScheduler(calendar, log, time.Minute)
+	scheduler.SetMaxLateness(2 * time.Hour)
+	scheduler.SetClock(func() time.Time { return beginning.Add(3*time.Hour + 10*time.Minute) })
+	scheduler.HandleNotifications(ctx)
+
+	relayed, err := relay.Drain(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, relayed)
+	var notified model.Event
+	require.NoError(t, json.Unmarshal(broker.published[0], &notified))
+	require.Equal(t, "Ретро", notified.Title)
+}
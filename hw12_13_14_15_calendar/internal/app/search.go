@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SearchEvents полнотекстовый поиск событий по названию и описанию. Возвращает не более limit
+// (0 - model.DefaultSearchLimit) событий, содержащих все слова text, в порядке убывания релевантности.
+// Пустой userID снимает ограничение по пользователю, но обычный пользователь ищет только
+// среди доступных ему событий.
+func (calendar *Calendar) SearchEvents(ctx context.Context, text, userID string, limit int) (
+	[]model.SearchResult, error,
+) {
+	v := &ValidationError{}
+	if strings.TrimSpace(text) == "" {
+		v.add("q", "must not be blank")
+	}
+	if limit == 0 {
+		limit = model.DefaultSearchLimit
+	}
+	if limit < 1 || limit > model.MaxSearchLimit {
+		v.add("limit", "must be between 1 and "+strconv.Itoa(model.MaxSearchLimit))
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	visibility, err := calendar.eventVisibility(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar.mutex.RLock()
+	defer calendar.mutex.RUnlock()
+
+	return calendar.storage.SearchEvents(ctx, model.SearchQuery{
+		Text: text, UserID: userID, Limit: limit, Visibility: visibility,
+	})
+}
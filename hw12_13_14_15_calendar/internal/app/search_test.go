@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarSearchEvents(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	ctx := context.Background()
+
+	beginning := time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC)
+	for _, user := range []string{"alice", "bob"} {
+		_, err := calendar.CreateEvent(ctx, &model.Event{
+			Title: "Созвон", Description: "Статус проекта", UserID: user,
+			Beginning: beginning, Finish: beginning.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		beginning = beginning.Add(2 * time.Hour)
+	}
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := calendar.SearchEvents(ctx, "  ", "", model.MaxSearchLimit+1)
+		require.Equal(t, []string{"q", "limit"}, fieldNames(t, err))
+		_, err = calendar.SearchEvents(ctx, "проект", "", -1)
+		require.Equal(t, []string{"limit"}, fieldNames(t, err))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		results, err := calendar.SearchEvents(ctx, "статус проекта", "", 0)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		results, err = calendar.SearchEvents(ctx, "статус проекта", "", 1)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "alice", results[0].Event.UserID)
+	})
+
+	t.Run("user searches only own events", func(t *testing.T) {
+		bob := auth.WithPrincipal(ctx, auth.Principal{UserID: "bob"})
+		results, err := calendar.SearchEvents(bob, "созвон", "", 0)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "bob", results[0].Event.UserID)
+
+		_, err = calendar.SearchEvents(bob, "созвон", "alice", 0)
+		require.True(t, errors.Is(err, ErrForbidden))
+	})
+}
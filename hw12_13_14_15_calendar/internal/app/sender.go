@@ -2,51 +2,133 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 )
 
-// Sender отвечает за чтение сообщений из очереди RabbitMQ
-// и логирование их.
+// deliveryPurgeInterval период удаления отметок о доставках старше model.DeliveryRetention.
+const deliveryPurgeInterval = time.Hour
+
+// ErrChannelUnavailable рассыльщику не передан Notifier для канала доставки.
+var ErrChannelUnavailable = errors.New("notification channel is not available")
+
+// DeliveryError ошибки доставки сообщения по отдельным каналам. Сообщение доставлено
+// по остальным каналам маршрута.
+type DeliveryError struct {
+	Errors map[model.Channel]error
+}
+
+func (e *DeliveryError) Error() string {
+	channels := make([]string, 0, len(e.Errors))
+	for channel := range e.Errors {
+		channels = append(channels, string(channel))
+	}
+	sort.Strings(channels)
+
+	parts := make([]string, len(channels))
+	for i, channel := range channels {
+		parts[i] = channel + ": " + e.Errors[model.Channel(channel)].Error()
+	}
+	return "delivery failed: " + strings.Join(parts, "; ")
+}
+
+// Unwrap возвращает ошибки всех каналов для errors.Is и errors.As.
+func (e *DeliveryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ChannelStats число сообщений, доставленных и не доставленных по каналу.
+type ChannelStats struct {
+	Delivered int64
+	Failed    int64
+}
+
+// Sender отвечает за чтение сообщений из очереди брокера и доставку их получателям
+// по каналам из настроек уведомлений.
 type Sender struct {
-	broker   broker.Broker
-	logger   *logger.Logger
-	stopChan chan struct{}
+	app       *Calendar
+	broker    broker.Broker
+	notifiers map[model.Channel]Notifier
+	logger    *logger.Logger
+	stopChan  chan struct{}
+
+	statsMu sync.Mutex
+	stats   map[model.Channel]*ChannelStats
 }
 
-func NewSender(broker broker.Broker, logger *logger.Logger) *Sender {
+// NewSender создает рассыльщик, доставляющий сообщения через notifiers по каналам.
+// Сообщения для каналов без Notifier не доставляются и учитываются как ошибки.
+func NewSender(
+	app *Calendar, broker broker.Broker, notifiers map[model.Channel]Notifier, logger *logger.Logger,
+) *Sender {
 	return &Sender{
-		broker:   broker,
-		logger:   logger,
-		stopChan: make(chan struct{}),
+		app:       app,
+		broker:    broker,
+		notifiers: notifiers,
+		logger:    logger,
+		stopChan:  make(chan struct{}),
+		stats:     make(map[model.Channel]*ChannelStats),
 	}
 }
 
-// Start запускает процесс рассыльщика, который читает сообщения из очереди RabbitMQ.
+// Start запускает процесс рассыльщика, который читает сообщения из очереди брокера
+// и доставляет их. Ошибки доставки записываются в журнал и учитываются в Stats. Без AutoAck
+// сообщение подтверждается, откладывается или отклоняется после доставки, см. settle.
 func (s *Sender) Start(ctx context.Context) error {
 	s.logger.Info("Sender started")
 
 	if s.broker == nil {
 		return fmt.Errorf("broker is not initialized")
 	}
+	if len(s.notifiers) == 0 {
+		return fmt.Errorf("no notifiers are configured")
+	}
 
-	msgs, err := s.broker.Consume(*config.Get().RabbitMQ.Consume)
+	consume := *config.Get().RabbitMQ.Consume
+	if consume.AutoAck {
+		s.logger.Warn("Messages are acknowledged on receipt, failed messages will not be retried")
+	}
+	msgs, err := s.broker.Consume(consume)
 	if err != nil {
 		return fmt.Errorf("failed to start consuming messages: %w", err)
 	}
+	defer s.logStats()
+
+	purge := time.NewTicker(deliveryPurgeInterval)
+	defer purge.Stop()
 
 	for {
 		select {
-		case msg := <-msgs:
-			s.logger.Info("Received message: %s", msg.Body)
+		case msg, ok := <-msgs:
+			if !ok {
+				return fmt.Errorf("message channel closed")
+			}
+			s.logger.Debug("Received message %s: %s", msg.Key, msg.Body)
+			err := s.Deliver(ctx, msg.Key, msg.Body)
+			if err != nil {
+				s.logger.Error("Failed to deliver message: %s", err)
+			}
+			if !consume.AutoAck {
+				s.settle(ctx, msg, err)
+			}
 
-			if !config.Get().RabbitMQ.Consume.AutoAck {
-				if err := msg.Ack(false); err != nil {
-					s.logger.Error("Failed to acknowledge message: %s", err)
-				}
+		case <-purge.C:
+			if err := s.app.PurgeDeliveries(ctx, time.Now().Add(-model.DeliveryRetention)); err != nil {
+				s.logger.Error("Failed to purge delivery records: %s", err)
 			}
 
 		case <-ctx.Done():
@@ -60,6 +142,174 @@ func (s *Sender) Start(ctx context.Context) error {
 	}
 }
 
+// Deliver доставляет сообщение из очереди уведомлений: находит получателя и отправляет сообщение
+// по каждому каналу его маршрута, см. model.NotificationSettings.Route. Если по части каналов
+// доставить не удалось, возвращает *DeliveryError с ошибками этих каналов.
+//
+// Непустой key - ключ идемпотентности сообщения, см. Relay. Каналы, по которым сообщение с тем же
+// ключом уже доставлено, пропускаются, поэтому повторная публикация и повторная попытка после
+// частичной доставки не дублируют уведомления.
+func (s *Sender) Deliver(ctx context.Context, key string, body []byte) error {
+	notice, err := model.DecodeNotice(body)
+	if err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	recipient, err := s.app.SelectRecipient(ctx, notice.RecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to find recipient %s: %w", notice.RecipientID, err)
+	}
+
+	delivered := make(map[model.Channel]bool)
+	if key != "" {
+		channels, err := s.app.DeliveredChannels(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check deliveries of message %s: %w", key, err)
+		}
+		for _, channel := range channels {
+			delivered[channel] = true
+		}
+	}
+
+	failed := make(map[model.Channel]error)
+	for _, channel := range recipient.Settings.Route(notice) {
+		if delivered[channel] {
+			s.logger.Info("Message %s was already delivered via %s, skipping", key, channel)
+			continue
+		}
+
+		err := ErrChannelUnavailable
+		if notifier, ok := s.notifiers[channel]; ok {
+			err = notifier.Notify(ctx, notice, recipient)
+		}
+		s.count(channel, err)
+
+		if err != nil {
+			failed[channel] = err
+			s.logger.Error("Failed to deliver message about event %s to %s via %s: %s",
+				notice.EventID, notice.RecipientID, channel, err)
+			continue
+		}
+		s.logger.Info("Message about event %s sent to %s via %s", notice.EventID, notice.RecipientID, channel)
+		if key != "" {
+			if err := s.app.MarkDelivered(ctx, key, channel); err != nil {
+				s.logger.Error("Failed to record delivery of message %s via %s: %s", key, channel, err)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return &DeliveryError{Errors: failed}
+	}
+	return nil
+}
+
+// settle подтверждает сообщение msg после доставки с ошибкой err. Сообщение, доставку которого
+// может исправить повторная попытка, публикуется для следующей попытки с задержкой, пока не исчерпаны
+// попытки очереди, и подтверждается, только когда брокер принял копию, иначе возвращается в очередь.
+// Остальные сообщения отклоняются и попадают в очередь недоставленных сообщений.
+func (s *Sender) settle(ctx context.Context, msg broker.Message, err error) {
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			s.logger.Error("Failed to acknowledge message: %s", err)
+		}
+		return
+	}
+
+	queue := *config.Get().RabbitMQ.Queue
+	attempt := msg.Attempt
+	if queue.DeadLetterExchange != "" && attempt < queue.MaxAttempts && retryable(err) {
+		if err := s.broker.Retry(ctx, queue, msg); err != nil {
+			s.logger.Error("Failed to schedule message retry: %s", err)
+			if err := msg.Nack(true); err != nil {
+				s.logger.Error("Failed to requeue message: %s", err)
+			}
+			return
+		}
+		s.logger.Info("Message will be retried in %s, attempt %d of %d",
+			broker.RetryDelay(queue, attempt), attempt+1, queue.MaxAttempts)
+		if err := msg.Ack(); err != nil {
+			s.logger.Error("Failed to acknowledge message: %s", err)
+		}
+		return
+	}
+
+	s.logger.Error("Message is dead-lettered after %d attempts: %s", attempt, err)
+	if err := msg.Nack(false); err != nil {
+		s.logger.Error("Failed to reject message: %s", err)
+	}
+}
+
+// retryable сообщает, может ли повторная попытка доставить сообщение после ошибки err. Некорректное
+// сообщение и отсутствующий получатель, адрес или канал повтором не исправить. Сообщение с ошибками
+// по нескольким каналам повторяется, если повтор может исправить хотя бы одну из них.
+func retryable(err error) bool {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		for _, channelErr := range deliveryErr.Errors {
+			if retryable(channelErr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr),
+		errors.Is(err, model.ErrUnknownMessage), errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, ErrNoEmail), errors.Is(err, ErrNoWebhook), errors.Is(err, ErrWebhookAddress),
+		errors.Is(err, ErrChannelUnavailable):
+		return false
+	}
+	return true
+}
+
+// count учитывает результат доставки по каналу.
+func (s *Sender) count(channel model.Channel, err error) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	stats, ok := s.stats[channel]
+	if !ok {
+		stats = &ChannelStats{}
+		s.stats[channel] = stats
+	}
+	if err != nil {
+		stats.Failed++
+	} else {
+		stats.Delivered++
+	}
+}
+
+// Stats возвращает число доставленных и недоставленных сообщений по каналам с момента запуска.
+func (s *Sender) Stats() map[model.Channel]ChannelStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	stats := make(map[model.Channel]ChannelStats, len(s.stats))
+	for channel, channelStats := range s.stats {
+		stats[channel] = *channelStats
+	}
+	return stats
+}
+
+// logStats записывает в журнал итоги доставки по каналам.
+func (s *Sender) logStats() {
+	stats := s.Stats()
+	channels := make([]string, 0, len(stats))
+	for channel := range stats {
+		channels = append(channels, string(channel))
+	}
+	sort.Strings(channels)
+	for _, channel := range channels {
+		channelStats := stats[model.Channel(channel)]
+		s.logger.Info("Channel %s: %d delivered, %d failed", channel, channelStats.Delivered, channelStats.Failed)
+	}
+}
+
 // Stop останавливает рассыльщик, посылая сигнал остановки.
 func (s *Sender) Stop() {
 	s.logger.Info("Sender stopping...")
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/mailer"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/mailer/smtptest"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderDeliver(t *testing.T) {
+	server := smtptest.NewServer()
+	defer server.Close()
+
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	smtp := mailer.NewSMTP(config.SMTPConfig{
+		Host: server.Host(), Port: server.Port(), From: "calendar@example.com", Timeout: 5 * time.Second,
+	})
+	sender := NewSender(calendar, &fakeBroker{}, map[model.Channel]Notifier{
+		model.ChannelEmail: NewEmailNotifier(smtp),
+	}, log)
+	ctx := context.Background()
+
+	alice, err := calendar.CreateUser(ctx, &model.User{FirstName: "Алиса", Email: "alice@example.com"})
+	require.NoError(t, err)
+	bob, err := calendar.CreateUser(ctx, &model.User{FirstName: "Боб"})
+	require.NoError(t, err)
+
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	encode := func(v interface{}) []byte {
+		body, err := json.Marshal(v)
+		require.NoError(t, err)
+		return body
+	}
+	lastMail := func() smtptest.Mail {
+		messages := server.Messages()
+		require.NotEmpty(t, messages)
+		require.Equal(t, []string{"alice@example.com"}, messages[len(messages)-1].To)
+		mail, err := messages[len(messages)-1].Parse()
+		require.NoError(t, err)
+		return mail
+	}
+
+	require.NoError(t, sender.Deliver(ctx, "", encode(model.Event{
+		ID: "1", Title: "Планерка", Beginning: beginning, Notification: beginning.Add(-time.Hour), UserID: alice.GetID(),
+	})))
+	mail := lastMail()
+	require.Equal(t, "Скоро начнется: Планерка", mail.Subject)
+	require.Contains(t, mail.Text, "Здравствуйте, Алиса!")
+	require.Contains(t, mail.HTML, "<b>Планерка</b>")
+
+	require.NoError(t, sender.Deliver(ctx, "", encode(model.ReminderNotice{
+		Type: model.ReminderMessage, EventID: "1", Title: "Планерка", Beginning: beginning,
+		UserID: alice.GetID(), MinutesBefore: 15,
+	})))
+	require.Contains(t, lastMail().Text, "Через 15 мин. начнется событие «Планерка».")
+
+	require.NoError(t, sender.Deliver(ctx, "", encode(model.Invitation{
+		Type: model.InvitationMessage, EventID: "1", Title: "Планерка", Beginning: beginning,
+		OrganizerID: bob.GetID(), AttendeeID: alice.GetID(),
+	})))
+	require.Equal(t, "Приглашение: Планерка", lastMail().Subject)
+	require.Len(t, server.Messages(), 3)
+
+	t.Run("undeliverable", func(t *testing.T) {
+		err := sender.Deliver(ctx, "", encode(model.Event{ID: "1", Title: "Планерка", UserID: bob.GetID()}))
+		require.True(t, errors.Is(err, ErrNoEmail))
+		err = sender.Deliver(ctx, "", encode(model.Event{ID: "1", Title: "Планерка", UserID: "unknown"}))
+		require.True(t, errors.Is(err, model.ErrUserNotFound))
+		err = sender.Deliver(ctx, "", []byte(`{"type": "digest"}`))
+		require.True(t, errors.Is(err, model.ErrUnknownMessage))
+
+		server.Fail(true)
+		defer server.Fail(false)
+		require.Error(t, sender.Deliver(ctx, "", encode(model.Event{ID: "1", Title: "Планерка", UserID: alice.GetID()})))
+		require.Len(t, server.Messages(), 3)
+	})
+}
+
+func TestSenderRoute(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []model.Notice
+		status   = http.StatusOK
+	)
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		signature := SignWebhook([]byte("secret"), r.Header.Get(WebhookTimestampHeader), body)
+		require.Equal(t, signature, r.Header.Get(WebhookSignatureHeader))
+
+		var notice model.Notice
+		require.NoError(t, json.Unmarshal(body, &notice))
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, notice)
+		w.WriteHeader(status)
+	}))
+	defer webhook.Close()
+	notices := func() []model.Notice {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]model.Notice(nil), received...)
+	}
+
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	calendar := New(memorystorage.New(), *log)
+	notifier := NewWebhookNotifier(config.WebhookConfig{
+		Secret: "secret", Timeout: 5 * time.Second, AllowedHosts: []string{"127.0.0.1"},
+	})
+	trust(notifier, webhook)
+	sender := NewSender(calendar, &fakeBroker{}, map[model.Channel]Notifier{
+		model.ChannelWebhook: notifier,
+		model.ChannelLog:     NewLogNotifier(log),
+	}, log)
+	ctx := context.Background()
+
+	alice, err := calendar.CreateUser(ctx, &model.User{FirstName: "Алиса", Email: "alice@example.com"})
+	require.NoError(t, err)
+	require.NoError(t, calendar.SetNotificationSettings(ctx, model.NotificationSettings{
+		UserID: alice.GetID(), Channels: []model.Channel{model.ChannelWebhook, model.ChannelLog}, WebhookURL: webhook.URL,
+	}))
+
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	event, err := json.Marshal(model.Event{ID: "1", Title: "Планерка", Beginning: beginning, UserID: alice.GetID()})
+	require.NoError(t, err)
+	require.NoError(t, sender.Deliver(ctx, "", event))
+	require.Equal(t, []model.Notice{{
+		RecipientID: alice.GetID(), EventID: "1", Title: "Планерка", Beginning: beginning,
+	}}, notices())
+
+	// Канал напоминания заменяет настройки пользователя.
+	reminder, err := json.Marshal(model.ReminderNotice{
+		Type: model.ReminderMessage, EventID: "1", Title: "Планерка", Beginning: beginning,
+		UserID: alice.GetID(), MinutesBefore: 15, Channel: model.ChannelLog,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sender.Deliver(ctx, "", reminder))
+	require.Len(t, notices(), 1)
+
+	// Ошибка одного канала не мешает доставке по остальным.
+	mu.Lock()
+	status = http.StatusServiceUnavailable
+	mu.Unlock()
+	err = sender.Deliver(ctx, "", event)
+	var deliveryErr *DeliveryError
+	require.True(t, errors.As(err, &deliveryErr))
+	require.Len(t, deliveryErr.Errors, 1)
+	require.True(t, errors.Is(err, ErrWebhookStatus))
+
+	// Повтор сообщения с ключом идемпотентности доставляется только по каналам, где доставка не удалась.
+	key := "notification:1"
+	require.True(t, errors.Is(sender.Deliver(ctx, key, event), ErrWebhookStatus))
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	require.NoError(t, sender.Deliver(ctx, key, event))
+	sent := len(notices())
+	require.NoError(t, sender.Deliver(ctx, key, event))
+	require.Len(t, notices(), sent)
+
+	// Пользователю без настроек сообщения доставляются по электронной почте, для которой нет Notifier.
+	require.NoError(t, calendar.DeleteNotificationSettings(ctx, alice.GetID()))
+	require.True(t, errors.Is(sender.Deliver(ctx, "", event), ErrChannelUnavailable))
+
+	require.Equal(t, map[model.Channel]ChannelStats{
+		model.ChannelWebhook: {Delivered: 2, Failed: 2},
+		model.ChannelLog:     {Delivered: 4},
+		model.ChannelEmail:   {Failed: 1},
+	}, sender.Stats())
+}
+
+// fakeAcknowledger запоминает, как было подтверждено сообщение.
+type fakeAcknowledger struct {
+	acked, rejected, requeued int
+}
+
+func (a *fakeAcknowledger) Ack() error {
+	a.acked++
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(requeue bool) error {
+	if requeue {
+		a.requeued++
+	} else {
+		a.rejected++
+	}
+	return nil
+}
+
+func TestSenderSettle(t *testing.T) {
+	require.NoError(t, config.LoadConfig("../../config/sender_config.toml"))
+	maxAttempts := config.Get().RabbitMQ.Queue.MaxAttempts
+	require.Greater(t, maxAttempts, 1)
+
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	fake := &fakeBroker{}
+	sender := NewSender(New(memorystorage.New(), *log), fake, map[model.Channel]Notifier{
+		model.ChannelLog: NewLogNotifier(log),
+	}, log)
+	settle := func(attempt int, err error) *fakeAcknowledger {
+		acknowledger := &fakeAcknowledger{}
+		sender.settle(context.Background(), broker.Message{Acknowledger: acknowledger, Attempt: attempt}, err)
+		return acknowledger
+	}
+	transient := &DeliveryError{Errors: map[model.Channel]error{
+		model.ChannelEmail:   ErrNoEmail,
+		model.ChannelWebhook: ErrWebhookStatus,
+	}}
+
+	require.Equal(t, &fakeAcknowledger{acked: 1}, settle(1, nil))
+	require.Empty(t, fake.retried)
+
+	require.Equal(t, &fakeAcknowledger{acked: 1}, settle(1, transient))
+	require.Len(t, fake.retried, 1)
+	require.Equal(t, &fakeAcknowledger{acked: 1}, settle(maxAttempts-1, errors.New("connection refused")))
+	require.Len(t, fake.retried, 2)
+
+	// Исчерпанные попытки и ошибки, которые не исправить повтором, уходят в очередь недоставленных.
+	require.Equal(t, &fakeAcknowledger{rejected: 1}, settle(maxAttempts, transient))
+	require.Equal(t, &fakeAcknowledger{rejected: 1}, settle(1, fmt.Errorf("failed: %w", ErrNoEmail)))
+	require.Equal(t, &fakeAcknowledger{rejected: 1}, settle(1, &DeliveryError{Errors: map[model.Channel]error{
+		model.ChannelEmail: ErrNoEmail,
+	}}))
+	_, err := model.DecodeNotice([]byte("{"))
+	require.Equal(t, &fakeAcknowledger{rejected: 1}, settle(1, err))
+	require.Len(t, fake.retried, 2)
+
+	// Если отложить сообщение не удалось, оно сразу возвращается в очередь.
+	fake.err = errors.New("broker is down")
+	require.Equal(t, &fakeAcknowledger{requeued: 1}, settle(1, transient))
+}
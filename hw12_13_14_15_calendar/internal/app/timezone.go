@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// Location возвращает часовой пояс, в котором вычисляются дни, недели и месяцы запроса.
+// Явно указанный timeZone важнее часового пояса пользователя из контекста,
+// без них используется UTC. Неизвестный часовой пояс возвращается как *ValidationError.
+func (calendar *Calendar) Location(ctx context.Context, timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		if principal, ok := auth.FromContext(ctx); ok && principal.UserID != "" {
+			calendar.mutex.RLock()
+			defer calendar.mutex.RUnlock()
+
+			var err error
+			if timeZone, err = calendar.userTimeZone(ctx, principal.UserID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	loc, err := model.LoadLocation(timeZone)
+	if err != nil {
+		v := &ValidationError{}
+		v.add("timeZone", "must be an IANA time zone")
+		return nil, v.err()
+	}
+	return loc, nil
+}
+
+// userTimeZone возвращает часовой пояс пользователя, пустой, если он не задан или пользователя нет.
+func (calendar *Calendar) userTimeZone(ctx context.Context, userID string) (string, error) {
+	if userID == "" {
+		return "", nil
+	}
+	user, err := calendar.selectUser(ctx, userID)
+	if errors.Is(err, model.ErrUserNotFound) {
+		return "", nil
+	}
+	return user.TimeZone, err
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarTimeZones(t *testing.T) {
+	calendar := New(memorystorage.New(), *logger.New(&config.LoggerConfig{Level: "error"}))
+	internal := context.Background()
+
+	user, err := calendar.CreateUser(internal, &model.User{FirstName: "Кэндзи", TimeZone: "Asia/Tokyo"})
+	require.NoError(t, err)
+	kenji := auth.WithPrincipal(internal, auth.Principal{UserID: user.GetID()})
+
+	_, err = calendar.CreateUser(internal, &model.User{FirstName: "Марс", TimeZone: "Mars/Olympus"})
+	require.Equal(t, []string{"timeZone"}, fieldNames(t, err))
+
+	t.Run("location", func(t *testing.T) {
+		loc, err := calendar.Location(internal, "")
+		require.NoError(t, err)
+		require.Equal(t, time.UTC, loc)
+
+		loc, err = calendar.Location(kenji, "")
+		require.NoError(t, err)
+		require.Equal(t, "Asia/Tokyo", loc.String())
+
+		loc, err = calendar.Location(kenji, "Europe/Berlin")
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Berlin", loc.String())
+
+		_, err = calendar.Location(kenji, "Local")
+		require.Equal(t, []string{"timeZone"}, fieldNames(t, err))
+	})
+
+	t.Run("event inherits time zone", func(t *testing.T) {
+		beginning := time.Date(2024, time.June, 3, 1, 0, 0, 0, time.UTC)
+		event, err := calendar.CreateEvent(kenji, &model.Event{
+			Title: "Завтрак", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Asia/Tokyo", event.GetTimeZone())
+
+		shared, err := calendar.CreateCalendar(kenji, model.Calendar{Name: "Работа", TimeZone: "Europe/Berlin"})
+		require.NoError(t, err)
+		event, err = calendar.CreateEvent(kenji, &model.Event{
+			Title: "Ретро", Beginning: beginning.AddDate(0, 0, 1), Finish: beginning.AddDate(0, 0, 1).Add(time.Hour),
+			CalendarID: shared.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Berlin", event.GetTimeZone())
+
+		_, err = calendar.CreateEvent(kenji, &model.Event{
+			Title: "Ошибка", Beginning: beginning, Finish: beginning, TimeZone: "Mars/Olympus",
+		})
+		require.Equal(t, []string{"timeZone"}, fieldNames(t, err))
+	})
+
+	t.Run("day in user time zone", func(t *testing.T) {
+		// Созвон в 20:00 UTC 2 июня приходится на утро 3 июня по Токио.
+		call := time.Date(2024, time.June, 2, 20, 0, 0, 0, time.UTC)
+		_, err := calendar.CreateEvent(kenji, &model.Event{Title: "Созвон", Beginning: call, Finish: call.Add(time.Hour)})
+		require.NoError(t, err)
+
+		loc, err := calendar.Location(kenji, "")
+		require.NoError(t, err)
+		events, err := calendar.SelectEventsForDay(kenji, time.Date(2024, time.June, 3, 0, 0, 0, 0, loc))
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		events, err = calendar.SelectEventsForDay(kenji, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, "Завтрак", events[0].GetTitle())
+	})
+}
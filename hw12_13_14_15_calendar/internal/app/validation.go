@@ -0,0 +1,225 @@
+package app
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// colorPattern формат цвета календаря.
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// FieldError ошибка валидации отдельного поля.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError ошибка валидации сущности со списком всех некорректных полей.
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// add добавляет ошибку поля.
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// err возвращает nil, если ошибок нет, иначе саму ошибку валидации.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateUser проверяет поля пользователя.
+func ValidateUser(user model.User) error {
+	v := &ValidationError{}
+	validateUser(v, "", user)
+	return v.err()
+}
+
+// ValidateEvent проверяет поля события. Для обновления дополнительно требуется ID.
+func ValidateEvent(event model.Event, update bool) error {
+	v := &ValidationError{}
+	if update && strings.TrimSpace(event.ID) == "" {
+		v.add("id", "must not be blank")
+	}
+	validateEvent(v, "", event)
+	return v.err()
+}
+
+// ValidateEvents проверяет список событий, поля получают префикс с индексом события.
+func ValidateEvents(events []model.Event) error {
+	v := &ValidationError{}
+	for i, event := range events {
+		validateEvent(v, fmt.Sprintf("events[%d].", i), event)
+	}
+	return v.err()
+}
+
+// ValidateCalendar проверяет поля календаря. Для обновления дополнительно требуется ID.
+func ValidateCalendar(calendar model.Calendar, update bool) error {
+	v := &ValidationError{}
+	if update && strings.TrimSpace(calendar.ID) == "" {
+		v.add("id", "must not be blank")
+	}
+	if strings.TrimSpace(calendar.Name) == "" {
+		v.add("name", "must not be blank")
+	}
+	if strings.TrimSpace(calendar.OwnerID) == "" {
+		v.add("ownerId", "must not be blank")
+	}
+	if !validTimeZone(calendar.TimeZone) {
+		v.add("timeZone", "must be an IANA time zone")
+	}
+	if calendar.Color != "" && !colorPattern.MatchString(calendar.Color) {
+		v.add("color", "must be in #rrggbb format")
+	}
+	return v.err()
+}
+
+// ValidateWorkingHours проверяет рабочее время пользователя.
+func ValidateWorkingHours(hours model.WorkingHours) error {
+	v := &ValidationError{}
+	if strings.TrimSpace(hours.UserID) == "" {
+		v.add("userId", "must not be blank")
+	}
+	if !validTimeZone(hours.TimeZone) {
+		v.add("timeZone", "must be an IANA time zone")
+	}
+	start, err := model.ParseClock(hours.Start)
+	if err != nil || start == 24*time.Hour {
+		v.add("start", "must be in HH:MM format")
+	}
+	if end, err := model.ParseClock(hours.End); err != nil {
+		v.add("end", "must be in HH:MM format")
+	} else if end <= start {
+		v.add("end", "must be after start")
+	}
+	seen := make(map[int]bool, len(hours.Days))
+	for _, day := range hours.Days {
+		if day < 1 || day > 7 || seen[day] {
+			v.add("days", "must be distinct weekdays from 1 (Monday) to 7 (Sunday)")
+			break
+		}
+		seen[day] = true
+	}
+	if len(hours.Days) == 0 {
+		v.add("days", "must not be empty")
+	}
+	return v.err()
+}
+
+// ValidateNotificationSettings проверяет настройки уведомлений пользователя.
+func ValidateNotificationSettings(settings model.NotificationSettings) error {
+	v := &ValidationError{}
+	if strings.TrimSpace(settings.UserID) == "" {
+		v.add("userId", "must not be blank")
+	}
+	if len(settings.Channels) == 0 {
+		v.add("channels", "must not be empty")
+	}
+	seen := make(map[model.Channel]bool, len(settings.Channels))
+	for _, channel := range settings.Channels {
+		if channel == model.ChannelDefault || !channel.Valid() || seen[channel] {
+			v.add("channels", "must be distinct channels: email, webhook or log")
+			break
+		}
+		seen[channel] = true
+	}
+	if settings.WebhookURL != "" {
+		u, err := url.Parse(settings.WebhookURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			v.add("webhookUrl", "must be an absolute https URL")
+		}
+	} else if seen[model.ChannelWebhook] {
+		v.add("webhookUrl", "is required for the webhook channel")
+	}
+	return v.err()
+}
+
+// validTimeZone проверяет, что name - часовой пояс IANA.
+// Пустая строка допустима только там, где она означает UTC, и проверяется вызывающим.
+func validTimeZone(name string) bool {
+	_, err := model.LoadLocation(name)
+	return err == nil && name != ""
+}
+
+func validateUser(v *ValidationError, prefix string, user model.User) {
+	if user.Email != "" {
+		address, err := mail.ParseAddress(user.Email)
+		if err != nil || address.Address != user.Email {
+			v.add(prefix+"email", "must be a valid email address")
+		}
+	}
+	if user.Age < 0 {
+		v.add(prefix+"age", "must not be negative")
+	}
+	if user.TimeZone != "" && !validTimeZone(user.TimeZone) {
+		v.add(prefix+"timeZone", "must be an IANA time zone")
+	}
+}
+
+func validateEvent(v *ValidationError, prefix string, event model.Event) {
+	if strings.TrimSpace(event.Title) == "" {
+		v.add(prefix+"title", "must not be blank")
+	}
+	if event.Beginning.IsZero() {
+		v.add(prefix+"beginning", "is required")
+	}
+	if event.Finish.IsZero() {
+		v.add(prefix+"finish", "is required")
+	} else if event.Finish.Before(event.Beginning) {
+		v.add(prefix+"finish", "must not be before beginning")
+	}
+	if !event.Notification.IsZero() && event.Notification.After(event.Beginning) {
+		v.add(prefix+"notification", "must not be after beginning")
+	}
+	if _, err := model.ParseRecurrence(event.Recurrence); err != nil {
+		v.add(prefix+"recurrence", err.Error())
+	}
+	if event.TimeZone != "" && !validTimeZone(event.TimeZone) {
+		v.add(prefix+"timeZone", "must be an IANA time zone")
+	}
+	validateReminders(v, prefix, event.Reminders)
+}
+
+func validateReminders(v *ValidationError, prefix string, reminders []model.Reminder) {
+	if len(reminders) > model.MaxReminders {
+		v.add(prefix+"reminders", fmt.Sprintf("must not contain more than %d reminders", model.MaxReminders))
+	}
+	type key struct {
+		minutes int
+		channel model.Channel
+	}
+	seen := make(map[key]struct{}, len(reminders))
+	for i, reminder := range reminders {
+		field := fmt.Sprintf("%sreminders[%d].", prefix, i)
+		if reminder.MinutesBefore < 0 || reminder.Offset() > model.MaxReminderOffset {
+			v.add(field+"minutesBefore", fmt.Sprintf("must be between 0 and %d", int(model.MaxReminderOffset/time.Minute)))
+		}
+		if !reminder.Channel.Valid() {
+			v.add(field+"channel", "must be one of email, webhook, log or empty")
+		}
+		k := key{reminder.MinutesBefore, reminder.Channel}
+		if _, ok := seen[k]; ok {
+			v.add(field+"minutesBefore", "duplicates another reminder")
+		}
+		seen[k] = struct{}{}
+	}
+}
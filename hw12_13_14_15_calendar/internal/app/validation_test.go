@@ -0,0 +1,100 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEvent(t *testing.T) {
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	valid := model.Event{
+		Title:        "Планерка",
+		Beginning:    beginning,
+		Finish:       beginning.Add(time.Hour),
+		Notification: beginning.Add(-15 * time.Minute),
+		Recurrence:   "FREQ=WEEKLY",
+	}
+
+	t.Run("valid event", func(t *testing.T) {
+		require.NoError(t, ValidateEvent(valid, false))
+	})
+
+	t.Run("all invalid fields are reported", func(t *testing.T) {
+		event := model.Event{
+			Title:        "  ",
+			Beginning:    beginning,
+			Finish:       beginning.Add(-time.Hour),
+			Notification: beginning.Add(time.Minute),
+			Recurrence:   "FREQ=SECONDLY",
+		}
+
+		err := ValidateEvent(event, true)
+		require.Equal(t, []string{"id", "title", "finish", "notification", "recurrence"}, fieldNames(t, err))
+	})
+
+	t.Run("missing dates", func(t *testing.T) {
+		err := ValidateEvent(model.Event{Title: "Планерка"}, false)
+		require.Equal(t, []string{"beginning", "finish"}, fieldNames(t, err))
+	})
+
+	t.Run("events are prefixed with index", func(t *testing.T) {
+		invalid := valid
+		invalid.Title = ""
+
+		err := ValidateEvents([]model.Event{valid, invalid})
+		require.Equal(t, []string{"events[1].title"}, fieldNames(t, err))
+	})
+}
+
+func TestValidateUser(t *testing.T) {
+	require.NoError(t, ValidateUser(model.User{FirstName: "Иван"}))
+	require.NoError(t, ValidateUser(model.User{Email: "ivan@example.com", Age: 30}))
+
+	err := ValidateUser(model.User{Email: "Иван <ivan@example.com>", Age: -1})
+	require.Equal(t, []string{"email", "age"}, fieldNames(t, err))
+
+	err = ValidateUser(model.User{Email: "not an email"})
+	require.Equal(t, []string{"email"}, fieldNames(t, err))
+}
+
+func TestValidateNotificationSettings(t *testing.T) {
+	require.NoError(t, ValidateNotificationSettings(model.NotificationSettings{
+		UserID: "1", Channels: []model.Channel{model.ChannelEmail, model.ChannelWebhook},
+		WebhookURL: "https://example.com/hook",
+	}))
+
+	err := ValidateNotificationSettings(model.NotificationSettings{
+		Channels: []model.Channel{model.ChannelEmail, model.ChannelEmail}, WebhookURL: "ftp://example.com",
+	})
+	require.Equal(t, []string{"userId", "channels", "webhookUrl"}, fieldNames(t, err))
+
+	err = ValidateNotificationSettings(model.NotificationSettings{
+		UserID: "1", Channels: []model.Channel{model.ChannelWebhook}, WebhookURL: "http://example.com/hook",
+	})
+	require.Equal(t, []string{"webhookUrl"}, fieldNames(t, err))
+
+	err = ValidateNotificationSettings(model.NotificationSettings{UserID: "1"})
+	require.Equal(t, []string{"channels"}, fieldNames(t, err))
+
+	err = ValidateNotificationSettings(model.NotificationSettings{
+		UserID: "1", Channels: []model.Channel{model.ChannelWebhook, "sms"},
+	})
+	require.Equal(t, []string{"channels", "webhookUrl"}, fieldNames(t, err))
+}
+
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	names := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		names = append(names, field.Field)
+	}
+	return names
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Области доступа ключей API.
+const (
+	ScopeEventsRead  = "events:read"
+	ScopeEventsWrite = "events:write"
+	ScopeUsersAdmin  = "users:admin"
+)
+
+// Scopes все поддерживаемые области доступа.
+var Scopes = []string{ScopeEventsRead, ScopeEventsWrite, ScopeUsersAdmin}
+
+// APIKeyPrefix отличает ключи API от JWT в заголовке Authorization.
+const APIKeyPrefix = "cal_"
+
+// apiKeySize число случайных байт ключа API.
+const apiKeySize = 32
+
+// GenerateAPIKey создает новый случайный ключ API.
+func GenerateAPIKey() (string, error) {
+	secret := make([]byte, apiKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashAPIKey возвращает хеш ключа API для хранения и поиска.
+// Ключ содержит достаточно случайных байт, поэтому соль и медленный хеш не нужны.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyAuthenticator проверяет ключи API сервисных клиентов.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (Principal, error)
+}
+
+// Authenticator принимает JWT пользователей и ключи API сервисных клиентов.
+type Authenticator struct {
+	verifier *Verifier
+	keys     APIKeyAuthenticator
+}
+
+// NewAuthenticator создает Authenticator, проверяющий JWT через verifier и ключи API через keys.
+func NewAuthenticator(verifier *Verifier, keys APIKeyAuthenticator) *Authenticator {
+	return &Authenticator{verifier: verifier, keys: keys}
+}
+
+// Authenticate проверяет токен: ключи API распознаются по префиксу APIKeyPrefix, остальное - JWT.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if strings.HasPrefix(token, APIKeyPrefix) {
+		return a.keys.AuthenticateAPIKey(ctx, token)
+	}
+	return a.verifier.Authenticate(token)
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+)
+
+var (
+	// ErrUnauthenticated токен доступа отсутствует или недействителен.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrUnsupportedAlgorithm алгоритм подписи не поддерживается.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
+// Principal аутентифицированный пользователь или сервисный клиент с ключом API.
+type Principal struct {
+	// UserID идентификатор пользователя календаря из claim sub, пустой для ключа API.
+	UserID string
+	// Admin разрешает доступ к данным всех пользователей.
+	Admin bool
+	// KeyID идентификатор ключа API сервисного клиента.
+	KeyID string
+	// Scopes области доступа ключа API. Nil для пользователей с JWT:
+	// их доступ ограничен собственными данными, а не областями.
+	Scopes []string
+}
+
+// HasScope проверяет, разрешена ли область доступа scope.
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal возвращает контекст с аутентифицированным пользователем.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext возвращает аутентифицированного пользователя из контекста.
+// Отсутствие пользователя означает внутренний вызов, например из планировщика.
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// claims набор claims токена доступа.
+type claims struct {
+	jwt.RegisteredClaims
+	Admin bool `json:"admin,omitempty"`
+}
+
+// Verifier проверяет подпись и срок действия JWT.
+type Verifier struct {
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+// NewVerifier создает Verifier по конфигурации. Для HS256 файл ключа содержит общий секрет,
+// для RS256 - открытый ключ RSA в формате PEM.
+func NewVerifier(conf *config.AuthConfig) (*Verifier, error) {
+	data, err := os.ReadFile(conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	switch conf.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		secret := []byte(strings.TrimSpace(string(data)))
+		if len(secret) == 0 {
+			return nil, errors.New("empty HS256 secret")
+		}
+		return &Verifier{method: jwt.SigningMethodHS256, key: secret}, nil
+	case jwt.SigningMethodRS256.Alg():
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		return &Verifier{method: jwt.SigningMethodRS256, key: key}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, conf.Algorithm)
+	}
+}
+
+// Authenticate проверяет токен и возвращает пользователя из claim sub.
+// Токен должен быть подписан настроенным алгоритмом и содержать срок действия exp.
+func (v *Verifier) Authenticate(token string) (Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	}, jwt.WithValidMethods([]string{v.method.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if c.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: missing sub claim", ErrUnauthenticated)
+	}
+
+	return Principal{UserID: c.Subject, Admin: c.Admin}, nil
+}
+
+// BearerToken извлекает токен из значения заголовка Authorization вида "Bearer <token>".
+func BearerToken(header string) (string, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", fmt.Errorf("%w: missing bearer token", ErrUnauthenticated)
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, c jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, c).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifier(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	hs256, err := NewVerifier(&config.AuthConfig{Algorithm: "HS256", KeyFile: writeKey(t, append(secret, '\n'))})
+	require.NoError(t, err)
+	rs256, err := NewVerifier(&config.AuthConfig{
+		Algorithm: "RS256",
+		KeyFile:   writeKey(t, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+	})
+	require.NoError(t, err)
+
+	valid := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "alice",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Admin: true,
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+	noSubject := valid
+	noSubject.Subject = ""
+
+	t.Run("valid tokens", func(t *testing.T) {
+		principal, err := hs256.Authenticate(sign(t, jwt.SigningMethodHS256, secret, valid))
+		require.NoError(t, err)
+		require.Equal(t, Principal{UserID: "alice", Admin: true}, principal)
+
+		principal, err = rs256.Authenticate(sign(t, jwt.SigningMethodRS256, rsaKey, valid))
+		require.NoError(t, err)
+		require.Equal(t, Principal{UserID: "alice", Admin: true}, principal)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			verifier *Verifier
+			token    string
+		}{
+			"expired":         {hs256, sign(t, jwt.SigningMethodHS256, secret, expired)},
+			"without exp":     {hs256, sign(t, jwt.SigningMethodHS256, secret, noExpiry)},
+			"without sub":     {hs256, sign(t, jwt.SigningMethodHS256, secret, noSubject)},
+			"wrong secret":    {hs256, sign(t, jwt.SigningMethodHS256, []byte("other"), valid)},
+			"wrong algorithm": {rs256, sign(t, jwt.SigningMethodHS256, publicKey, valid)},
+			"none algorithm":  {hs256, sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid)},
+			"malformed":       {rs256, "not.a.token"},
+		} {
+			_, err := tc.verifier.Authenticate(tc.token)
+			require.ErrorIs(t, err, ErrUnauthenticated, name)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewVerifier(&config.AuthConfig{Algorithm: "ES256", KeyFile: writeKey(t, secret)})
+		require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+		_, err = NewVerifier(&config.AuthConfig{Algorithm: "HS256", KeyFile: writeKey(t, []byte("\n"))})
+		require.Error(t, err)
+		_, err = NewVerifier(&config.AuthConfig{Algorithm: "RS256", KeyFile: writeKey(t, secret)})
+		require.Error(t, err)
+		_, err = NewVerifier(&config.AuthConfig{Algorithm: "HS256", KeyFile: filepath.Join(t.TempDir(), "missing")})
+		require.Error(t, err)
+	})
+}
+
+func TestBearerToken(t *testing.T) {
+	token, err := BearerToken("Bearer abc.def.ghi")
+	require.NoError(t, err)
+	require.Equal(t, "abc.def.ghi", token)
+
+	token, err = BearerToken("bearer  abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", token)
+
+	for _, header := range []string{"", "Bearer", "Bearer ", "Basic abc", "abc"} {
+		_, err := BearerToken(header)
+		require.ErrorIs(t, err, ErrUnauthenticated, header)
+	}
+}
+
+func TestPrincipalHasScope(t *testing.T) {
+	user := Principal{UserID: "alice"}
+	require.True(t, user.HasScope(ScopeUsersAdmin))
+
+	reader := Principal{KeyID: "1", Scopes: []string{ScopeEventsRead}}
+	require.True(t, reader.HasScope(ScopeEventsRead))
+	require.False(t, reader.HasScope(ScopeEventsWrite))
+
+	require.False(t, Principal{KeyID: "2", Scopes: []string{}}.HasScope(ScopeEventsRead))
+}
+
+// keyFunc проверяет ключи API функцией.
+type keyFunc func(key string) (Principal, error)
+
+func (f keyFunc) AuthenticateAPIKey(_ context.Context, key string) (Principal, error) {
+	return f(key)
+}
+
+func TestAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	verifier, err := NewVerifier(&config.AuthConfig{Algorithm: "HS256", KeyFile: writeKey(t, secret)})
+	require.NoError(t, err)
+
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, APIKeyPrefix))
+	other, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+	require.Len(t, HashAPIKey(key), 64)
+	require.Equal(t, HashAPIKey(key), HashAPIKey(key))
+	require.NotEqual(t, HashAPIKey(key), HashAPIKey(other))
+
+	authenticator := NewAuthenticator(verifier, keyFunc(func(token string) (Principal, error) {
+		if HashAPIKey(token) != HashAPIKey(key) {
+			return Principal{}, ErrUnauthenticated
+		}
+		return Principal{KeyID: "1", Admin: true, Scopes: []string{ScopeEventsRead}}, nil
+	}))
+	ctx := context.Background()
+
+	principal, err := authenticator.Authenticate(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "1", principal.KeyID)
+
+	_, err = authenticator.Authenticate(ctx, other)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+
+	token := sign(t, jwt.SigningMethodHS256, secret, jwt.RegisteredClaims{
+		Subject:   "alice",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	principal, err = authenticator.Authenticate(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, Principal{UserID: "alice"}, principal)
+}
@@ -2,15 +2,79 @@ package broker
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
-	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// ErrAutoAck сообщение получено с автоматическим подтверждением, его нельзя подтвердить или отклонить.
+var ErrAutoAck = errors.New("message is acknowledged automatically")
+
 type Broker interface {
 	Start() error
 	Stop() error
 	QueueDeclare(config config.QueueConfig) error
-	Consume(config config.ConsumeConfig) (<-chan amqp.Delivery, error)
+	Consume(config config.ConsumeConfig) (<-chan Message, error)
 	PublishWithContext(ctx context.Context, config config.PublishConfig, body []byte) error
+	// PublishWithKey публикует сообщение с ключом идемпотентности key, по которому получатель
+	// отбрасывает повторные публикации того же сообщения. Возвращает nil, только когда брокер
+	// принял сообщение и не потеряет его при сбое, поэтому после этого его можно удалить из outbox.
+	PublishWithKey(ctx context.Context, config config.PublishConfig, key string, body []byte) error
+	// Retry публикует копию сообщения msg очереди config для следующей попытки обработки
+	// через RetryDelay. Возвращает nil, только когда брокер принял копию: лишь после этого
+	// вызывающий может подтвердить исходное сообщение, иначе он возвращает его в очередь.
+	Retry(ctx context.Context, config config.QueueConfig, msg Message) error
+}
+
+// Acknowledger подтверждает или отклоняет сообщение в брокере, из которого оно получено.
+type Acknowledger interface {
+	Ack() error
+	// Nack отклоняет сообщение: с requeue оно возвращается в очередь, без него попадает
+	// в очередь недоставленных сообщений, если она настроена, иначе удаляется.
+	Nack(requeue bool) error
+}
+
+// Message сообщение, полученное из очереди брокера.
+type Message struct {
+	// Key ключ идемпотентности, с которым сообщение опубликовано, см. Broker.PublishWithKey.
+	Key         string
+	ContentType string
+	Body        []byte
+	// Attempt номер попытки обработки сообщения, начиная с 1.
+	Attempt int
+	// Acknowledger не задан у сообщений, полученных с автоматическим подтверждением.
+	Acknowledger Acknowledger
+}
+
+// Ack подтверждает обработку сообщения.
+func (m Message) Ack() error {
+	if m.Acknowledger == nil {
+		return ErrAutoAck
+	}
+	return m.Acknowledger.Ack()
+}
+
+// Nack отклоняет сообщение, см. Acknowledger.
+func (m Message) Nack(requeue bool) error {
+	if m.Acknowledger == nil {
+		return ErrAutoAck
+	}
+	return m.Acknowledger.Nack(requeue)
+}
+
+// RetryDelay возвращает задержку перед попыткой attempt+1 после неудачной попытки attempt:
+// config.RetryDelay, удваиваемую с каждой попыткой, но не больше config.MaxRetryDelay.
+func RetryDelay(config config.QueueConfig, attempt int) time.Duration {
+	delay := config.RetryDelay
+	for i := 1; i < attempt; i++ {
+		if config.MaxRetryDelay > 0 && delay >= config.MaxRetryDelay {
+			break
+		}
+		delay *= 2
+	}
+	if config.MaxRetryDelay > 0 && delay > config.MaxRetryDelay {
+		delay = config.MaxRetryDelay
+	}
+	return delay
 }
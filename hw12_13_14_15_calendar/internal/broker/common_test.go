@@ -0,0 +1,23 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDelay(t *testing.T) {
+	queue := config.QueueConfig{RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute}
+	delays := make([]time.Duration, 0, 5)
+	for attempt := 1; attempt <= 5; attempt++ {
+		delays = append(delays, RetryDelay(queue, attempt))
+	}
+	require.Equal(t, []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute,
+	}, delays)
+
+	queue.MaxRetryDelay = 0
+	require.Equal(t, 80*time.Second, RetryDelay(queue, 4))
+}
@@ -0,0 +1,221 @@
+package memorybroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+)
+
+// queueSize емкость очереди. Публикация в заполненную очередь ждет, пока ее не разберут потребители.
+const queueSize = 1024
+
+// ErrStopped брокер остановлен.
+var ErrStopped = errors.New("broker is stopped")
+
+// BrokerMemory брокер в памяти процесса, очереди которого - буферизованные каналы. Обменники
+// и ключи маршрутизации не моделируются: опубликованное сообщение попадает во все объявленные очереди.
+// Сообщения не переживают перезапуск процесса, поэтому брокер предназначен для тестов и запуска
+// календаря, планировщика и рассыльщика в одном процессе.
+type BrokerMemory struct {
+	mu     sync.RWMutex
+	queues map[string]*queue
+	done   chan struct{}
+}
+
+// queue очередь брокера и отклоненные из нее сообщения.
+type queue struct {
+	config   config.QueueConfig
+	messages chan broker.Message
+
+	deadMu sync.Mutex
+	dead   []broker.Message
+}
+
+func New() *BrokerMemory {
+	return &BrokerMemory{
+		queues: make(map[string]*queue),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start ничего не делает: брокеру в памяти не к чему подключаться.
+func (b *BrokerMemory) Start() error {
+	return nil
+}
+
+// Stop останавливает брокер: публикация возвращает ErrStopped, отложенные повторы отменяются.
+// Каналы потребителей не закрываются.
+func (b *BrokerMemory) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	select {
+	case <-b.done:
+	default:
+		close(b.done)
+	}
+	return nil
+}
+
+// QueueDeclare создает очередь, если ее еще нет. Очередь с DeadLetterExchange сохраняет
+// отклоненные сообщения, см. DeadLetters.
+func (b *BrokerMemory) QueueDeclare(config config.QueueConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.queues[config.Name]; !ok {
+		b.queues[config.Name] = &queue{config: config, messages: make(chan broker.Message, queueSize)}
+	}
+	return nil
+}
+
+// Consume возвращает канал сообщений очереди. Потребители одной очереди получают сообщения по очереди.
+func (b *BrokerMemory) Consume(config config.ConsumeConfig) (<-chan broker.Message, error) {
+	q, err := b.queue(config.Queue)
+	if err != nil {
+		return nil, err
+	}
+	if !config.AutoAck {
+		return q.messages, nil
+	}
+
+	messages := make(chan broker.Message)
+	go func() {
+		for {
+			select {
+			case msg := <-q.messages:
+				msg.Acknowledger = nil
+				select {
+				case messages <- msg:
+				case <-b.done:
+					return
+				}
+			case <-b.done:
+				return
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// PublishWithContext публикует сообщение во все объявленные очереди.
+func (b *BrokerMemory) PublishWithContext(ctx context.Context, config config.PublishConfig, body []byte) error {
+	return b.PublishWithKey(ctx, config, "", body)
+}
+
+// PublishWithKey публикует сообщение с ключом идемпотентности key во все объявленные очереди.
+func (b *BrokerMemory) PublishWithKey(ctx context.Context, config config.PublishConfig, key string, body []byte) error {
+	b.mu.RLock()
+	queues := make([]*queue, 0, len(b.queues))
+	for _, q := range b.queues {
+		queues = append(queues, q)
+	}
+	b.mu.RUnlock()
+
+	for _, q := range queues {
+		msg := broker.Message{
+			Key: key, ContentType: config.ContentType, Body: append([]byte(nil), body...), Attempt: 1,
+		}
+		if err := b.enqueue(ctx, q, msg); err != nil {
+			return fmt.Errorf("failed to publish a message: %w", err)
+		}
+	}
+	return nil
+}
+
+// Retry возвращает копию сообщения msg в очередь config через broker.RetryDelay
+// с увеличенным номером попытки.
+func (b *BrokerMemory) Retry(_ context.Context, config config.QueueConfig, msg broker.Message) error {
+	if msg.Attempt >= config.MaxAttempts {
+		return fmt.Errorf("message has used all %d attempts", config.MaxAttempts)
+	}
+	q, err := b.queue(config.Name)
+	if err != nil {
+		return err
+	}
+
+	msg.Attempt++
+	time.AfterFunc(broker.RetryDelay(config, msg.Attempt-1), func() {
+		b.enqueue(context.Background(), q, msg)
+	})
+	return nil
+}
+
+// DeadLetters возвращает сообщения, отклоненные из очереди name без возврата в очередь.
+func (b *BrokerMemory) DeadLetters(name string) ([]broker.Message, error) {
+	q, err := b.queue(name)
+	if err != nil {
+		return nil, err
+	}
+
+	q.deadMu.Lock()
+	defer q.deadMu.Unlock()
+	return append([]broker.Message(nil), q.dead...), nil
+}
+
+// queue возвращает объявленную очередь name.
+func (b *BrokerMemory) queue(name string) (*queue, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	q, ok := b.queues[name]
+	if !ok {
+		return nil, fmt.Errorf("queue %s is not declared", name)
+	}
+	return q, nil
+}
+
+// enqueue кладет сообщение в очередь q, ожидая свободного места.
+func (b *BrokerMemory) enqueue(ctx context.Context, q *queue, msg broker.Message) error {
+	select {
+	case <-b.done:
+		return ErrStopped
+	default:
+	}
+
+	msg.Acknowledger = acknowledger{broker: b, queue: q, msg: msg}
+	select {
+	case q.messages <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.done:
+		return ErrStopped
+	}
+}
+
+// acknowledger подтверждает сообщение очереди брокера в памяти.
+type acknowledger struct {
+	broker *BrokerMemory
+	queue  *queue
+	msg    broker.Message
+}
+
+// Ack ничего не делает: сообщение уже удалено из очереди.
+func (a acknowledger) Ack() error {
+	return nil
+}
+
+// Nack возвращает сообщение в очередь или, если очередь сохраняет отклоненные сообщения,
+// перекладывает его к ним.
+func (a acknowledger) Nack(requeue bool) error {
+	msg := a.msg
+	msg.Acknowledger = nil
+	if requeue {
+		// Потребитель, отклонивший сообщение, может быть единственным читателем заполненной очереди.
+		go a.broker.enqueue(context.Background(), a.queue, msg)
+		return nil
+	}
+
+	if a.queue.config.DeadLetterExchange != "" {
+		a.queue.deadMu.Lock()
+		defer a.queue.deadMu.Unlock()
+		a.queue.dead = append(a.queue.dead, msg)
+	}
+	return nil
+}
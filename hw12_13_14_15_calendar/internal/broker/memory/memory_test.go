@@ -0,0 +1,92 @@
+package memorybroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerMemory(t *testing.T) {
+	ctx := context.Background()
+	queue := config.QueueConfig{
+		Name: "notifications", DeadLetterExchange: "notifications.dlx", MaxAttempts: 2, RetryDelay: time.Millisecond,
+	}
+	publish := config.PublishConfig{ContentType: "application/json"}
+
+	b := New()
+	require.NoError(t, b.Start())
+	_, err := b.Consume(config.ConsumeConfig{Queue: queue.Name})
+	require.Error(t, err)
+	require.NoError(t, b.QueueDeclare(queue))
+	require.NoError(t, b.QueueDeclare(queue))
+	messages, err := b.Consume(config.ConsumeConfig{Queue: queue.Name})
+	require.NoError(t, err)
+
+	receive := func() broker.Message {
+		select {
+		case msg := <-messages:
+			return msg
+		case <-time.After(time.Second):
+			require.FailNow(t, "message is not received")
+			return broker.Message{}
+		}
+	}
+
+	require.NoError(t, b.PublishWithKey(ctx, publish, "notification:1", []byte("{}")))
+	msg := receive()
+	require.Equal(t, "notification:1", msg.Key)
+	require.Equal(t, "application/json", msg.ContentType)
+	require.Equal(t, []byte("{}"), msg.Body)
+	require.Equal(t, 1, msg.Attempt)
+	require.NoError(t, msg.Ack())
+
+	t.Run("requeue", func(t *testing.T) {
+		require.NoError(t, b.PublishWithContext(ctx, publish, []byte("requeued")))
+		require.NoError(t, receive().Nack(true))
+		msg := receive()
+		require.Equal(t, []byte("requeued"), msg.Body)
+		require.Equal(t, 1, msg.Attempt)
+		require.NoError(t, msg.Ack())
+	})
+
+	t.Run("retry and dead letter", func(t *testing.T) {
+		require.NoError(t, b.PublishWithKey(ctx, publish, "reminder:1", []byte("retried")))
+		msg := receive()
+		require.NoError(t, b.Retry(ctx, queue, msg))
+		require.NoError(t, msg.Ack())
+
+		msg = receive()
+		require.Equal(t, "reminder:1", msg.Key)
+		require.Equal(t, 2, msg.Attempt)
+		require.Error(t, b.Retry(ctx, queue, msg))
+		require.NoError(t, msg.Nack(false))
+
+		dead, err := b.DeadLetters(queue.Name)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		require.Equal(t, []byte("retried"), dead[0].Body)
+	})
+
+	t.Run("auto ack", func(t *testing.T) {
+		auto, err := b.Consume(config.ConsumeConfig{Queue: queue.Name, AutoAck: true})
+		require.NoError(t, err)
+		require.NoError(t, b.PublishWithContext(ctx, publish, []byte("auto")))
+		// Сообщение может достаться любому из потребителей очереди.
+		select {
+		case msg := <-auto:
+			require.ErrorIs(t, msg.Ack(), broker.ErrAutoAck)
+		case msg := <-messages:
+			require.NoError(t, msg.Ack())
+		case <-time.After(time.Second):
+			require.FailNow(t, "message is not received")
+		}
+	})
+
+	require.NoError(t, b.Stop())
+	require.NoError(t, b.Stop())
+	require.ErrorIs(t, b.PublishWithContext(ctx, publish, []byte("late")), ErrStopped)
+}
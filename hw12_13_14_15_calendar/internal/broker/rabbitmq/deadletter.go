@@ -0,0 +1,103 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetter сообщение из очереди недоставленных сообщений.
+type DeadLetter struct {
+	Body []byte
+	// Attempts число сделанных попыток обработки.
+	Attempts int
+	// Reason причина, по которой RabbitMQ переложил сообщение: rejected, expired или maxlen.
+	Reason string
+	// Time время, когда сообщение попало в очередь недоставленных сообщений.
+	Time time.Time
+}
+
+// InspectDeadLetters возвращает до limit первых сообщений очереди недоставленных сообщений
+// очереди config, оставляя их в очереди.
+func (b *BrokerRabbit) InspectDeadLetters(config config.QueueConfig, limit int) ([]DeadLetter, error) {
+	letters := make([]DeadLetter, 0, limit)
+	var last uint64
+	for len(letters) < limit {
+		msg, ok, err := b.ch.Get(DeadLetterQueue(config.Name), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get a dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+		last = msg.DeliveryTag
+		letters = append(letters, deadLetter(msg))
+	}
+
+	if last != 0 {
+		if err := b.ch.Nack(last, true, true); err != nil {
+			return nil, fmt.Errorf("failed to return dead letters to the queue: %w", err)
+		}
+	}
+	return letters, nil
+}
+
+// ReplayDeadLetters переносит до limit первых сообщений очереди недоставленных сообщений обратно
+// в очередь config со сброшенным счетчиком попыток. Сообщение удаляется из очереди недоставленных
+// только после того, как RabbitMQ подтвердит его публикацию. Возвращает число перенесенных сообщений.
+func (b *BrokerRabbit) ReplayDeadLetters(ctx context.Context, config config.QueueConfig, limit int) (int, error) {
+	replayed := 0
+	for replayed < limit {
+		msg, ok, err := b.ch.Get(DeadLetterQueue(config.Name), false)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to get a dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		headers := make(amqp.Table, len(msg.Headers))
+		for key, value := range msg.Headers {
+			headers[key] = value
+		}
+		delete(headers, AttemptHeader)
+		delete(headers, "x-death")
+
+		err = b.publishConfirmed(ctx, "", config.Name, false, false, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: msg.DeliveryMode,
+			MessageId:    msg.MessageId,
+			Timestamp:    msg.Timestamp,
+			Body:         msg.Body,
+		})
+		if err != nil {
+			_ = msg.Nack(false, true)
+			return replayed, fmt.Errorf("failed to replay a dead letter: %w", err)
+		}
+
+		if err := msg.Ack(false); err != nil {
+			return replayed, fmt.Errorf("failed to remove a replayed dead letter: %w", err)
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// deadLetter разбирает сообщение очереди недоставленных сообщений. RabbitMQ добавляет
+// в заголовок x-death запись о каждом перекладывании, последняя запись идет первой.
+func deadLetter(msg amqp.Delivery) DeadLetter {
+	letter := DeadLetter{Body: msg.Body, Attempts: attempt(msg.Headers)}
+	deaths, _ := msg.Headers["x-death"].([]interface{})
+	if len(deaths) == 0 {
+		return letter
+	}
+	if death, ok := deaths[0].(amqp.Table); ok {
+		letter.Reason, _ = death["reason"].(string)
+		letter.Time, _ = death["time"].(time.Time)
+	}
+	return letter
+}
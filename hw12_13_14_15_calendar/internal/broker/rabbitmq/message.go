@@ -0,0 +1,58 @@
+package rabbitmq
+
+import (
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AttemptHeader заголовок с номером попытки обработки сообщения, отсутствует у первой попытки.
+const AttemptHeader = "x-attempt"
+
+// delivery подтверждает сообщение RabbitMQ по его тегу доставки.
+type delivery struct {
+	amqp.Delivery
+}
+
+func (d delivery) Ack() error {
+	return d.Delivery.Ack(false)
+}
+
+func (d delivery) Nack(requeue bool) error {
+	return d.Delivery.Nack(false, requeue)
+}
+
+// message преобразует доставку RabbitMQ в сообщение брокера. Сообщения, полученные
+// с автоматическим подтверждением, подтверждать нельзя.
+func message(msg amqp.Delivery, autoAck bool) broker.Message {
+	message := broker.Message{
+		Key:         msg.MessageId,
+		ContentType: msg.ContentType,
+		Body:        msg.Body,
+		Attempt:     attempt(msg.Headers),
+	}
+	if !autoAck {
+		message.Acknowledger = delivery{msg}
+	}
+	return message
+}
+
+// attempt возвращает номер попытки обработки сообщения с заголовками headers, начиная с 1.
+func attempt(headers amqp.Table) int {
+	var attempt int64
+	switch v := headers[AttemptHeader].(type) {
+	case int8:
+		attempt = int64(v)
+	case int16:
+		attempt = int64(v)
+	case int32:
+		attempt = int64(v)
+	case int64:
+		attempt = v
+	case int:
+		attempt = int64(v)
+	}
+	if attempt < 1 {
+		return 1
+	}
+	return int(attempt)
+}
@@ -0,0 +1,31 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttempt(t *testing.T) {
+	require.Equal(t, 1, attempt(nil))
+	require.Equal(t, 3, attempt(amqp.Table{AttemptHeader: int32(3)}))
+	require.Equal(t, 4, attempt(amqp.Table{AttemptHeader: int64(4)}))
+	require.Equal(t, 1, attempt(amqp.Table{AttemptHeader: "2"}))
+}
+
+func TestMessage(t *testing.T) {
+	msg := amqp.Delivery{
+		MessageId: "notification:1", ContentType: "application/json", Body: []byte("{}"),
+		Headers: amqp.Table{AttemptHeader: int32(2)},
+	}
+
+	received := message(msg, true)
+	require.Equal(t, broker.Message{
+		Key: "notification:1", ContentType: "application/json", Body: []byte("{}"), Attempt: 2,
+	}, received)
+	require.ErrorIs(t, received.Ack(), broker.ErrAutoAck)
+
+	require.NotNil(t, message(msg, false).Acknowledger)
+}
@@ -2,16 +2,26 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/broker"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// ErrNotConfirmed RabbitMQ отказался принять опубликованное сообщение.
+var ErrNotConfirmed = errors.New("publication was not confirmed")
+
 type BrokerRabbit struct {
 	url  string
 	conn *amqp.Connection
 	ch   *amqp.Channel
+
+	// confirms включен ли на канале режим подтверждения публикаций, см. publishConfirmed.
+	confirmMu sync.Mutex
+	confirms  bool
 }
 
 func New(connConfig config.ConnectionConfig) BrokerRabbit {
@@ -36,15 +46,28 @@ func (b *BrokerRabbit) Start() error {
 	return nil
 }
 
-// QueueDeclare создает (декларирует) очередь в RabbitMQ.
+// QueueDeclare создает (декларирует) очередь в RabbitMQ. Если задан config.DeadLetterExchange,
+// очередь отправляет в него отклоненные сообщения, а вместе с ней объявляются очереди повторов
+// и очередь недоставленных сообщений, см. declareDeadLetters.
 func (b *BrokerRabbit) QueueDeclare(config config.QueueConfig) error {
+	var args amqp.Table
+	if config.DeadLetterExchange != "" {
+		if err := b.declareDeadLetters(config); err != nil {
+			return err
+		}
+		args = amqp.Table{
+			"x-dead-letter-exchange":    config.DeadLetterExchange,
+			"x-dead-letter-routing-key": DeadLetterQueue(config.Name),
+		}
+	}
+
 	_, err := b.ch.QueueDeclare(
 		config.Name,
 		config.Durable,
 		config.AutoDelete,
 		config.Exclusive,
 		config.NoWait,
-		nil,
+		args,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to declare a queue: %w", err)
@@ -53,6 +76,50 @@ func (b *BrokerRabbit) QueueDeclare(config config.QueueConfig) error {
 	return nil
 }
 
+// declareDeadLetters объявляет обменник config.DeadLetterExchange с привязанной к нему очередью
+// недоставленных сообщений DeadLetterQueue и очереди повторов RetryQueue для попыток
+// с 1 по MaxAttempts-1. Сообщение лежит в очереди повторов broker.RetryDelay, после чего
+// возвращается в основную очередь. У каждой попытки своя очередь с постоянным временем жизни
+// сообщений, поэтому сообщения с короткой задержкой не ждут истечения более длинных.
+func (b *BrokerRabbit) declareDeadLetters(config config.QueueConfig) error {
+	err := b.ch.ExchangeDeclare(config.DeadLetterExchange, amqp.ExchangeDirect, config.Durable,
+		false, false, config.NoWait, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare a dead letter exchange: %w", err)
+	}
+
+	dead := DeadLetterQueue(config.Name)
+	if _, err := b.ch.QueueDeclare(dead, config.Durable, false, false, config.NoWait, nil); err != nil {
+		return fmt.Errorf("failed to declare a dead letter queue: %w", err)
+	}
+	if err := b.ch.QueueBind(dead, dead, config.DeadLetterExchange, config.NoWait, nil); err != nil {
+		return fmt.Errorf("failed to bind a dead letter queue: %w", err)
+	}
+
+	for attempt := 1; attempt < config.MaxAttempts; attempt++ {
+		_, err := b.ch.QueueDeclare(RetryQueue(config.Name, attempt), config.Durable, false, false, config.NoWait,
+			amqp.Table{
+				"x-message-ttl":             broker.RetryDelay(config, attempt).Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": config.Name,
+			})
+		if err != nil {
+			return fmt.Errorf("failed to declare a retry queue: %w", err)
+		}
+	}
+	return nil
+}
+
+// RetryQueue возвращает имя очереди, в которой сообщение очереди queue ждет попытки attempt+1.
+func RetryQueue(queue string, attempt int) string {
+	return fmt.Sprintf("%s.retry.%d", queue, attempt)
+}
+
+// DeadLetterQueue возвращает имя очереди недоставленных сообщений очереди queue.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dead"
+}
+
 // Stop закрывает канал и соединение с RabbitMQ.
 func (b *BrokerRabbit) Stop() error {
 	if err := b.ch.Close(); err != nil {
@@ -67,8 +134,9 @@ func (b *BrokerRabbit) Stop() error {
 }
 
 // Consume регистрирует потребителя для указанной очереди и возвращает канал для получения сообщений.
-func (b *BrokerRabbit) Consume(config config.ConsumeConfig) (<-chan amqp.Delivery, error) {
-	delivery, err := b.ch.Consume(
+// Канал закрывается вместе с каналом RabbitMQ.
+func (b *BrokerRabbit) Consume(config config.ConsumeConfig) (<-chan broker.Message, error) {
+	deliveries, err := b.ch.Consume(
 		config.Queue,
 		config.Consumer,
 		config.AutoAck,
@@ -81,7 +149,14 @@ func (b *BrokerRabbit) Consume(config config.ConsumeConfig) (<-chan amqp.Deliver
 		return nil, fmt.Errorf("failed to register a consumer: %w", err)
 	}
 
-	return delivery, nil
+	messages := make(chan broker.Message)
+	go func() {
+		defer close(messages)
+		for msg := range deliveries {
+			messages <- message(msg, config.AutoAck)
+		}
+	}()
+	return messages, nil
 }
 
 // PublishWithContext публикует сообщение в RabbitMQ с использованием контекста.
@@ -101,3 +176,73 @@ func (b *BrokerRabbit) PublishWithContext(ctx context.Context, config config.Pub
 
 	return nil
 }
+
+// PublishWithKey публикует постоянное сообщение с ключом идемпотентности key в свойстве message-id
+// и возвращается после того, как RabbitMQ подтвердит, что принял его.
+func (b *BrokerRabbit) PublishWithKey(ctx context.Context, config config.PublishConfig, key string, body []byte) error {
+	err := b.publishConfirmed(ctx,
+		config.Exchange,
+		config.Key,
+		config.Mandatory,
+		config.Immediate,
+		amqp.Publishing{
+			ContentType:  config.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    key,
+			Body:         body,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to publish a message: %w", err)
+	}
+
+	return nil
+}
+
+// publishConfirmed публикует сообщение и ждет подтверждения RabbitMQ. При первом вызове канал
+// переводится в режим подтверждения публикаций.
+func (b *BrokerRabbit) publishConfirmed(
+	ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
+) error {
+	b.confirmMu.Lock()
+	if !b.confirms {
+		if err := b.ch.Confirm(false); err != nil {
+			b.confirmMu.Unlock()
+			return fmt.Errorf("failed to enable publisher confirms: %w", err)
+		}
+		b.confirms = true
+	}
+	b.confirmMu.Unlock()
+
+	confirmation, err := b.ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	if err != nil {
+		return err
+	}
+	ok, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotConfirmed
+	}
+	return nil
+}
+
+// Retry публикует постоянную копию сообщения msg в очередь повторов его текущей попытки с увеличенным
+// номером попытки в заголовке AttemptHeader и возвращается после того, как RabbitMQ подтвердит, что принял ее.
+func (b *BrokerRabbit) Retry(ctx context.Context, config config.QueueConfig, msg broker.Message) error {
+	if msg.Attempt >= config.MaxAttempts {
+		return fmt.Errorf("message has used all %d attempts", config.MaxAttempts)
+	}
+
+	err := b.publishConfirmed(ctx, "", RetryQueue(config.Name, msg.Attempt), false, false, amqp.Publishing{
+		Headers:      amqp.Table{AttemptHeader: int32(msg.Attempt + 1)},
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.Key,
+		Body:         msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish a message for retry: %w", err)
+	}
+	return nil
+}
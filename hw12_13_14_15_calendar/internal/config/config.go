@@ -15,6 +15,11 @@ type Config struct {
 	HTTPServer *ServerConfig
 	GRPCServer *ServerConfig
 	RabbitMQ   *RabbitMQConfig
+	Auth       *AuthConfig
+	SMTP       *SMTPConfig
+	Webhook    *WebhookConfig
+	Relay      *RelayConfig
+	Scheduler  *SchedulerConfig
 }
 
 type LoggerConfig struct {
@@ -61,6 +66,15 @@ type QueueConfig struct {
 	AutoDelete bool
 	Exclusive  bool
 	NoWait     bool
+	// DeadLetterExchange обменник, в который попадают сообщения, не обработанные за MaxAttempts попыток.
+	// Пустой отключает повторы и очередь недоставленных сообщений.
+	DeadLetterExchange string
+	// MaxAttempts число попыток обработки сообщения, включая первую.
+	MaxAttempts int
+	// RetryDelay задержка перед второй попыткой, перед каждой следующей она удваивается,
+	// но не превышает MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
 }
 
 type RabbitMQConfig struct {
@@ -70,6 +84,54 @@ type RabbitMQConfig struct {
 	Consume    *ConsumeConfig
 }
 
+// AuthConfig настройки проверки JWT. Пустой KeyFile отключает аутентификацию.
+type AuthConfig struct {
+	// Algorithm алгоритм подписи: HS256 или RS256.
+	Algorithm string
+	// KeyFile файл с общим секретом для HS256 или открытым ключом PEM для RS256.
+	KeyFile string
+}
+
+// SMTPConfig настройки почтового сервера, через который рассыльщик отправляет письма.
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username и Password для AUTH PLAIN, пустой Username отключает аутентификацию.
+	Username string
+	Password string
+	// From адрес отправителя писем.
+	From string
+	// StartTLS требует шифрования соединения командой STARTTLS.
+	StartTLS bool
+	// Timeout ограничивает время отправки одного письма, 0 - без ограничения.
+	Timeout time.Duration
+}
+
+// RelayConfig настройки ретранслятора, публикующего сообщения outbox в брокер.
+type RelayConfig struct {
+	// Interval пауза между проверками outbox, когда в нем не осталось сообщений.
+	Interval time.Duration
+	// BatchSize число сообщений, публикуемых в одной транзакции.
+	BatchSize int
+}
+
+// SchedulerConfig настройки планировщика уведомлений.
+type SchedulerConfig struct {
+	// MaxLateness опоздание, после которого уведомление устаревает и не отправляется, 0 - без ограничения.
+	MaxLateness time.Duration
+}
+
+// WebhookConfig настройки доставки уведомлений на адреса пользователей по HTTP.
+type WebhookConfig struct {
+	// Secret ключ подписи запросов HMAC-SHA256, без него канал webhook отключен.
+	Secret string
+	// Timeout ограничивает время одного запроса, 0 - без ограничения.
+	Timeout time.Duration
+	// AllowedHosts узлы, на которые запросы отправляются, даже если они разрешаются во внутренние адреса.
+	// Адреса остальных узлов должны быть публичными.
+	AllowedHosts []string
+}
+
 type ServerConfig struct {
 	Host string
 	Port string
@@ -122,6 +184,11 @@ func New(configPath string) (Config, error) {
 				AutoDelete: viper.GetBool("queue.autoDelete"),
 				Exclusive:  viper.GetBool("queue.exclusive"),
 				NoWait:     viper.GetBool("queue.noWait"),
+
+				DeadLetterExchange: viper.GetString("queue.deadLetterExchange"),
+				MaxAttempts:        viper.GetInt("queue.maxAttempts"),
+				RetryDelay:         viper.GetDuration("queue.retryDelay"),
+				MaxRetryDelay:      viper.GetDuration("queue.maxRetryDelay"),
 			},
 			Consume: &ConsumeConfig{
 				Queue:     viper.GetString("consume.queue"),
@@ -133,6 +200,31 @@ func New(configPath string) (Config, error) {
 				Interval:  viper.GetDuration("consume.interval"),
 			},
 		},
+		Auth: &AuthConfig{
+			Algorithm: viper.GetString("auth.Algorithm"),
+			KeyFile:   viper.GetString("auth.KeyFile"),
+		},
+		SMTP: &SMTPConfig{
+			Host:     viper.GetString("smtp.Host"),
+			Port:     viper.GetString("smtp.Port"),
+			Username: viper.GetString("smtp.Username"),
+			Password: viper.GetString("smtp.Password"),
+			From:     viper.GetString("smtp.From"),
+			StartTLS: viper.GetBool("smtp.StartTLS"),
+			Timeout:  viper.GetDuration("smtp.Timeout"),
+		},
+		Webhook: &WebhookConfig{
+			Secret:       viper.GetString("webhook.Secret"),
+			Timeout:      viper.GetDuration("webhook.Timeout"),
+			AllowedHosts: viper.GetStringSlice("webhook.AllowedHosts"),
+		},
+		Relay: &RelayConfig{
+			Interval:  viper.GetDuration("relay.Interval"),
+			BatchSize: viper.GetInt("relay.BatchSize"),
+		},
+		Scheduler: &SchedulerConfig{
+			MaxLateness: viper.GetDuration("scheduler.MaxLateness"),
+		},
 	}, nil
 }
 
@@ -0,0 +1,528 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+const (
+	prodID     = "-//OTUS//Calendar//RU"
+	dateTime   = "20060102T150405"
+	dateTimeZ  = "20060102T150405Z"
+	dateOnly   = "20060102"
+	lineLength = 75
+)
+
+var (
+	ErrInvalidCalendar = errors.New("invalid iCalendar data")
+	ErrInvalidDuration = errors.New("invalid iCalendar duration")
+)
+
+// property строка содержимого iCalendar: NAME;PARAM=VALUE:VALUE.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Marshal кодирует события в VCALENDAR согласно RFC 5545.
+// UID загруженного события сохраняется, у остальных событий UID - их идентификатор.
+// Время события с часовым поясом записывается в местном времени с TZID, чтобы повторения
+// сохраняли местное время при переходе на летнее время, остальные события - в UTC.
+func Marshal(events []model.Event) []byte {
+	var buf bytes.Buffer
+	stamp := time.Now().UTC().Format(dateTimeZ)
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+prodID)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+
+	locations := make([]*time.Location, len(events))
+	written := make(map[string]bool)
+	for i, event := range events {
+		locations[i] = eventLocation(event)
+		if loc := locations[i]; loc != nil && !written[loc.String()] {
+			writeTimeZone(&buf, loc, event.Beginning.In(loc).Year())
+			written[loc.String()] = true
+		}
+	}
+
+	for i, event := range events {
+		loc := locations[i]
+		writeLine(&buf, "BEGIN:VEVENT")
+		if uid := event.UID; uid != "" || event.ID != "" {
+			if uid == "" {
+				uid = event.ID
+			}
+			writeLine(&buf, "UID:"+escapeText(uid))
+		}
+		writeLine(&buf, "DTSTAMP:"+stamp)
+		writeLine(&buf, formatTimes("DTSTART", loc, event.Beginning))
+		writeLine(&buf, formatTimes("DTEND", loc, event.Finish))
+		writeLine(&buf, "SUMMARY:"+escapeText(event.Title))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Recurrence != "" {
+			writeLine(&buf, "RRULE:"+strings.TrimPrefix(event.Recurrence, "RRULE:"))
+		}
+		if len(event.ExDates) > 0 {
+			writeLine(&buf, formatTimes("EXDATE", loc, event.ExDates...))
+		}
+		if !event.Notification.IsZero() {
+			writeLine(&buf, "BEGIN:VALARM")
+			writeLine(&buf, "ACTION:DISPLAY")
+			writeLine(&buf, "DESCRIPTION:"+escapeText(event.Title))
+			writeLine(&buf, "TRIGGER:"+formatDuration(event.Notification.Sub(event.Beginning)))
+			writeLine(&buf, "END:VALARM")
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// eventLocation возвращает часовой пояс события или nil, если время события записывается в UTC.
+func eventLocation(event model.Event) *time.Location {
+	if event.TimeZone == "" || event.TimeZone == "UTC" {
+		return nil
+	}
+	loc, err := model.LoadLocation(event.TimeZone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// formatTimes форматирует свойство со списком моментов времени в UTC или, если loc задан,
+// в местном времени с параметром TZID.
+func formatTimes(name string, loc *time.Location, times ...time.Time) string {
+	values := make([]string, 0, len(times))
+	for _, t := range times {
+		if loc == nil {
+			values = append(values, t.UTC().Format(dateTimeZ))
+		} else {
+			values = append(values, t.In(loc).Format(dateTime))
+		}
+	}
+	if loc != nil {
+		name += ";TZID=" + loc.String()
+	}
+	return name + ":" + strings.Join(values, ",")
+}
+
+// vevent свойства VEVENT и вложенных VALARM. Событие собирается после разбора всего календаря,
+// потому что VTIMEZONE, на которые оно ссылается, могут идти после него.
+type vevent struct {
+	props  []property
+	alarms [][]property
+}
+
+// Unmarshal разбирает VCALENDAR и возвращает описанные в нем события.
+// Из всех напоминаний события используется первое. Часовой пояс события берется из TZID в DTSTART.
+func Unmarshal(data []byte) ([]model.Event, error) {
+	lines, err := unfold(data)
+	if err != nil {
+		return nil, err
+	}
+
+	vevents := make([]vevent, 0)
+	zones := make(timeZones)
+	stack := make([]string, 0)
+	var eventProps, alarmProps []property
+	var alarms [][]property
+	var tzid string
+	var offset *int
+
+	for i, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCalendar, i+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			switch current(stack) {
+			case "VEVENT":
+				eventProps, alarms = nil, nil
+			case "VALARM":
+				alarmProps = nil
+			case "VTIMEZONE":
+				tzid, offset = "", nil
+			}
+			continue
+		case "END":
+			if len(stack) == 0 || current(stack) != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidCalendar, i+1, prop.value)
+			}
+			switch current(stack) {
+			case "VEVENT":
+				vevents = append(vevents, vevent{props: eventProps, alarms: alarms})
+			case "VALARM":
+				alarms = append(alarms, alarmProps)
+			case "VTIMEZONE":
+				if tzid != "" && offset != nil {
+					zones[tzid] = time.FixedZone(tzid, *offset)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		switch current(stack) {
+		case "VEVENT":
+			eventProps = append(eventProps, prop)
+		case "VALARM":
+			alarmProps = append(alarmProps, prop)
+		case "VTIMEZONE":
+			if prop.name == "TZID" {
+				tzid = prop.value
+			}
+		case "STANDARD", "DAYLIGHT":
+			// Без правил IANA пояс заменяется смещением стандартного времени.
+			if prop.name == "TZOFFSETTO" && (offset == nil || current(stack) == "STANDARD") {
+				value, err := parseOffset(prop.value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCalendar, i+1, err)
+				}
+				offset = &value
+			}
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: unterminated %s", ErrInvalidCalendar, current(stack))
+	}
+
+	events := make([]model.Event, 0, len(vevents))
+	for _, v := range vevents {
+		event, err := buildEvent(v.props, v.alarms, zones)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// buildEvent собирает событие из свойств VEVENT и вложенных VALARM.
+func buildEvent(props []property, alarms [][]property, zones timeZones) (model.Event, error) {
+	var event model.Event
+	var duration time.Duration
+	var allDay, hasFinish, hasDuration bool
+
+	for _, prop := range props {
+		var err error
+		switch prop.name {
+		case "UID":
+			event.UID = unescapeText(prop.value)
+		case "SUMMARY":
+			event.Title = unescapeText(prop.value)
+		case "DESCRIPTION":
+			event.Description = unescapeText(prop.value)
+		case "DTSTART":
+			event.Beginning, err = parseTime(prop, zones)
+			if tzid := prop.params["TZID"]; tzid != "" {
+				_, event.TimeZone = zones.location(tzid)
+			}
+			allDay = strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len(dateOnly)
+		case "DTEND":
+			event.Finish, err = parseTime(prop, zones)
+			hasFinish = true
+		case "DURATION":
+			duration, err = parseDuration(prop.value)
+			hasDuration = true
+		case "RRULE":
+			event.Recurrence = prop.value
+			_, err = model.ParseRecurrence(prop.value)
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				var exDate time.Time
+				exDate, err = parseTime(property{name: prop.name, params: prop.params, value: value}, zones)
+				if err != nil {
+					break
+				}
+				event.ExDates = append(event.ExDates, exDate)
+			}
+		}
+		if err != nil {
+			return model.Event{}, fmt.Errorf("%w: %s: %v", ErrInvalidCalendar, prop.name, err)
+		}
+	}
+
+	if event.Beginning.IsZero() {
+		return model.Event{}, fmt.Errorf("%w: VEVENT without DTSTART", ErrInvalidCalendar)
+	}
+
+	switch {
+	case hasFinish:
+	case hasDuration:
+		event.Finish = event.Beginning.Add(duration)
+	case allDay:
+		event.Finish = event.Beginning.AddDate(0, 0, 1)
+	default:
+		event.Finish = event.Beginning
+	}
+
+	if len(alarms) > 0 {
+		notification, err := parseTrigger(alarms[0], event, zones)
+		if err != nil {
+			return model.Event{}, err
+		}
+		event.Notification = notification
+	}
+
+	return event, nil
+}
+
+// parseTrigger вычисляет момент напоминания по свойству TRIGGER.
+func parseTrigger(props []property, event model.Event, zones timeZones) (time.Time, error) {
+	for _, prop := range props {
+		if prop.name != "TRIGGER" {
+			continue
+		}
+
+		if strings.EqualFold(prop.params["VALUE"], "DATE-TIME") {
+			t, err := parseTime(prop, zones)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("%w: TRIGGER: %v", ErrInvalidCalendar, err)
+			}
+			return t, nil
+		}
+
+		offset, err := parseDuration(prop.value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: TRIGGER: %v", ErrInvalidCalendar, err)
+		}
+		if strings.EqualFold(prop.params["RELATED"], "END") {
+			return event.Finish.Add(offset), nil
+		}
+		return event.Beginning.Add(offset), nil
+	}
+
+	return time.Time{}, nil
+}
+
+// parseTime разбирает DATE-TIME или DATE с учетом параметра TZID.
+func parseTime(prop property, zones timeZones) (time.Time, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeZ, value)
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		loc, _ = zones.location(tzid)
+	}
+
+	if len(value) == len(dateOnly) {
+		t, err := time.ParseInLocation(dateOnly, value, loc)
+		return t.UTC(), err
+	}
+
+	t, err := time.ParseInLocation(dateTime, value, loc)
+	return t.UTC(), err
+}
+
+// parseDuration разбирает длительность RFC 5545, например "-PT15M" или "P1DT2H".
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	sign := time.Duration(1)
+
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+	s = s[1:]
+
+	var result time.Duration
+	inTime := false
+	number := ""
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			if inTime || number != "" {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+			}
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+		}
+		number = ""
+
+		unit := time.Duration(0)
+		switch {
+		case r == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case r == 'H' && inTime:
+			unit = time.Hour
+		case r == 'M' && inTime:
+			unit = time.Minute
+		case r == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+		}
+		result += time.Duration(n) * unit
+	}
+
+	if number != "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+
+	return sign * result, nil
+}
+
+// formatDuration форматирует длительность в формате RFC 5545.
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 || days == 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 || (hours == 0 && minutes == 0) {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+	return b.String()
+}
+
+// unfold разбивает данные на строки, склеивая перенесенные (RFC 5545, 3.1).
+func unfold(data []byte) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseLine разбирает строку содержимого на имя, параметры и значение.
+func parseLine(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+	inQuotes := false
+	start := 0
+	name := ""
+
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ';' || r == ':') && !inQuotes:
+			part := line[start:i]
+			if name == "" {
+				name = part
+			} else if key, value, ok := strings.Cut(part, "="); ok {
+				prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+			start = i + 1
+
+			if r == ':' {
+				prop.name = strings.ToUpper(name)
+				prop.value = line[i+1:]
+				return prop, nil
+			}
+		}
+	}
+
+	return prop, fmt.Errorf("missing ':' in %q", line)
+}
+
+// writeLine записывает строку, перенося ее по 75 октетов (RFC 5545, 3.1).
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Продолжение начинается с пробела, который входит в лимит.
+		limit = lineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+func current(stack []string) string {
+	if len(stack) == 0 {
+		return ""
+	}
+	return stack[len(stack)-1]
+}
@@ -0,0 +1,247 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	events := []model.Event{
+		{
+			ID:           "f0a1c8d2-5b6e-4f7a-9c3d-2e1b0a9f8e7d",
+			Title:        "Планерка; обсуждение, итоги",
+			Description:  "Повестка:\nпервый пункт\\второй пункт " + strings.Repeat("длинный текст ", 10),
+			Beginning:    beginning,
+			Finish:       beginning.Add(90 * time.Minute),
+			Notification: beginning.Add(-15 * time.Minute),
+			Recurrence:   "FREQ=WEEKLY;BYDAY=MO",
+			ExDates:      []time.Time{beginning.AddDate(0, 0, 7)},
+		},
+		{
+			ID:        "3c2b1a09-8f7e-4d6c-5b4a-392817060504",
+			UID:       "imported@example.com",
+			Title:     "Без напоминания",
+			Beginning: beginning.AddDate(0, 0, 1),
+			Finish:    beginning.AddDate(0, 0, 2),
+		},
+	}
+
+	data := Marshal(events)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		require.LessOrEqual(t, len(line), lineLength)
+	}
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	// UID выгруженного события - его идентификатор, у загруженного события UID сохраняется.
+	require.Equal(t, events[0].ID, decoded[0].UID)
+	require.Equal(t, events[1].UID, decoded[1].UID)
+
+	for i, event := range decoded {
+		require.Equal(t, events[i].Title, event.Title)
+		require.Equal(t, events[i].Description, event.Description)
+		require.True(t, events[i].Beginning.Equal(event.Beginning))
+		require.True(t, events[i].Finish.Equal(event.Finish))
+		require.True(t, events[i].Notification.Equal(event.Notification))
+		require.Equal(t, events[i].Recurrence, event.Recurrence)
+		require.Equal(t, events[i].ExDates, event.ExDates)
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// 10:00 по Берлину летом - это 08:00 UTC, а после перехода на зимнее время - 09:00 UTC.
+		beginning := time.Date(2024, time.October, 21, 8, 0, 0, 0, time.UTC)
+		events := []model.Event{{
+			ID:         "5d6e7f80-91a2-4b3c-8d4e-5f6a7b8c9d0e",
+			Title:      "Планерка",
+			Beginning:  beginning,
+			Finish:     beginning.Add(time.Hour),
+			Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+			ExDates:    []time.Time{beginning.AddDate(0, 0, 14).Add(time.Hour)},
+			TimeZone:   "Europe/Berlin",
+		}}
+
+		data := string(Marshal(events))
+		require.Contains(t, data, "DTSTART;TZID=Europe/Berlin:20241021T100000\r\n")
+		require.Contains(t, data, "DTEND;TZID=Europe/Berlin:20241021T110000\r\n")
+		require.Contains(t, data, "EXDATE;TZID=Europe/Berlin:20241104T100000\r\n")
+		require.Contains(t, data, strings.Join([]string{
+			"BEGIN:VTIMEZONE",
+			"TZID:Europe/Berlin",
+			"BEGIN:DAYLIGHT",
+			"DTSTART:20240331T020000",
+			"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+			"TZOFFSETFROM:+0100",
+			"TZOFFSETTO:+0200",
+			"TZNAME:CEST",
+			"END:DAYLIGHT",
+			"BEGIN:STANDARD",
+			"DTSTART:20241027T030000",
+			"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+			"TZOFFSETFROM:+0200",
+			"TZOFFSETTO:+0100",
+			"TZNAME:CET",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		}, "\r\n"))
+
+		decoded, err := Unmarshal([]byte(data))
+		require.NoError(t, err)
+		require.Len(t, decoded, 1)
+		require.Equal(t, "Europe/Berlin", decoded[0].TimeZone)
+		require.Equal(t, events[0].Beginning, decoded[0].Beginning)
+		require.Equal(t, events[0].Finish, decoded[0].Finish)
+		require.Equal(t, events[0].ExDates, decoded[0].ExDates)
+	})
+
+	t.Run("foreign time zones", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			`DTSTART;TZID="W. Europe Standard Time":20240603T100000`,
+			"SUMMARY:Outlook",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART;TZID=Custom Zone:20240603T100000",
+			"SUMMARY:VTIMEZONE",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART;TZID=Mars/Olympus:20240603T100000",
+			"SUMMARY:Unknown",
+			"END:VEVENT",
+			"BEGIN:VTIMEZONE",
+			"TZID:Custom Zone",
+			"BEGIN:DAYLIGHT",
+			"DTSTART:19700329T020000",
+			"TZOFFSETFROM:+0300",
+			"TZOFFSETTO:+0400",
+			"END:DAYLIGHT",
+			"BEGIN:STANDARD",
+			"DTSTART:19701025T030000",
+			"TZOFFSETFROM:+0400",
+			"TZOFFSETTO:+0300",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := Unmarshal([]byte(data))
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+
+		require.Equal(t, "Europe/Berlin", events[0].TimeZone)
+		require.Equal(t, time.Date(2024, time.June, 3, 8, 0, 0, 0, time.UTC), events[0].Beginning)
+		// Пояс без имени IANA заменяется смещением стандартного времени из VTIMEZONE.
+		require.Empty(t, events[1].TimeZone)
+		require.Equal(t, time.Date(2024, time.June, 3, 7, 0, 0, 0, time.UTC), events[1].Beginning)
+		require.Empty(t, events[2].TimeZone)
+		require.Equal(t, time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC), events[2].Beginning)
+	})
+
+	t.Run("windows zones", func(t *testing.T) {
+		for windows, iana := range windowsZones {
+			_, err := model.LoadLocation(iana)
+			require.NoError(t, err, windows)
+		}
+	})
+
+	t.Run("offsets", func(t *testing.T) {
+		for value, expected := range map[string]int{"+0300": 3 * 3600, "-0530": -(5*3600 + 30*60), "+000015": 15} {
+			offset, err := parseOffset(value)
+			require.NoError(t, err, value)
+			require.Equal(t, expected, offset, value)
+			require.Equal(t, value, formatOffset(offset))
+		}
+		for _, value := range []string{"", "0300", "+03", "+03:00", "+0a00"} {
+			_, err := parseOffset(value)
+			require.Error(t, err, value)
+		}
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("foreign calendar", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Google Inc//Google Calendar 70.9054//EN",
+			"BEGIN:VEVENT",
+			"DTSTART;TZID=Europe/Moscow:20240603T100000",
+			"DURATION:PT1H30M",
+			"SUMMARY:Встреча с ",
+			" командой",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"TRIGGER;RELATED=END:-P1D",
+			"END:VALARM",
+			"BEGIN:VALARM",
+			"TRIGGER:-PT5M",
+			"END:VALARM",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20240610",
+			"SUMMARY:Отпуск",
+			"BEGIN:VALARM",
+			"TRIGGER;VALUE=DATE-TIME:20240609T090000Z",
+			"END:VALARM",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := Unmarshal([]byte(data))
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		require.Equal(t, "Встреча с командой", events[0].Title)
+		require.Equal(t, time.Date(2024, time.June, 3, 7, 0, 0, 0, time.UTC), events[0].Beginning)
+		require.Equal(t, time.Date(2024, time.June, 3, 8, 30, 0, 0, time.UTC), events[0].Finish)
+		require.Equal(t, time.Date(2024, time.June, 2, 8, 30, 0, 0, time.UTC), events[0].Notification)
+
+		require.Equal(t, time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC), events[1].Beginning)
+		require.Equal(t, time.Date(2024, time.June, 11, 0, 0, 0, 0, time.UTC), events[1].Finish)
+		require.Equal(t, time.Date(2024, time.June, 9, 9, 0, 0, 0, time.UTC), events[1].Notification)
+	})
+
+	t.Run("invalid calendar", func(t *testing.T) {
+		for _, data := range []string{
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\nEND:VCALENDAR",
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240603T100000Z\nEND:VCALENDAR",
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-06-03\nEND:VEVENT\nEND:VCALENDAR",
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240603T100000Z\nRRULE:FREQ=SECONDLY\nEND:VEVENT\nEND:VCALENDAR",
+			"BEGIN:VCALENDAR\nnot a content line\nEND:VCALENDAR",
+		} {
+			_, err := Unmarshal([]byte(data))
+			require.ErrorIs(t, err, ErrInvalidCalendar, data)
+		}
+	})
+}
+
+func TestDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"-PT15M":    -15 * time.Minute,
+		"P1DT2H":    26 * time.Hour,
+		"+P1W":      7 * 24 * time.Hour,
+		"PT1H0M30S": time.Hour + 30*time.Second,
+		"PT0S":      0,
+	} {
+		d, err := parseDuration(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, d, value)
+
+		parsed, err := parseDuration(formatDuration(d))
+		require.NoError(t, err, value)
+		require.Equal(t, expected, parsed, value)
+	}
+
+	for _, value := range []string{"", "P", "15M", "PT15", "P1H", "PT1D"} {
+		_, err := parseDuration(value)
+		require.ErrorIs(t, err, ErrInvalidDuration, value)
+	}
+}
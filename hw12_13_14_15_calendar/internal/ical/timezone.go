@@ -0,0 +1,262 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// windowsZones часовые пояса IANA для распространенных имен Windows, которые Outlook и Exchange
+// передают в TZID. Соответствие взято из windowsZones.xml CLDR для территории 001.
+var windowsZones = map[string]string{
+	"Dateline Standard Time":          "Etc/GMT+12",
+	"UTC-11":                          "Etc/GMT+11",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"Pacific Standard Time (Mexico)":  "America/Tijuana",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Mountain Standard Time":          "America/Denver",
+	"Mountain Standard Time (Mexico)": "America/Mazatlan",
+	"Central America Standard Time":   "America/Guatemala",
+	"Central Standard Time":           "America/Chicago",
+	"Central Standard Time (Mexico)":  "America/Mexico_City",
+	"Canada Central Standard Time":    "America/Regina",
+	"SA Pacific Standard Time":        "America/Bogota",
+	"Eastern Standard Time":           "America/New_York",
+	"US Eastern Standard Time":        "America/Indiana/Indianapolis",
+	"Venezuela Standard Time":         "America/Caracas",
+	"Atlantic Standard Time":          "America/Halifax",
+	"SA Western Standard Time":        "America/La_Paz",
+	"Central Brazilian Standard Time": "America/Cuiaba",
+	"Paraguay Standard Time":          "America/Asuncion",
+	"Pacific SA Standard Time":        "America/Santiago",
+	"Newfoundland Standard Time":      "America/St_Johns",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"Argentina Standard Time":         "America/Argentina/Buenos_Aires",
+	"SA Eastern Standard Time":        "America/Cayenne",
+	"Montevideo Standard Time":        "America/Montevideo",
+	"UTC-02":                          "Etc/GMT+2",
+	"Azores Standard Time":            "Atlantic/Azores",
+	"Cape Verde Standard Time":        "Atlantic/Cape_Verde",
+	"GMT Standard Time":               "Europe/London",
+	"Greenwich Standard Time":         "Atlantic/Reykjavik",
+	"Morocco Standard Time":           "Africa/Casablanca",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Romance Standard Time":           "Europe/Paris",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"Middle East Standard Time":       "Asia/Beirut",
+	"Egypt Standard Time":             "Africa/Cairo",
+	"E. Europe Standard Time":         "Europe/Chisinau",
+	"Syria Standard Time":             "Asia/Damascus",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"FLE Standard Time":               "Europe/Kiev",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"Kaliningrad Standard Time":       "Europe/Kaliningrad",
+	"Jordan Standard Time":            "Asia/Amman",
+	"Arabic Standard Time":            "Asia/Baghdad",
+	"Turkey Standard Time":            "Europe/Istanbul",
+	"Arab Standard Time":              "Asia/Riyadh",
+	"Belarus Standard Time":           "Europe/Minsk",
+	"Russian Standard Time":           "Europe/Moscow",
+	"E. Africa Standard Time":         "Africa/Nairobi",
+	"Volgograd Standard Time":         "Europe/Volgograd",
+	"Iran Standard Time":              "Asia/Tehran",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"Astrakhan Standard Time":         "Europe/Astrakhan",
+	"Azerbaijan Standard Time":        "Asia/Baku",
+	"Russia Time Zone 3":              "Europe/Samara",
+	"Saratov Standard Time":           "Europe/Saratov",
+	"Georgian Standard Time":          "Asia/Tbilisi",
+	"Caucasus Standard Time":          "Asia/Yerevan",
+	"Afghanistan Standard Time":       "Asia/Kabul",
+	"West Asia Standard Time":         "Asia/Tashkent",
+	"Ekaterinburg Standard Time":      "Asia/Yekaterinburg",
+	"Pakistan Standard Time":          "Asia/Karachi",
+	"India Standard Time":             "Asia/Kolkata",
+	"Sri Lanka Standard Time":         "Asia/Colombo",
+	"Nepal Standard Time":             "Asia/Kathmandu",
+	"Central Asia Standard Time":      "Asia/Almaty",
+	"Bangladesh Standard Time":        "Asia/Dhaka",
+	"Omsk Standard Time":              "Asia/Omsk",
+	"Myanmar Standard Time":           "Asia/Yangon",
+	"SE Asia Standard Time":           "Asia/Bangkok",
+	"Altai Standard Time":             "Asia/Barnaul",
+	"N. Central Asia Standard Time":   "Asia/Novosibirsk",
+	"Tomsk Standard Time":             "Asia/Tomsk",
+	"North Asia Standard Time":        "Asia/Krasnoyarsk",
+	"China Standard Time":             "Asia/Shanghai",
+	"North Asia East Standard Time":   "Asia/Irkutsk",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"W. Australia Standard Time":      "Australia/Perth",
+	"Taipei Standard Time":            "Asia/Taipei",
+	"Transbaikal Standard Time":       "Asia/Chita",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"Korea Standard Time":             "Asia/Seoul",
+	"Yakutsk Standard Time":           "Asia/Yakutsk",
+	"Cen. Australia Standard Time":    "Australia/Adelaide",
+	"AUS Central Standard Time":       "Australia/Darwin",
+	"E. Australia Standard Time":      "Australia/Brisbane",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"West Pacific Standard Time":      "Pacific/Port_Moresby",
+	"Tasmania Standard Time":          "Australia/Hobart",
+	"Vladivostok Standard Time":       "Asia/Vladivostok",
+	"Sakhalin Standard Time":          "Asia/Sakhalin",
+	"Magadan Standard Time":           "Asia/Magadan",
+	"Russia Time Zone 10":             "Asia/Srednekolymsk",
+	"Russia Time Zone 11":             "Asia/Kamchatka",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+	"UTC+12":                          "Etc/GMT-12",
+	"Fiji Standard Time":              "Pacific/Fiji",
+	"Tonga Standard Time":             "Pacific/Tongatapu",
+	"Samoa Standard Time":             "Pacific/Apia",
+}
+
+// weekdays сокращения дней недели RFC 5545 в порядке time.Weekday.
+var weekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// timeZones часовые пояса из компонентов VTIMEZONE по TZID. Пояс задан смещением
+// стандартного времени и нужен только для TZID, которых нет в базе IANA.
+type timeZones map[string]*time.Location
+
+// location возвращает часовой пояс для TZID и его имя IANA. TZID ищется в базе IANA,
+// среди имен Windows и среди VTIMEZONE календаря. Неизвестный TZID означает UTC,
+// чтобы один такой пояс не прерывал загрузку всего календаря.
+func (zones timeZones) location(tzid string) (*time.Location, string) {
+	name := strings.TrimPrefix(strings.TrimSpace(tzid), "/")
+	if loc, err := model.LoadLocation(name); err == nil && name != "" {
+		return loc, name
+	}
+	if iana, ok := windowsZones[name]; ok {
+		if loc, err := model.LoadLocation(iana); err == nil {
+			return loc, iana
+		}
+	}
+	if loc, ok := zones[tzid]; ok {
+		return loc, ""
+	}
+	return time.UTC, ""
+}
+
+// parseOffset разбирает смещение UTC в формате RFC 5545, например "+0300" или "-053000".
+func parseOffset(value string) (int, error) {
+	s := strings.TrimSpace(value)
+	if len(s) != 5 && len(s) != 7 || s[0] != '+' && s[0] != '-' {
+		return 0, fmt.Errorf("invalid UTC offset %q", value)
+	}
+
+	offset := 0
+	for i, unit := range []int{3600, 60, 1}[:(len(s)-1)/2] {
+		n, err := strconv.Atoi(s[1+2*i : 3+2*i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid UTC offset %q", value)
+		}
+		offset += n * unit
+	}
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// formatOffset форматирует смещение UTC в секундах в формате RFC 5545.
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	if offset%60 != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, offset/3600, offset/60%60, offset%60)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+}
+
+// transition переход часового пояса на новое смещение.
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// transitions возвращает переходы часового пояса loc в течение года year.
+func transitions(loc *time.Location, year int) []transition {
+	result := make([]transition, 0, 2)
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+
+	for day := start; day.Before(end); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, before := day.Zone()
+		if _, after := next.Zone(); after == before {
+			continue
+		}
+
+		// Момент перехода ищется с точностью до секунды.
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, offset := mid.Zone(); offset == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		name, offset := hi.Zone()
+		result = append(result, transition{at: hi, offsetFrom: before, offsetTo: offset, name: name, dst: hi.IsDST()})
+	}
+
+	return result
+}
+
+// writeTimeZone записывает компонент VTIMEZONE для часового пояса loc. Переходы берутся из года year
+// и повторяются ежегодно в тот же день недели месяца, как в правилах большинства поясов с летним временем.
+// Получатели, знающие пояс IANA из TZID, используют собственные правила.
+func writeTimeZone(buf *bytes.Buffer, loc *time.Location, year int) {
+	writeLine(buf, "BEGIN:VTIMEZONE")
+	writeLine(buf, "TZID:"+loc.String())
+
+	changes := transitions(loc, year)
+	if len(changes) == 0 {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		writeLine(buf, "BEGIN:STANDARD")
+		writeLine(buf, "DTSTART:19700101T000000")
+		writeLine(buf, "TZOFFSETFROM:"+formatOffset(offset))
+		writeLine(buf, "TZOFFSETTO:"+formatOffset(offset))
+		writeLine(buf, "TZNAME:"+name)
+		writeLine(buf, "END:STANDARD")
+	}
+
+	for _, change := range changes {
+		component := "STANDARD"
+		if change.dst {
+			component = "DAYLIGHT"
+		}
+		// DTSTART перехода указывается в местном времени до перехода.
+		local := change.at.In(time.FixedZone("", change.offsetFrom))
+		week := (local.Day()-1)/7 + 1
+		if local.AddDate(0, 0, 7).Month() != local.Month() {
+			week = -1
+		}
+
+		writeLine(buf, "BEGIN:"+component)
+		writeLine(buf, "DTSTART:"+local.Format(dateTime))
+		writeLine(buf, fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s",
+			local.Month(), week, weekdays[local.Weekday()]))
+		writeLine(buf, "TZOFFSETFROM:"+formatOffset(change.offsetFrom))
+		writeLine(buf, "TZOFFSETTO:"+formatOffset(change.offsetTo))
+		writeLine(buf, "TZNAME:"+change.name)
+		writeLine(buf, "END:"+component)
+	}
+
+	writeLine(buf, "END:VTIMEZONE")
+}
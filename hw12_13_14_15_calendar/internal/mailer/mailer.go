@@ -0,0 +1,108 @@
+// Package mailer формирует письма с уведомлениями календаря и отправляет их по SMTP.
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	netmail "net/mail"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// timeLayout формат времени события в письме.
+const timeLayout = "02.01.2006 15:04 MST"
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templates, "templates/notice.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/notice.html.tmpl"))
+)
+
+// Message письмо с текстовой и HTML-версией.
+type Message struct {
+	To      netmail.Address
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// noticeData данные шаблонов письма.
+type noticeData struct {
+	Name        string
+	Invitation  bool
+	Lead        string
+	Title       string
+	Description string
+	Beginning   string
+	Finish      string
+}
+
+// Render формирует письмо получателю recipient по сообщению из очереди.
+// Время события показывается в часовом поясе получателя.
+func Render(notice model.Notice, recipient model.IUser) (Message, error) {
+	loc, err := model.LoadLocation(recipient.GetTimeZone())
+	if err != nil {
+		loc = time.UTC
+	}
+
+	name := strings.TrimSpace(recipient.GetFirstName() + " " + recipient.GetLastName())
+	data := noticeData{
+		Name:        recipient.GetFirstName(),
+		Invitation:  notice.Type == model.InvitationMessage,
+		Lead:        lead(notice),
+		Title:       notice.Title,
+		Description: notice.Description,
+		Beginning:   notice.Beginning.In(loc).Format(timeLayout),
+	}
+	if !notice.Finish.IsZero() {
+		data.Finish = notice.Finish.In(loc).Format(timeLayout)
+	}
+
+	var text, html bytes.Buffer
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      netmail.Address{Name: name, Address: recipient.GetEmail()},
+		Subject: subject(notice),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// subject возвращает тему письма.
+func subject(notice model.Notice) string {
+	switch notice.Type {
+	case model.InvitationMessage:
+		return "Приглашение: " + notice.Title
+	case model.ReminderMessage:
+		return "Напоминание: " + notice.Title
+	}
+	return "Скоро начнется: " + notice.Title
+}
+
+// lead возвращает, через сколько начнется событие, для напоминаний, и "Скоро" для уведомлений.
+func lead(notice model.Notice) string {
+	minutes := notice.MinutesBefore
+	switch {
+	case notice.Type != model.ReminderMessage:
+		return "Скоро"
+	case minutes == 0:
+		return "Сейчас"
+	case minutes%(24*60) == 0:
+		return fmt.Sprintf("Через %d дн.", minutes/(24*60))
+	case minutes%60 == 0:
+		return fmt.Sprintf("Через %d ч.", minutes/60)
+	}
+	return fmt.Sprintf("Через %d мин.", minutes)
+}
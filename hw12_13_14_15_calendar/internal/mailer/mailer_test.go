@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/mailer/smtptest"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	beginning := time.Date(2024, time.June, 3, 7, 0, 0, 0, time.UTC)
+	recipient := &model.User{
+		FirstName: "Алиса", LastName: "Петрова", Email: "alice@example.com", TimeZone: "Europe/Moscow",
+	}
+
+	t.Run("reminder", func(t *testing.T) {
+		message, err := Render(model.Notice{
+			Type: model.ReminderMessage, Title: "Планерка <срочно>", Description: "Отчеты & планы",
+			Beginning: beginning, Finish: beginning.Add(time.Hour), MinutesBefore: 120,
+		}, recipient)
+		require.NoError(t, err)
+		require.Equal(t, "Алиса Петрова", message.To.Name)
+		require.Equal(t, "alice@example.com", message.To.Address)
+		require.Equal(t, "Напоминание: Планерка <срочно>", message.Subject)
+
+		require.Contains(t, message.Text, "Здравствуйте, Алиса!")
+		require.Contains(t, message.Text, "Через 2 ч. начнется событие «Планерка <срочно>».")
+		require.Contains(t, message.Text, "Начало: 03.06.2024 10:00 MSK")
+		require.Contains(t, message.Text, "Окончание: 03.06.2024 11:00 MSK")
+		require.Contains(t, message.Text, "Отчеты & планы")
+
+		require.Contains(t, message.HTML, "<b>Планерка &lt;срочно&gt;</b>")
+		require.Contains(t, message.HTML, "Отчеты &amp; планы")
+		require.Contains(t, message.HTML, "03.06.2024 10:00 MSK")
+	})
+
+	t.Run("invitation", func(t *testing.T) {
+		message, err := Render(model.Notice{
+			Type: model.InvitationMessage, Title: "Ретро", Beginning: beginning,
+		}, &model.User{Email: "bob@example.com"})
+		require.NoError(t, err)
+		require.Equal(t, "Приглашение: Ретро", message.Subject)
+		require.Contains(t, message.Text, "Здравствуйте!")
+		require.Contains(t, message.Text, "Вас пригласили на событие «Ретро».")
+		require.Contains(t, message.Text, "Начало: 03.06.2024 07:00 UTC")
+		require.NotContains(t, message.Text, "Окончание")
+	})
+
+	t.Run("event notification", func(t *testing.T) {
+		message, err := Render(model.Notice{Title: "Ретро", Beginning: beginning}, recipient)
+		require.NoError(t, err)
+		require.Equal(t, "Скоро начнется: Ретро", message.Subject)
+		require.Contains(t, message.Text, "Скоро начнется событие «Ретро».")
+	})
+}
+
+func TestLead(t *testing.T) {
+	for minutes, expected := range map[int]string{
+		0: "Сейчас", 15: "Через 15 мин.", 90: "Через 90 мин.", 60: "Через 1 ч.", 2880: "Через 2 дн.",
+	} {
+		require.Equal(t, expected, lead(model.Notice{Type: model.ReminderMessage, MinutesBefore: minutes}))
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	server := smtptest.NewServer()
+	defer server.Close()
+
+	conf := config.SMTPConfig{
+		Host: server.Host(), Port: server.Port(), Username: "calendar", Password: "secret",
+		From: "Календарь <calendar@example.com>", Timeout: 5 * time.Second,
+	}
+	message := Message{Subject: "Напоминание: Планерка", Text: "Текст письма", HTML: "<p>Текст письма</p>"}
+	message.To.Name, message.To.Address = "Алиса", "alice@example.com"
+	ctx := context.Background()
+
+	require.NoError(t, NewSMTP(conf).Send(ctx, message))
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "calendar", messages[0].Username)
+	require.Equal(t, "calendar@example.com", messages[0].From)
+	require.Equal(t, []string{"alice@example.com"}, messages[0].To)
+
+	mail, err := messages[0].Parse()
+	require.NoError(t, err)
+	require.Equal(t, "Напоминание: Планерка", mail.Subject)
+	require.Equal(t, "Текст письма", mail.Text)
+	require.Equal(t, "<p>Текст письма</p>", mail.HTML)
+	to, err := mail.Header.AddressList("To")
+	require.NoError(t, err)
+	require.Equal(t, "Алиса", to[0].Name)
+	require.NotEmpty(t, mail.Header.Get("Message-ID"))
+
+	t.Run("temporary failure", func(t *testing.T) {
+		server.Fail(true)
+		defer server.Fail(false)
+		require.Error(t, NewSMTP(conf).Send(ctx, message))
+		require.Len(t, server.Messages(), 1)
+	})
+
+	t.Run("STARTTLS required", func(t *testing.T) {
+		tlsConf := conf
+		tlsConf.StartTLS = true
+		require.True(t, errors.Is(NewSMTP(tlsConf).Send(ctx, message), ErrNoStartTLS))
+	})
+
+	t.Run("invalid sender", func(t *testing.T) {
+		invalid := conf
+		invalid.From = "calendar"
+		require.Error(t, NewSMTP(invalid).Send(ctx, message))
+	})
+}
@@ -0,0 +1,249 @@
+// Package smtptest предоставляет SMTP-сервер в памяти процесса для тестов отправки писем.
+package smtptest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message письмо, принятое сервером.
+type Message struct {
+	// Username имя пользователя из AUTH PLAIN, пустое без аутентификации.
+	Username string
+	From     string
+	To       []string
+	Data     []byte
+}
+
+// Mail разобранное письмо multipart/alternative.
+type Mail struct {
+	Header  netmail.Header
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Server SMTP-сервер, принимающий письма на 127.0.0.1 и сохраняющий их в памяти.
+type Server struct {
+	// Addr адрес сервера в виде host:port.
+	Addr string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	messages []Message
+	fail     bool
+}
+
+// NewServer запускает сервер на свободном порту. Сервер нужно остановить методом Close.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("smtptest: failed to listen: " + err.Error())
+	}
+	s := &Server{Addr: listener.Addr().String(), listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Host возвращает хост сервера.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Addr)
+	return host
+}
+
+// Port возвращает порт сервера.
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.Addr)
+	return port
+}
+
+// Messages возвращает принятые письма в порядке получения.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Fail включает временный отказ: пока он включен, сервер отвечает 451 на MAIL.
+func (s *Server) Fail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+// Close останавливает сервер и дожидается завершения открытых сессий.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(textproto.NewConn(conn))
+		}()
+	}
+}
+
+// session обслуживает одно соединение.
+func (s *Server) session(conn *textproto.Conn) {
+	var message Message
+	reply := func(format string, args ...interface{}) bool {
+		return conn.PrintfLine(format, args...) == nil
+	}
+	if !reply("220 smtptest ESMTP") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		ok := true
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			ok = reply("250-smtptest") && reply("250-8BITMIME") && reply("250 AUTH PLAIN")
+		case "HELO", "NOOP":
+			ok = reply("250 OK")
+		case "AUTH":
+			message.Username, ok = s.auth(conn, arg)
+			if ok {
+				ok = reply("235 Authentication successful")
+			}
+		case "MAIL":
+			s.mu.Lock()
+			fail := s.fail
+			s.mu.Unlock()
+			if fail {
+				ok = reply("451 Temporary failure")
+				break
+			}
+			message = Message{Username: message.Username, From: address(arg)}
+			ok = reply("250 OK")
+		case "RCPT":
+			message.To = append(message.To, address(arg))
+			ok = reply("250 OK")
+		case "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			if message.Data, err = conn.ReadDotBytes(); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			message = Message{Username: message.Username}
+			ok = reply("250 OK")
+		case "RSET":
+			message = Message{Username: message.Username}
+			ok = reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			ok = reply("502 Command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// auth принимает AUTH PLAIN с любым паролем и возвращает имя пользователя.
+// При ошибке сессия завершается.
+func (s *Server) auth(conn *textproto.Conn, arg string) (string, bool) {
+	mechanism, response, _ := strings.Cut(arg, " ")
+	if !strings.EqualFold(mechanism, "PLAIN") {
+		conn.PrintfLine("504 Unrecognized authentication type")
+		return "", false
+	}
+	if response == "" {
+		if conn.PrintfLine("334 ") != nil {
+			return "", false
+		}
+		var err error
+		if response, err = conn.ReadLine(); err != nil {
+			return "", false
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(response)
+	fields := strings.Split(string(decoded), "\x00")
+	if err != nil || len(fields) != 3 {
+		conn.PrintfLine("501 Malformed credentials")
+		return "", false
+	}
+	return fields[1], true
+}
+
+// address извлекает адрес из аргумента MAIL FROM:<...> или RCPT TO:<...>.
+func address(arg string) string {
+	start, end := strings.Index(arg, "<"), strings.LastIndex(arg, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
+
+// Parse разбирает письмо с текстовой и HTML-частью.
+func (m Message) Parse() (Mail, error) {
+	msg, err := netmail.ReadMessage(bytes.NewReader(m.Data))
+	if err != nil {
+		return Mail{}, err
+	}
+	mail := Mail{Header: msg.Header}
+	if mail.Subject, err = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); err != nil {
+		return Mail{}, err
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return Mail{}, err
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			return mail, nil
+		}
+		if err != nil {
+			return Mail{}, err
+		}
+		var content io.Reader = part
+		if part.Header.Get("Content-Transfer-Encoding") == "quoted-printable" {
+			content = quotedprintable.NewReader(part)
+		}
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return Mail{}, err
+		}
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/plain":
+			mail.Text = string(data)
+		case "text/html":
+			mail.HTML = string(data)
+		}
+	}
+}
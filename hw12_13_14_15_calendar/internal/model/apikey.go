@@ -0,0 +1,28 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAPIKeyNotFound ключ API с указанным идентификатором или хешем не существует.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey ключ API сервисного клиента. Сам ключ не хранится, только его хеш.
+type APIKey struct {
+	ID string
+	// Name описание клиента, которому выдан ключ.
+	Name string
+	// Hash хеш SHA-256 ключа в шестнадцатеричном виде.
+	Hash string
+	// Scopes области доступа ключа, например events:read.
+	Scopes    []string
+	CreatedAt time.Time
+	// RevokedAt время отзыва ключа, нулевое значение - ключ действует.
+	RevokedAt time.Time
+}
+
+// Revoked сообщает, отозван ли ключ.
+func (key APIKey) Revoked() bool {
+	return !key.RevokedAt.IsZero()
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAttendeeNotFound пользователь не приглашен на событие.
+var ErrAttendeeNotFound = errors.New("attendee not found")
+
+// AttendeeStatus ответ участника на приглашение.
+type AttendeeStatus string
+
+const (
+	// StatusNeedsAction участник еще не ответил на приглашение.
+	StatusNeedsAction AttendeeStatus = "needs-action"
+	StatusAccepted    AttendeeStatus = "accepted"
+	StatusDeclined    AttendeeStatus = "declined"
+	StatusTentative   AttendeeStatus = "tentative"
+)
+
+// ResponseStatuses статусы, которыми участник может ответить на приглашение.
+var ResponseStatuses = []AttendeeStatus{StatusAccepted, StatusDeclined, StatusTentative}
+
+// IsResponse проверяет, что статус - ответ на приглашение.
+func (s AttendeeStatus) IsResponse() bool {
+	for _, status := range ResponseStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Attendee участник события.
+type Attendee struct {
+	EventID string         `json:"eventId"`
+	UserID  string         `json:"userId"`
+	Status  AttendeeStatus `json:"status"`
+}
+
+// InvitationMessage тип сообщения брокера с приглашением на событие.
+const InvitationMessage = "invitation"
+
+// Invitation приглашение участника на событие, публикуемое в брокер сообщений.
+type Invitation struct {
+	// Type всегда InvitationMessage, отличает приглашения от уведомлений о событиях в общей очереди.
+	Type        string    `json:"type"`
+	EventID     string    `json:"eventId"`
+	Title       string    `json:"title"`
+	Beginning   time.Time `json:"beginning"`
+	Finish      time.Time `json:"finish"`
+	OrganizerID string    `json:"organizerId"`
+	AttendeeID  string    `json:"attendeeId"`
+}
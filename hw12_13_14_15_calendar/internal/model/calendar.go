@@ -0,0 +1,105 @@
+package model
+
+import "errors"
+
+var (
+	// ErrCalendarNotFound календарь с указанным идентификатором не существует.
+	ErrCalendarNotFound = errors.New("calendar not found")
+	// ErrAccessNotFound у пользователя нет записи доступа к календарю.
+	ErrAccessNotFound = errors.New("access entry not found")
+)
+
+// Role уровень доступа к календарю. Каждая роль включает права предыдущих.
+type Role string
+
+const (
+	// RoleRead просмотр календаря и его событий.
+	RoleRead Role = "read"
+	// RoleWrite создание, изменение и удаление событий календаря.
+	RoleWrite Role = "write"
+	// RoleOwner изменение и удаление календаря и управление доступом к нему.
+	RoleOwner Role = "owner"
+)
+
+// Roles все роли в порядке возрастания прав.
+var Roles = []Role{RoleRead, RoleWrite, RoleOwner}
+
+// Allows проверяет, что роль включает права роли required. Пустая роль не дает прав.
+func (r Role) Allows(required Role) bool {
+	return r.rank() > 0 && r.rank() >= required.rank()
+}
+
+// rank возвращает порядковый номер роли, 0 для неизвестной роли.
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if role == r {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ACLEntry запись доступа пользователя к календарю.
+type ACLEntry struct {
+	CalendarID string `json:"calendarId"`
+	UserID     string `json:"userId"`
+	Role       Role   `json:"role"`
+}
+
+// Calendar именованный календарь пользователя, которым можно поделиться с другими пользователями.
+type Calendar struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	OwnerID string `json:"ownerId"`
+	// TimeZone часовой пояс IANA, например Europe/Moscow.
+	TimeZone string `json:"timeZone"`
+	// Color цвет календаря в формате #rrggbb, пустой - цвет по умолчанию.
+	Color string `json:"color,omitempty"`
+	// ACL доступ других пользователей. Владельцу доступ не выдается, его роль всегда RoleOwner.
+	ACL []ACLEntry `json:"acl,omitempty"`
+}
+
+// RoleOf возвращает роль пользователя в календаре или пустую роль, если доступа нет.
+func (c Calendar) RoleOf(userID string) Role {
+	if userID == c.OwnerID {
+		return RoleOwner
+	}
+	for _, entry := range c.ACL {
+		if entry.UserID == userID {
+			return entry.Role
+		}
+	}
+	return ""
+}
+
+// Visibility ограничивает события теми, что доступны пользователю: его собственными,
+// событиями календарей, к которым у него есть доступ, и событиями, в которых он участвует.
+type Visibility struct {
+	// ReaderID пользователь, пустое значение снимает ограничение.
+	ReaderID string
+	// CalendarIDs календари, доступные ReaderID.
+	CalendarIDs []string
+	// EventIDs события, в которых участвует ReaderID.
+	EventIDs []string
+}
+
+// Allows проверяет, доступно ли событие пользователю.
+func (v Visibility) Allows(event Event) bool {
+	if v.ReaderID == "" || event.UserID == v.ReaderID {
+		return true
+	}
+	return contains(v.CalendarIDs, event.CalendarID) || contains(v.EventIDs, event.ID)
+}
+
+// contains проверяет, что непустое значение value есть в values.
+func contains(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
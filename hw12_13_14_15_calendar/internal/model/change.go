@@ -0,0 +1,21 @@
+package model
+
+// ChangeType тип изменения события.
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+	ChangeDeleted ChangeType = "deleted"
+	// ChangeReset изменения после запрошенного при возобновлении подписки уже недоступны,
+	// клиенту нужно заново прочитать события.
+	ChangeReset ChangeType = "reset"
+)
+
+// EventChange изменение события. Для удаленного события Event содержит его последнее известное состояние.
+type EventChange struct {
+	// ID идентификатор изменения в ленте, по которому можно возобновить подписку.
+	ID    string     `json:"id"`
+	Type  ChangeType `json:"type"`
+	Event Event      `json:"event"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ConflictHorizon период от начала более позднего события, в пределах которого
+// ищутся пересечения повторяющихся событий.
+const ConflictHorizon = 365 * 24 * time.Hour
+
+// ErrDateBusy время события пересекается с другим событием того же пользователя.
+var ErrDateBusy = errors.New("date busy")
+
+// Overlaps проверяет, пересекается ли событие (или одно из его повторений) с другим событием.
+// Границы не считаются пересечением: событие может начаться в момент окончания другого.
+func (event *Event) Overlaps(other *Event) (bool, error) {
+	if event.Recurrence == "" && other.Recurrence == "" {
+		return intersects(*event, *other), nil
+	}
+
+	// До начала более позднего события пересечений нет, поэтому окно начинается с него. Повторение
+	// другой серии, начавшееся раньше, может еще длиться, поэтому окно сдвигается на длительность.
+	from := event.Beginning
+	if other.Beginning.After(from) {
+		from = other.Beginning
+	}
+	to := from.Add(ConflictHorizon)
+	duration := event.Finish.Sub(event.Beginning)
+	if otherDuration := other.Finish.Sub(other.Beginning); otherDuration > duration {
+		duration = otherDuration
+	}
+	from = from.Add(-duration)
+
+	occurrences, err := event.Occurrences(from, to)
+	if err != nil {
+		return false, err
+	}
+	otherOccurrences, err := other.Occurrences(from, to)
+	if err != nil {
+		return false, err
+	}
+
+	// Повторения упорядочены по началу и имеют одинаковую длительность, поэтому
+	// достаточно одного прохода, сдвигая ту серию, повторение которой заканчивается раньше.
+	for i, j := 0, 0; i < len(occurrences) && j < len(otherOccurrences); {
+		if intersects(occurrences[i], otherOccurrences[j]) {
+			return true, nil
+		}
+		if occurrences[i].Finish.Before(otherOccurrences[j].Finish) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return false, nil
+}
+
+func intersects(a, b Event) bool {
+	return a.Beginning.Before(b.Finish) && b.Beginning.Before(a.Finish)
+}
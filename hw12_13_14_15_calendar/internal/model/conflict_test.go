@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventOverlaps(t *testing.T) {
+	// Понедельник.
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := func(beginning time.Time, duration time.Duration, recurrence string) *Event {
+		return &Event{Beginning: beginning, Finish: beginning.Add(duration), Recurrence: recurrence}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     *Event
+		overlaps bool
+	}{
+		{"same time", event(start, time.Hour, ""), event(start, time.Hour, ""), true},
+		{"partial", event(start, time.Hour, ""), event(start.Add(30*time.Minute), time.Hour, ""), true},
+		{"adjacent", event(start, time.Hour, ""), event(start.Add(time.Hour), time.Hour, ""), false},
+		{"zero length", event(start, 0, ""), event(start, time.Hour, ""), false},
+		{
+			"series hits single",
+			event(start, time.Hour, "FREQ=WEEKLY"), event(start.AddDate(0, 0, 14), time.Hour, ""), true,
+		},
+		{
+			"series misses single",
+			event(start, time.Hour, "FREQ=WEEKLY"), event(start.AddDate(0, 0, 15), time.Hour, ""), false,
+		},
+		{
+			"series ended before single",
+			event(start, time.Hour, "FREQ=WEEKLY;COUNT=2"), event(start.AddDate(0, 0, 14), time.Hour, ""), false,
+		},
+		{
+			"old series hits single",
+			event(time.Date(2020, time.January, 6, 10, 0, 0, 0, time.UTC), time.Hour, "FREQ=WEEKLY"),
+			event(start.Add(30*time.Minute), time.Hour, ""),
+			true,
+		},
+		{
+			"old series still running",
+			event(time.Date(2020, time.January, 6, 10, 0, 0, 0, time.UTC), 3*time.Hour, "FREQ=WEEKLY"),
+			event(start.Add(2*time.Hour), time.Hour, ""),
+			true,
+		},
+		{
+			"two series meet later",
+			event(start, time.Hour, "FREQ=WEEKLY;BYDAY=MO"),
+			event(start.AddDate(0, 0, 1), time.Hour, "FREQ=DAILY;INTERVAL=3"),
+			true,
+		},
+		{
+			"two series never meet",
+			event(start, time.Hour, "FREQ=WEEKLY;BYDAY=MO"),
+			event(start.AddDate(0, 0, 1), time.Hour, "FREQ=WEEKLY;BYDAY=TU,WE"),
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			overlaps, err := tc.a.Overlaps(tc.b)
+			require.NoError(t, err)
+			require.Equal(t, tc.overlaps, overlaps)
+
+			overlaps, err = tc.b.Overlaps(tc.a)
+			require.NoError(t, err)
+			require.Equal(t, tc.overlaps, overlaps)
+		})
+	}
+}
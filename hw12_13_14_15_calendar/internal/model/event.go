@@ -1,9 +1,13 @@
 package model
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrEventNotFound событие с указанным идентификатором не существует.
+var ErrEventNotFound = errors.New("event not found")
+
 // IEvent интерфейс для структуры Event, предоставляющий методы доступа к полям.
 type IEvent interface {
 	GetID() string
@@ -13,6 +17,11 @@ type IEvent interface {
 	GetFinish() time.Time
 	GetNotification() time.Time
 	GetUserID() string
+	GetRecurrence() string
+	GetExDates() []time.Time
+	GetCalendarID() string
+	GetTimeZone() string
+	GetReminders() []Reminder
 }
 
 // Event структура, представляющая событие.
@@ -24,6 +33,21 @@ type Event struct {
 	Finish       time.Time `json:"finish"`
 	Notification time.Time `json:"notification"`
 	UserID       string    `json:"userId"`
+	// Recurrence правило повторения RFC 5545 (RRULE), пустое для однократного события.
+	Recurrence string `json:"recurrence,omitempty"`
+	// ExDates даты начала повторений, исключенных из серии (EXDATE).
+	ExDates []time.Time `json:"exDates,omitempty"`
+	// CalendarID календарь события, пустой - личное событие, доступное только владельцу.
+	CalendarID string `json:"calendarId,omitempty"`
+	// TimeZone часовой пояс IANA, в котором разворачиваются повторения, пустой - UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// UID идентификатор события iCalendar, из которого оно загружено, пустой - событие создано в календаре.
+	UID string `json:"uid,omitempty"`
+	// Reminders напоминания о каждом повторении события.
+	Reminders []Reminder `json:"reminders,omitempty"`
+	// Notified время, до которого включительно уведомления о повторениях события отправлены или пропущены.
+	// Когда меняются время или повторение события, заменяется на InitialNotified.
+	Notified time.Time `json:"-"`
 }
 
 // GetID возвращает ID события.
@@ -60,3 +84,33 @@ func (event *Event) GetNotification() time.Time {
 func (event *Event) GetUserID() string {
 	return event.UserID
 }
+
+// GetRecurrence возвращает правило повторения события.
+func (event *Event) GetRecurrence() string {
+	return event.Recurrence
+}
+
+// GetExDates возвращает исключенные из серии повторения.
+func (event *Event) GetExDates() []time.Time {
+	return event.ExDates
+}
+
+// GetCalendarID возвращает ID календаря события.
+func (event *Event) GetCalendarID() string {
+	return event.CalendarID
+}
+
+// GetTimeZone возвращает часовой пояс события.
+func (event *Event) GetTimeZone() string {
+	return event.TimeZone
+}
+
+// GetReminders возвращает напоминания события.
+func (event *Event) GetReminders() []Reminder {
+	return event.Reminders
+}
+
+// Location возвращает часовой пояс события, UTC для события без часового пояса.
+func (event *Event) Location() (*time.Location, error) {
+	return LoadLocation(event.TimeZone)
+}
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultSlotLimit число предлагаемых слотов, если оно не указано в запросе.
+	DefaultSlotLimit = 5
+	// MaxSlotLimit максимальное число предлагаемых слотов.
+	MaxSlotLimit = 50
+	// MaxFreeBusyUsers максимальное число пользователей в запросе занятости.
+	MaxFreeBusyUsers = 50
+	// MaxFreeBusyWindow максимальная длина интервала запроса занятости.
+	MaxFreeBusyWindow = 62 * 24 * time.Hour
+)
+
+var (
+	// ErrWorkingHoursNotFound у пользователя не задано рабочее время.
+	ErrWorkingHoursNotFound = errors.New("working hours not found")
+	// ErrInvalidClock время суток не в формате ЧЧ:ММ.
+	ErrInvalidClock = errors.New("invalid time of day")
+)
+
+// Interval полуоткрытый интервал времени [Start, End).
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// BusyInterval интервал, в который пользователь занят событием.
+type BusyInterval struct {
+	UserID string
+	Interval
+}
+
+// WorkingHours рабочее время пользователя. Пользователь без рабочего времени доступен в любое время.
+type WorkingHours struct {
+	UserID string `json:"userId"`
+	// TimeZone часовой пояс IANA, в котором заданы Start и End.
+	TimeZone string `json:"timeZone"`
+	// Start и End начало и конец рабочего дня в формате ЧЧ:ММ, End позже Start.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Days рабочие дни недели по ISO 8601: 1 - понедельник, 7 - воскресенье.
+	Days []int `json:"days"`
+}
+
+// Intervals возвращает рабочие интервалы, пересекающиеся с [from, to), обрезанные по его границам.
+// Начало и конец рабочего дня вычисляются в часовом поясе TimeZone для каждого дня отдельно,
+// поэтому при переходе на летнее время рабочий день сохраняет местные часы.
+func (w WorkingHours) Intervals(from, to time.Time) ([]Interval, error) {
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	start, err := ParseClock(w.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseClock(w.End)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]Interval, 0)
+	first := from.In(loc)
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !w.worksOn(day.Weekday()) {
+			continue
+		}
+		// Прибавление смещения к полуночи ошибалось бы на час в дни перехода на летнее время.
+		interval := Interval{
+			Start: time.Date(day.Year(), day.Month(), day.Day(), 0, int(start/time.Minute), 0, 0, loc),
+			End:   time.Date(day.Year(), day.Month(), day.Day(), 0, int(end/time.Minute), 0, 0, loc),
+		}
+		if clipped, ok := clip(interval, from, to); ok {
+			intervals = append(intervals, clipped)
+		}
+	}
+	return intervals, nil
+}
+
+// worksOn проверяет, что день недели рабочий.
+func (w WorkingHours) worksOn(weekday time.Weekday) bool {
+	iso := int(weekday)
+	if weekday == time.Sunday {
+		iso = 7
+	}
+	for _, day := range w.Days {
+		if day == iso {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock разбирает время суток в формате ЧЧ:ММ (от 00:00 до 24:00) и возвращает смещение от полуночи.
+func ParseClock(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	// Parse допускает час из одной цифры.
+	if err != nil || len(value) != len("15:04") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidClock, value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// FreeBusyQuery запрос занятости пользователей и свободных слотов для встречи.
+type FreeBusyQuery struct {
+	UserIDs []string
+	// From и To интервал [From, To), в котором ищутся занятость и слоты.
+	From time.Time
+	To   time.Time
+	// Duration длительность встречи.
+	Duration time.Duration
+	// Limit максимальное число слотов.
+	Limit int
+}
+
+// UserBusy занятость пользователя: объединенные интервалы его событий в порядке начала.
+type UserBusy struct {
+	UserID string     `json:"userId"`
+	Busy   []Interval `json:"busy"`
+}
+
+// FreeBusy занятость пользователей в порядке запроса и первые слоты, в которые свободны все.
+type FreeBusy struct {
+	Users []UserBusy `json:"users"`
+	Slots []Interval `json:"slots"`
+}
+
+// BusyIntervals возвращает интервалы события и его повторений, пересекающиеся с [from, to),
+// обрезанные по границам интервала.
+func (event *Event) BusyIntervals(from, to time.Time) ([]Interval, error) {
+	// Повторение, начавшееся раньше from не более чем на длительность события, еще идет в момент from.
+	occurrences, err := event.Occurrences(from.Add(-event.Finish.Sub(event.Beginning)), to)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]Interval, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		if clipped, ok := clip(Interval{Start: occurrence.Beginning, End: occurrence.Finish}, from, to); ok {
+			intervals = append(intervals, clipped)
+		}
+	}
+	return intervals, nil
+}
+
+// MergeIntervals сортирует интервалы по началу и объединяет пересекающиеся и соприкасающиеся.
+func MergeIntervals(intervals []Interval) []Interval {
+	sorted := append([]Interval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := make([]Interval, 0, len(sorted))
+	for _, interval := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !interval.Start.After(merged[last].End) {
+			if interval.End.After(merged[last].End) {
+				merged[last].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// Subtract возвращает части интервалов available, не занятые интервалами busy.
+// Оба списка должны быть упорядочены по началу и не содержать пересечений.
+func Subtract(available, busy []Interval) []Interval {
+	free := make([]Interval, 0, len(available))
+	j := 0
+	for _, interval := range available {
+		start := interval.Start
+		for j < len(busy) && !busy[j].End.After(start) {
+			j++
+		}
+		for k := j; k < len(busy) && busy[k].Start.Before(interval.End); k++ {
+			if busy[k].Start.After(start) {
+				free = append(free, Interval{Start: start, End: busy[k].Start})
+			}
+			if busy[k].End.After(start) {
+				start = busy[k].End
+			}
+		}
+		if start.Before(interval.End) {
+			free = append(free, Interval{Start: start, End: interval.End})
+		}
+	}
+	return free
+}
+
+// Intersect возвращает пересечение двух упорядоченных списков интервалов без пересечений внутри списка.
+func Intersect(a, b []Interval) []Interval {
+	result := make([]Interval, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].Start, a[i].End
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if start.Before(end) {
+			result = append(result, Interval{Start: start, End: end})
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Slots нарезает свободные интервалы на не более чем limit слотов длительностью duration.
+// Слоты идут подряд от начала каждого свободного интервала.
+func Slots(free []Interval, duration time.Duration, limit int) []Interval {
+	slots := make([]Interval, 0, limit)
+	for _, interval := range free {
+		for start := interval.Start; !start.Add(duration).After(interval.End); start = start.Add(duration) {
+			if len(slots) == limit {
+				return slots
+			}
+			slots = append(slots, Interval{Start: start, End: start.Add(duration)})
+		}
+	}
+	return slots
+}
+
+// clip обрезает интервал по границам [from, to), ok ложно, если пересечения нет.
+func clip(interval Interval, from, to time.Time) (Interval, bool) {
+	if interval.Start.Before(from) {
+		interval.Start = from
+	}
+	if interval.End.After(to) {
+		interval.End = to
+	}
+	return interval, interval.Start.Before(interval.End)
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingHoursIntervals(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("weekdays in time zone", func(t *testing.T) {
+		hours := WorkingHours{TimeZone: "Europe/Moscow", Start: "09:00", End: "18:00", Days: []int{1, 2, 3, 4, 5}}
+		// Пятница 12:00 по Москве - понедельник 12:00 по Москве.
+		from := time.Date(2024, time.June, 7, 9, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.June, 10, 9, 0, 0, 0, time.UTC)
+
+		intervals, err := hours.Intervals(from, to)
+		require.NoError(t, err)
+		require.Equal(t, []Interval{
+			{Start: from, End: time.Date(2024, time.June, 7, 18, 0, 0, 0, moscow)},
+			{Start: time.Date(2024, time.June, 10, 9, 0, 0, 0, moscow), End: to},
+		}, intervals)
+	})
+
+	t.Run("local hours across daylight saving change", func(t *testing.T) {
+		hours := WorkingHours{TimeZone: "Europe/Berlin", Start: "09:00", End: "17:00", Days: []int{7, 1}}
+		from := time.Date(2024, time.March, 30, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.April, 2, 0, 0, 0, 0, time.UTC)
+
+		intervals, err := hours.Intervals(from, to)
+		require.NoError(t, err)
+		require.Len(t, intervals, 2)
+		for i, day := range []int{31, 1} {
+			month := time.March
+			if day == 1 {
+				month = time.April
+			}
+			require.True(t, time.Date(2024, month, day, 9, 0, 0, 0, berlin).Equal(intervals[i].Start))
+			require.True(t, time.Date(2024, month, day, 17, 0, 0, 0, berlin).Equal(intervals[i].End))
+		}
+		require.Equal(t, 7, intervals[0].Start.UTC().Hour())
+	})
+
+	t.Run("invalid clock", func(t *testing.T) {
+		_, err := WorkingHours{TimeZone: "UTC", Start: "9", End: "18:00"}.Intervals(time.Now(), time.Now())
+		require.True(t, errors.Is(err, ErrInvalidClock))
+	})
+}
+
+func TestParseClock(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"00:00": 0,
+		"09:30": 9*time.Hour + 30*time.Minute,
+		"24:00": 24 * time.Hour,
+	} {
+		clock, err := ParseClock(value)
+		require.NoError(t, err)
+		require.Equal(t, expected, clock)
+	}
+	for _, value := range []string{"", "9:30", "24:01", "12:60", "noon"} {
+		_, err := ParseClock(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestEventBusyIntervals(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	daily := Event{Beginning: start, Finish: start.Add(2 * time.Hour), Recurrence: "FREQ=DAILY"}
+
+	// Повторение, начавшееся до начала интервала, обрезается по его границе.
+	from := start.AddDate(0, 0, 1).Add(time.Hour)
+	intervals, err := daily.BusyIntervals(from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, []Interval{
+		{Start: from, End: start.AddDate(0, 0, 1).Add(2 * time.Hour)},
+		{Start: start.AddDate(0, 0, 2), End: from.AddDate(0, 0, 1)},
+	}, intervals)
+}
+
+func TestIntervalOperations(t *testing.T) {
+	base := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return base.Add(time.Duration(hour) * time.Hour)
+	}
+	interval := func(start, end int) Interval {
+		return Interval{Start: at(start), End: at(end)}
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		merged := MergeIntervals([]Interval{interval(5, 6), interval(1, 3), interval(2, 4), interval(4, 5), interval(8, 9)})
+		require.Equal(t, []Interval{interval(1, 6), interval(8, 9)}, merged)
+	})
+
+	t.Run("subtract", func(t *testing.T) {
+		free := Subtract(
+			[]Interval{interval(0, 4), interval(6, 10)},
+			[]Interval{interval(1, 2), interval(3, 7), interval(9, 12)},
+		)
+		require.Equal(t, []Interval{interval(0, 1), interval(2, 3), interval(7, 9)}, free)
+	})
+
+	t.Run("intersect", func(t *testing.T) {
+		common := Intersect(
+			[]Interval{interval(0, 4), interval(6, 10)},
+			[]Interval{interval(2, 7), interval(9, 12)},
+		)
+		require.Equal(t, []Interval{interval(2, 4), interval(6, 7), interval(9, 10)}, common)
+	})
+
+	t.Run("slots", func(t *testing.T) {
+		free := []Interval{interval(0, 1), interval(2, 5), interval(6, 8)}
+		require.Equal(t, []Interval{interval(2, 4), interval(6, 8)}, Slots(free, 2*time.Hour, 5))
+		require.Equal(t, []Interval{interval(0, 1), interval(2, 3)}, Slots(free, time.Hour, 2))
+	})
+}
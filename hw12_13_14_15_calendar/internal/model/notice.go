@@ -0,0 +1,75 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownMessage сообщение брокера неизвестного типа.
+var ErrUnknownMessage = errors.New("unknown message type")
+
+// Notice сообщение пользователю из общей очереди уведомлений: уведомление о событии,
+// напоминание или приглашение, приведенные к общему виду.
+type Notice struct {
+	// Type пустой для уведомления о событии, ReminderMessage или InvitationMessage.
+	Type string `json:"type,omitempty"`
+	// RecipientID пользователь, которому адресовано сообщение.
+	RecipientID string    `json:"recipientId"`
+	EventID     string    `json:"eventId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Beginning   time.Time `json:"beginning"`
+	Finish      time.Time `json:"finish"`
+	// MinutesBefore за сколько минут до начала приходит напоминание.
+	MinutesBefore int `json:"minutesBefore,omitempty"`
+	// Channel канал доставки напоминания.
+	Channel Channel `json:"channel,omitempty"`
+	// OrganizerID организатор события, на которое приглашен получатель.
+	OrganizerID string `json:"organizerId,omitempty"`
+}
+
+// DecodeNotice разбирает сообщение из очереди уведомлений. Уведомления о событиях публикуются
+// в виде Event без поля type, остальные сообщения отличаются по полю type.
+func DecodeNotice(body []byte) (Notice, error) {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Notice{}, err
+	}
+
+	switch envelope.Type {
+	case "":
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			return Notice{}, err
+		}
+		return Notice{
+			RecipientID: event.UserID, EventID: event.ID, Title: event.Title, Description: event.Description,
+			Beginning: event.Beginning, Finish: event.Finish,
+		}, nil
+	case ReminderMessage:
+		var reminder ReminderNotice
+		if err := json.Unmarshal(body, &reminder); err != nil {
+			return Notice{}, err
+		}
+		return Notice{
+			Type: ReminderMessage, RecipientID: reminder.UserID, EventID: reminder.EventID, Title: reminder.Title,
+			Description: reminder.Description, Beginning: reminder.Beginning, Finish: reminder.Finish,
+			MinutesBefore: reminder.MinutesBefore, Channel: reminder.Channel,
+		}, nil
+	case InvitationMessage:
+		var invitation Invitation
+		if err := json.Unmarshal(body, &invitation); err != nil {
+			return Notice{}, err
+		}
+		return Notice{
+			Type: InvitationMessage, RecipientID: invitation.AttendeeID, EventID: invitation.EventID,
+			Title: invitation.Title, Beginning: invitation.Beginning, Finish: invitation.Finish,
+			OrganizerID: invitation.OrganizerID,
+		}, nil
+	}
+	return Notice{}, fmt.Errorf("%w: %q", ErrUnknownMessage, envelope.Type)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeNotice(t *testing.T) {
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	finish := beginning.Add(time.Hour)
+	encode := func(v interface{}) []byte {
+		body, err := json.Marshal(v)
+		require.NoError(t, err)
+		return body
+	}
+
+	t.Run("event notification", func(t *testing.T) {
+		notice, err := DecodeNotice(encode(Event{
+			ID: "1", Title: "Планерка", Description: "Отчеты", Beginning: beginning, Finish: finish,
+			Notification: beginning.Add(-time.Hour), UserID: "2",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Notice{
+			RecipientID: "2", EventID: "1", Title: "Планерка", Description: "Отчеты", Beginning: beginning, Finish: finish,
+		}, notice)
+	})
+
+	t.Run("reminder", func(t *testing.T) {
+		notice, err := DecodeNotice(encode(ReminderNotice{
+			Type: ReminderMessage, ReminderID: "3", EventID: "1", Title: "Планерка", Beginning: beginning, Finish: finish,
+			UserID: "2", MinutesBefore: 15, Channel: ChannelEmail,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Notice{
+			Type: ReminderMessage, RecipientID: "2", EventID: "1", Title: "Планерка", Beginning: beginning, Finish: finish,
+			MinutesBefore: 15, Channel: ChannelEmail,
+		}, notice)
+	})
+
+	t.Run("invitation", func(t *testing.T) {
+		notice, err := DecodeNotice(encode(Invitation{
+			Type: InvitationMessage, EventID: "1", Title: "Планерка", Beginning: beginning, Finish: finish,
+			OrganizerID: "2", AttendeeID: "4",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Notice{
+			Type: InvitationMessage, RecipientID: "4", EventID: "1", Title: "Планерка", Beginning: beginning, Finish: finish,
+			OrganizerID: "2",
+		}, notice)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := DecodeNotice([]byte(`{"type": "digest"}`))
+		require.True(t, errors.Is(err, ErrUnknownMessage))
+		_, err = DecodeNotice([]byte(`not json`))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// DeliveryRetention сколько рассыльщик помнит ключи доставленных сообщений.
+const DeliveryRetention = 7 * 24 * time.Hour
+
+// OutboxMessage сообщение для брокера, сохраненное в одной транзакции с изменением, которое его вызвало.
+// Ретранслятор публикует сообщения outbox и удаляет опубликованные, поэтому сообщение может быть
+// опубликовано повторно, но не теряется.
+type OutboxMessage struct {
+	ID int64
+	// Key ключ идемпотентности: одинаков у всех публикаций одного сообщения, по нему рассыльщик
+	// отбрасывает повторы. Сообщение с ключом, уже находящимся в outbox, не добавляется.
+	Key       string
+	Body      []byte
+	CreatedAt time.Time
+}
+
+// NotificationKey возвращает ключ идемпотентности уведомления о повторении события occurrence.
+func NotificationKey(occurrence Event) string {
+	return "notification:" + occurrence.ID + ":" + strconv.FormatInt(occurrence.Notification.Unix(), 10)
+}
+
+// ReminderKey возвращает ключ идемпотентности напоминания due. Ключ не зависит от идентификатора
+// напоминания, который меняется, когда напоминание планируется заново после изменения события.
+func ReminderKey(due DueReminder) string {
+	return "reminder:" + due.Event.ID + ":" + strconv.Itoa(due.Reminder.MinutesBefore) + ":" +
+		string(due.Reminder.Channel) + ":" + strconv.FormatInt(due.Reminder.Occurrence.Unix(), 10)
+}
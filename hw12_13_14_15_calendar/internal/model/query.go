@@ -0,0 +1,194 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPageSize размер страницы, если он не указан в запросе.
+	DefaultPageSize = 100
+	// MaxPageSize максимальный размер страницы.
+	MaxPageSize = 1000
+)
+
+// Порядок сортировки событий. Префикс "-" означает сортировку по убыванию.
+const (
+	OrderByBeginning     = "beginning"
+	OrderByBeginningDesc = "-beginning"
+	OrderByTitle         = "title"
+	OrderByTitleDesc     = "-title"
+)
+
+// Порядок сортировки пользователей.
+const (
+	OrderByLastName     = "lastName"
+	OrderByLastNameDesc = "-lastName"
+	OrderByEmail        = "email"
+	OrderByEmailDesc    = "-email"
+)
+
+// EventOrders поддерживаемые порядки сортировки событий, первый используется по умолчанию.
+var EventOrders = []string{OrderByBeginning, OrderByBeginningDesc, OrderByTitle, OrderByTitleDesc}
+
+// UserOrders поддерживаемые порядки сортировки пользователей, первый используется по умолчанию.
+var UserOrders = []string{OrderByLastName, OrderByLastNameDesc, OrderByEmail, OrderByEmailDesc}
+
+// EventFilter условия отбора событий. Пустые поля не ограничивают выборку.
+type EventFilter struct {
+	UserID     string
+	CalendarID string
+	// Title подстрока названия без учета регистра.
+	Title string
+	// From и To интервал [From, To), в котором начинается событие. Повторяющиеся события
+	// разворачиваются в повторения из интервала. Границы задаются вместе.
+	From time.Time
+	To   time.Time
+	Visibility
+}
+
+// Match проверяет условия отбора, кроме интервала времени.
+func (f EventFilter) Match(event Event) bool {
+	if f.UserID != "" && event.UserID != f.UserID {
+		return false
+	}
+	if f.CalendarID != "" && event.CalendarID != f.CalendarID {
+		return false
+	}
+	if !f.Allows(event) {
+		return false
+	}
+	return f.Title == "" || strings.Contains(strings.ToLower(event.Title), strings.ToLower(f.Title))
+}
+
+// Period сообщает, задан ли интервал времени.
+func (f EventFilter) Period() bool {
+	return !f.From.IsZero() && !f.To.IsZero()
+}
+
+// PageRequest параметры страницы в запросе клиента.
+type PageRequest struct {
+	// OrderBy порядок сортировки, пустой - порядок по умолчанию.
+	OrderBy string
+	// PageSize размер страницы, 0 - DefaultPageSize.
+	PageSize int
+	// PageToken непрозрачный токен следующей страницы из предыдущего ответа, пустой - первая страница.
+	PageToken string
+}
+
+// EventQuery запрос страницы событий.
+type EventQuery struct {
+	EventFilter
+	// OrderBy один из EventOrders. Одинаковые значения упорядочиваются по началу и идентификатору.
+	OrderBy  string
+	PageSize int
+	// After последнее событие предыдущей страницы, nil - первая страница.
+	// Используются поля сортировки, Beginning и ID.
+	After *Event
+}
+
+// UserQuery запрос страницы пользователей.
+type UserQuery struct {
+	// UserID ограничивает выборку одним пользователем.
+	UserID string
+	// OrderBy один из UserOrders. Одинаковые значения упорядочиваются по идентификатору.
+	OrderBy  string
+	PageSize int
+	// After последний пользователь предыдущей страницы, nil - первая страница.
+	After *User
+}
+
+// CompareEvents сравнивает события в порядке orderBy: отрицательное значение, если a раньше b.
+func CompareEvents(a, b Event, orderBy string) int {
+	c := 0
+	switch strings.TrimPrefix(orderBy, "-") {
+	case OrderByTitle:
+		c = strings.Compare(a.Title, b.Title)
+	}
+	if c == 0 {
+		c = compareTime(a.Beginning, b.Beginning)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if strings.HasPrefix(orderBy, "-") {
+		return -c
+	}
+	return c
+}
+
+// CompareUsers сравнивает пользователей в порядке orderBy: отрицательное значение, если a раньше b.
+func CompareUsers(a, b User, orderBy string) int {
+	c := 0
+	switch strings.TrimPrefix(orderBy, "-") {
+	case OrderByLastName:
+		c = strings.Compare(a.LastName, b.LastName)
+	case OrderByEmail:
+		c = strings.Compare(a.Email, b.Email)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if strings.HasPrefix(orderBy, "-") {
+		return -c
+	}
+	return c
+}
+
+// PageEvents сортирует события в порядке запроса и возвращает страницу после query.After.
+// Второе значение - последнее событие страницы, если за ним есть еще события, иначе nil.
+func PageEvents(events []Event, query EventQuery) ([]Event, *Event) {
+	sort.Slice(events, func(i, j int) bool {
+		return CompareEvents(events[i], events[j], query.OrderBy) < 0
+	})
+
+	start := 0
+	if query.After != nil {
+		start = sort.Search(len(events), func(i int) bool {
+			return CompareEvents(events[i], *query.After, query.OrderBy) > 0
+		})
+	}
+	events = events[start:]
+
+	if len(events) <= query.PageSize {
+		return events, nil
+	}
+	page := events[:query.PageSize]
+	last := page[len(page)-1]
+	return page, &last
+}
+
+// PageUsers сортирует пользователей в порядке запроса и возвращает страницу после query.After.
+// Второе значение - последний пользователь страницы, если за ним есть еще пользователи, иначе nil.
+func PageUsers(users []User, query UserQuery) ([]User, *User) {
+	sort.Slice(users, func(i, j int) bool {
+		return CompareUsers(users[i], users[j], query.OrderBy) < 0
+	})
+
+	start := 0
+	if query.After != nil {
+		start = sort.Search(len(users), func(i int) bool {
+			return CompareUsers(users[i], *query.After, query.OrderBy) > 0
+		})
+	}
+	users = users[start:]
+
+	if len(users) <= query.PageSize {
+		return users, nil
+	}
+	page := users[:query.PageSize]
+	last := page[len(page)-1]
+	return page, &last
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
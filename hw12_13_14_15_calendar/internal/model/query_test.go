@@ -0,0 +1,117 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageEvents(t *testing.T) {
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	events := []Event{
+		{ID: "c", Title: "Обед", Beginning: day.Add(12 * time.Hour)},
+		{ID: "a", Title: "Планерка", Beginning: day.Add(10 * time.Hour)},
+		{ID: "b", Title: "Обед", Beginning: day.Add(10 * time.Hour)},
+		{ID: "d", Title: "Ретро", Beginning: day.Add(9 * time.Hour)},
+	}
+	ids := func(events []Event) []string {
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.ID)
+		}
+		return result
+	}
+
+	for orderBy, expected := range map[string][]string{
+		OrderByBeginning:     {"d", "a", "b", "c"},
+		OrderByBeginningDesc: {"c", "b", "a", "d"},
+		OrderByTitle:         {"b", "c", "a", "d"},
+		OrderByTitleDesc:     {"d", "a", "c", "b"},
+	} {
+		query := EventQuery{OrderBy: orderBy, PageSize: 3}
+		page, next := PageEvents(events, query)
+		require.Equal(t, expected[:3], ids(page), orderBy)
+		require.NotNil(t, next, orderBy)
+		require.Equal(t, expected[2], next.ID, orderBy)
+
+		query.After = &Event{ID: next.ID, Title: next.Title, Beginning: next.Beginning}
+		page, next = PageEvents(events, query)
+		require.Equal(t, expected[3:], ids(page), orderBy)
+		require.Nil(t, next, orderBy)
+	}
+
+	t.Run("page of exact size has no next", func(t *testing.T) {
+		page, next := PageEvents(events, EventQuery{OrderBy: OrderByBeginning, PageSize: len(events)})
+		require.Len(t, page, len(events))
+		require.Nil(t, next)
+	})
+}
+
+func TestPageUsers(t *testing.T) {
+	users := []User{
+		{ID: "1", LastName: "Петров", Email: "b@example.com"},
+		{ID: "2", LastName: "Иванов", Email: "c@example.com"},
+		{ID: "3", LastName: "Иванов", Email: "a@example.com"},
+	}
+
+	page, next := PageUsers(users, UserQuery{OrderBy: OrderByLastName, PageSize: 2})
+	require.Equal(t, []string{"2", "3"}, []string{page[0].ID, page[1].ID})
+	require.Equal(t, "3", next.ID)
+
+	after := &User{ID: "1", Email: "b@example.com"}
+	page, next = PageUsers(users, UserQuery{OrderBy: OrderByEmailDesc, PageSize: 2, After: after})
+	require.Equal(t, "3", page[0].ID)
+	require.Len(t, page, 1)
+	require.Nil(t, next)
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	event := Event{UserID: "alice", Title: "Планерка команды"}
+	require.True(t, EventFilter{}.Match(event))
+	require.True(t, EventFilter{UserID: "alice", Title: "ПЛАНЕРКА"}.Match(event))
+	require.False(t, EventFilter{UserID: "bob"}.Match(event))
+	require.False(t, EventFilter{Title: "ретро"}.Match(event))
+}
+
+func TestVisibilityAllows(t *testing.T) {
+	own := Event{UserID: "alice"}
+	shared := Event{UserID: "bob", CalendarID: "team"}
+	private := Event{UserID: "bob"}
+
+	require.True(t, Visibility{}.Allows(private))
+
+	visibility := Visibility{ReaderID: "alice", CalendarIDs: []string{"team"}}
+	require.True(t, visibility.Allows(own))
+	require.True(t, visibility.Allows(shared))
+	require.False(t, visibility.Allows(private))
+	require.False(t, Visibility{ReaderID: "alice"}.Allows(shared))
+	require.True(t, Visibility{ReaderID: "alice", EventIDs: []string{"standup"}}.Allows(Event{ID: "standup"}))
+	require.False(t, Visibility{ReaderID: "alice", EventIDs: []string{"standup"}}.Allows(Event{ID: "retro"}))
+
+	require.False(t, EventFilter{Visibility: Visibility{ReaderID: "alice"}}.Match(shared))
+	require.True(t, EventFilter{CalendarID: "team", Visibility: visibility}.Match(shared))
+	require.False(t, EventFilter{CalendarID: "team"}.Match(own))
+}
+
+func TestRoleAllows(t *testing.T) {
+	require.True(t, RoleOwner.Allows(RoleWrite))
+	require.True(t, RoleWrite.Allows(RoleRead))
+	require.True(t, RoleRead.Allows(RoleRead))
+	require.False(t, RoleRead.Allows(RoleWrite))
+	require.False(t, Role("").Allows(RoleRead))
+	require.False(t, Role("admin").Allows(RoleRead))
+
+	calendar := Calendar{OwnerID: "alice", ACL: []ACLEntry{{UserID: "bob", Role: RoleWrite}}}
+	require.Equal(t, RoleOwner, calendar.RoleOf("alice"))
+	require.Equal(t, RoleWrite, calendar.RoleOf("bob"))
+	require.Equal(t, Role(""), calendar.RoleOf("carol"))
+}
+
+func TestAttendeeStatusIsResponse(t *testing.T) {
+	require.True(t, StatusAccepted.IsResponse())
+	require.True(t, StatusTentative.IsResponse())
+	require.True(t, StatusDeclined.IsResponse())
+	require.False(t, StatusNeedsAction.IsResponse())
+	require.False(t, AttendeeStatus("maybe").IsResponse())
+}
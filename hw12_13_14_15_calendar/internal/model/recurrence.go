@@ -0,0 +1,533 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Частоты повторения RFC 5545.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// NotificationHorizon период, в пределах которого NextNotification ищет следующее уведомление серии.
+const NotificationHorizon = 10 * 365 * 24 * time.Hour
+
+var (
+	ErrInvalidRecurrence     = errors.New("invalid recurrence rule")
+	ErrUnsupportedRecurrence = errors.New("unsupported recurrence rule")
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// WeekdayNum день недели из BYDAY, N - порядковый номер в месяце (0 - любой, -1 - последний).
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RecurrenceRule разобранное правило повторения RRULE.
+type RecurrenceRule struct {
+	Freq      string
+	Interval  int
+	ByDay     []WeekdayNum
+	Count     int
+	Until     time.Time
+	WeekStart time.Weekday
+}
+
+// ParseRecurrence разбирает правило повторения в формате RFC 5545, например
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10". Для пустой строки возвращает nil.
+func ParseRecurrence(rule string) (*RecurrenceRule, error) {
+	rule = strings.TrimSpace(rule)
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == "" {
+		return nil, nil
+	}
+
+	r := &RecurrenceRule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "UNTIL":
+			r.Until, err = parseRecurrenceTime(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "WKST":
+			day, ok := weekdays[strings.ToUpper(value)]
+			if !ok {
+				err = fmt.Errorf("unknown weekday %q", value)
+			}
+			r.WeekStart = day
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRecurrence, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRecurrence, key, err)
+		}
+	}
+
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	default:
+		return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRecurrence, r.Freq)
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrence)
+	}
+
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FreqMonthly {
+			return nil, fmt.Errorf("%w: numbered BYDAY with FREQ=%s", ErrUnsupportedRecurrence, r.Freq)
+		}
+	}
+	if len(r.ByDay) > 0 && r.Freq == FreqYearly {
+		return nil, fmt.Errorf("%w: BYDAY with FREQ=%s", ErrUnsupportedRecurrence, r.Freq)
+	}
+
+	return r, nil
+}
+
+// String возвращает правило в формате RFC 5545.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			prefix := ""
+			if day.N != 0 {
+				prefix = strconv.Itoa(day.N)
+			}
+			days = append(days, prefix+weekdayCode(day.Weekday))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between возвращает начала повторений серии, начинающейся в start, попадающие в интервал [from, to).
+// Повторения, совпадающие с exDates, исключаются, но учитываются в COUNT.
+func (r *RecurrenceRule) Between(start, from, to time.Time, exDates []time.Time) []time.Time {
+	result := make([]time.Time, 0)
+	r.each(start, to, exDates, func(candidate time.Time) bool {
+		if !candidate.Before(from) {
+			result = append(result, candidate)
+		}
+		return true
+	})
+	return result
+}
+
+// Next возвращает начало первого повторения серии, начинающейся в start, не раньше from и раньше to.
+func (r *RecurrenceRule) Next(start, from, to time.Time, exDates []time.Time) (time.Time, bool) {
+	var next time.Time
+	r.each(start, to, exDates, func(candidate time.Time) bool {
+		if candidate.Before(from) {
+			return true
+		}
+		next = candidate
+		return false
+	})
+	return next, !next.IsZero()
+}
+
+// each передает fn начала повторений серии раньше to по порядку, пока fn возвращает true.
+func (r *RecurrenceRule) each(start, to time.Time, exDates []time.Time, fn func(time.Time) bool) {
+	count := 0
+
+	for period := 0; ; period++ {
+		periodStart, candidates := r.period(start, period)
+		if !periodStart.Before(to) {
+			return
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if !candidate.Before(to) {
+				return
+			}
+			if !containsTime(exDates, candidate) && !fn(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// period возвращает начало n-го периода правила и отсортированные кандидаты на повторение в нем.
+func (r *RecurrenceRule) period(start time.Time, n int) (time.Time, []time.Time) {
+	loc := start.Location()
+	hour, minute, sec := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, start.Nanosecond(), loc)
+	}
+	step := n * r.Interval
+	candidates := make([]time.Time, 0)
+
+	switch r.Freq {
+	case FreqDaily:
+		day := time.Date(start.Year(), start.Month(), start.Day()+step, 0, 0, 0, 0, loc)
+		if r.matchesWeekday(day.Weekday()) {
+			candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+		}
+		return day, candidates
+
+	case FreqWeekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := time.Date(start.Year(), start.Month(), start.Day()-offset+7*step, 0, 0, 0, 0, loc)
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			matches := day.Weekday() == start.Weekday()
+			if len(r.ByDay) > 0 {
+				matches = r.matchesWeekday(day.Weekday())
+			}
+			if matches {
+				candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+			}
+		}
+		return weekStart, candidates
+
+	case FreqMonthly:
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		if len(r.ByDay) == 0 {
+			if candidate := at(monthStart.Year(), monthStart.Month(), start.Day()); candidate.Month() == monthStart.Month() {
+				candidates = append(candidates, candidate)
+			}
+			return monthStart, candidates
+		}
+		for _, day := range monthDays(monthStart, r.ByDay) {
+			candidates = append(candidates, at(monthStart.Year(), monthStart.Month(), day))
+		}
+		return monthStart, candidates
+
+	default:
+		yearStart := time.Date(start.Year()+step, time.January, 1, 0, 0, 0, 0, loc)
+		if candidate := at(yearStart.Year(), start.Month(), start.Day()); candidate.Month() == start.Month() {
+			candidates = append(candidates, candidate)
+		}
+		return yearStart, candidates
+	}
+}
+
+// matchesWeekday проверяет, входит ли день недели в BYDAY. Пустой BYDAY допускает любой день.
+func (r *RecurrenceRule) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays возвращает отсортированные номера дней месяца, подходящих под BYDAY.
+func monthDays(monthStart time.Time, byDay []WeekdayNum) []int {
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	set := make(map[int]struct{})
+
+	for _, wd := range byDay {
+		matches := make([]int, 0, 5)
+		for day := 1; day <= daysInMonth; day++ {
+			if time.Date(monthStart.Year(), monthStart.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() == wd.Weekday {
+				matches = append(matches, day)
+			}
+		}
+
+		switch {
+		case wd.N == 0:
+			for _, day := range matches {
+				set[day] = struct{}{}
+			}
+		case wd.N > 0 && wd.N <= len(matches):
+			set[matches[wd.N-1]] = struct{}{}
+		case wd.N < 0 && -wd.N <= len(matches):
+			set[matches[len(matches)+wd.N]] = struct{}{}
+		}
+	}
+
+	days := make([]int, 0, len(set))
+	for day := range set {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Occurrences возвращает повторения события, начинающиеся в интервале [from, to).
+// Для события без правила повторения возвращается само событие, если оно попадает в интервал.
+// Повторения события с часовым поясом сохраняют местное время начала при переходе на летнее время,
+// у события без часового пояса повторения разворачиваются в часовом поясе его времени начала.
+func (event *Event) Occurrences(from, to time.Time) ([]Event, error) {
+	rule, err := ParseRecurrence(event.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule == nil {
+		if event.Beginning.Before(from) || !event.Beginning.Before(to) {
+			return []Event{}, nil
+		}
+		return []Event{*event}, nil
+	}
+
+	start, err := event.seriesStart()
+	if err != nil {
+		return nil, err
+	}
+
+	starts := rule.Between(start, from, to, event.ExDates)
+	occurrences := make([]Event, 0, len(starts))
+	for _, start := range starts {
+		shift := start.Sub(event.Beginning)
+
+		occurrence := *event
+		occurrence.Beginning = start
+		occurrence.Finish = event.Finish.Add(shift)
+		if !event.Notification.IsZero() {
+			occurrence.Notification = event.Notification.Add(shift)
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	return occurrences, nil
+}
+
+// SameSchedule сообщает, совпадают ли повторения события с повторениями other.
+func (event *Event) SameSchedule(other Event) bool {
+	if !event.Beginning.Equal(other.Beginning) || event.Recurrence != other.Recurrence ||
+		event.TimeZone != other.TimeZone || len(event.ExDates) != len(other.ExDates) {
+		return false
+	}
+	for i := range event.ExDates {
+		if !event.ExDates[i].Equal(other.ExDates[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// seriesStart возвращает начало серии в часовом поясе, в котором разворачиваются ее повторения.
+func (event *Event) seriesStart() (time.Time, error) {
+	if event.TimeZone == "" {
+		return event.Beginning, nil
+	}
+	loc, err := event.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return event.Beginning.In(loc), nil
+}
+
+// NotifiedAt возвращает повторения события, уведомление о которых приходится на момент t.
+func (event *Event) NotifiedAt(t time.Time) ([]Event, error) {
+	if event.Notification.IsZero() {
+		return []Event{}, nil
+	}
+
+	start := t.Add(event.Beginning.Sub(event.Notification))
+	occurrences, err := event.Occurrences(start, start.Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	notified := make([]Event, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		if occurrence.Notification.Equal(t) {
+			notified = append(notified, occurrence)
+		}
+	}
+
+	return notified, nil
+}
+
+// PendingNotifications возвращает повторения события, время уведомления о которых наступило к моменту now,
+// но уведомление еще не отправлено: Notification позже Notified и не позже now.
+// Уведомления не позже since устарели и пропускаются, нулевой since не ограничивает опоздание.
+func (event *Event) PendingNotifications(now, since time.Time) ([]Event, error) {
+	if event.Notification.IsZero() {
+		return []Event{}, nil
+	}
+
+	lead := event.Beginning.Sub(event.Notification)
+	from := time.Time{}
+	if after := latest(event.Notified, since); !after.IsZero() {
+		from = after.Add(lead).Add(time.Nanosecond)
+	}
+	return event.Occurrences(from, now.Add(lead).Add(time.Nanosecond))
+}
+
+// NextNotification возвращает время первого уведомления события позже after или нулевое время,
+// если уведомлений не осталось. Повторения ищутся не дальше NotificationHorizon от after.
+func (event *Event) NextNotification(after time.Time) (time.Time, error) {
+	if event.Notification.IsZero() {
+		return time.Time{}, nil
+	}
+
+	rule, err := ParseRecurrence(event.Recurrence)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if rule == nil {
+		if event.Notification.After(after) {
+			return event.Notification, nil
+		}
+		return time.Time{}, nil
+	}
+
+	start, err := event.seriesStart()
+	if err != nil {
+		return time.Time{}, err
+	}
+	lead := event.Beginning.Sub(event.Notification)
+	from := after.Add(lead).Add(time.Nanosecond)
+	next, ok := rule.Next(start, from, latest(from, start).Add(NotificationHorizon), event.ExDates)
+	if !ok {
+		return time.Time{}, nil
+	}
+	return next.Add(-lead), nil
+}
+
+// InitialNotified возвращает отметку Notified для события, время или повторение которого задаются
+// в момент now. Уведомления о повторениях серии, начавшихся раньше now, считаются пропущенными.
+// У события без повторения отметка пустая: уведомление о нем отправляется, даже если его время прошло.
+func (event *Event) InitialNotified(now time.Time) time.Time {
+	if event.Recurrence == "" || event.Notification.IsZero() {
+		return time.Time{}
+	}
+	return now.Add(event.Notification.Sub(event.Beginning)).Truncate(time.Second)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("must be positive, got %d", n)
+	}
+	return n, nil
+}
+
+// parseRecurrenceTime разбирает значение UNTIL. Дата без времени включает весь день.
+func parseRecurrenceTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	parts := strings.Split(value, ",")
+	result := make([]WeekdayNum, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if len(part) < 2 {
+			return nil, fmt.Errorf("malformed weekday %q", part)
+		}
+
+		day, ok := weekdays[part[len(part)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", part)
+		}
+
+		n := 0
+		if prefix := part[:len(part)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("malformed weekday %q", part)
+			}
+		}
+		result = append(result, WeekdayNum{Weekday: day, N: n})
+	}
+
+	return result, nil
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range weekdays {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, item := range times {
+		if item.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
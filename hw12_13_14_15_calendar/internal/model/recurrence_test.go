@@ -0,0 +1,279 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("empty rule", func(t *testing.T) {
+		rule, err := ParseRecurrence("")
+		require.NoError(t, err)
+		require.Nil(t, rule)
+	})
+
+	t.Run("full rule", func(t *testing.T) {
+		rule, err := ParseRecurrence("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR,2TU;UNTIL=20241231T000000Z")
+		require.NoError(t, err)
+		require.Equal(t, FreqMonthly, rule.Freq)
+		require.Equal(t, 2, rule.Interval)
+		require.Equal(t, []WeekdayNum{{Weekday: time.Friday, N: -1}, {Weekday: time.Tuesday, N: 2}}, rule.ByDay)
+		require.Equal(t, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), rule.Until)
+		require.Equal(t, "FREQ=MONTHLY;INTERVAL=2;UNTIL=20241231T000000Z;BYDAY=-1FR,2TU", rule.String())
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rule := range []string{
+			"INTERVAL=2",
+			"FREQ=WEEKLY;INTERVAL=0",
+			"FREQ=WEEKLY;COUNT=2;UNTIL=20240101",
+			"FREQ=WEEKLY;BYDAY=XX",
+			"FREQ=WEEKLY;COUNT",
+		} {
+			_, err := ParseRecurrence(rule)
+			require.ErrorIs(t, err, ErrInvalidRecurrence, rule)
+		}
+	})
+
+	t.Run("unsupported rules", func(t *testing.T) {
+		for _, rule := range []string{
+			"FREQ=HOURLY",
+			"FREQ=YEARLY;BYMONTH=1",
+			"FREQ=YEARLY;BYDAY=MO",
+			"FREQ=WEEKLY;BYDAY=1MO",
+		} {
+			_, err := ParseRecurrence(rule)
+			require.ErrorIs(t, err, ErrUnsupportedRecurrence, rule)
+		}
+	})
+}
+
+func TestEventOccurrences(t *testing.T) {
+	// Понедельник.
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := Event{
+		ID:           "1",
+		Title:        "Планерка",
+		Beginning:    start,
+		Finish:       start.Add(time.Hour),
+		Notification: start.Add(-15 * time.Minute),
+	}
+
+	t.Run("single event", func(t *testing.T) {
+		occurrences, err := event.Occurrences(start, start.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Len(t, occurrences, 1)
+
+		occurrences, err = event.Occurrences(start.Add(time.Second), start.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Empty(t, occurrences)
+	})
+
+	t.Run("weekly by day with exdate", func(t *testing.T) {
+		weekly := event
+		weekly.Recurrence = "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5"
+		weekly.ExDates = []time.Time{start.AddDate(0, 0, 2)}
+
+		occurrences, err := weekly.Occurrences(start, start.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			start,
+			start.AddDate(0, 0, 7),
+			start.AddDate(0, 0, 9),
+			start.AddDate(0, 0, 14),
+		}, beginnings(occurrences))
+
+		for _, occurrence := range occurrences {
+			require.Equal(t, "1", occurrence.ID)
+			require.Equal(t, time.Hour, occurrence.Finish.Sub(occurrence.Beginning))
+			require.Equal(t, 15*time.Minute, occurrence.Beginning.Sub(occurrence.Notification))
+		}
+	})
+
+	t.Run("daily with interval and until", func(t *testing.T) {
+		daily := event
+		daily.Recurrence = "FREQ=DAILY;INTERVAL=3;UNTIL=20240612T100000Z"
+
+		occurrences, err := daily.Occurrences(start.AddDate(0, 0, 1), start.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			start.AddDate(0, 0, 3),
+			start.AddDate(0, 0, 6),
+			start.AddDate(0, 0, 9),
+		}, beginnings(occurrences))
+	})
+
+	t.Run("monthly skips short months", func(t *testing.T) {
+		monthly := event
+		monthly.Beginning = time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+		monthly.Finish = monthly.Beginning.Add(time.Hour)
+		monthly.Recurrence = "FREQ=MONTHLY;COUNT=3"
+
+		occurrences, err := monthly.Occurrences(monthly.Beginning, monthly.Beginning.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 31, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, time.May, 31, 10, 0, 0, 0, time.UTC),
+		}, beginnings(occurrences))
+	})
+
+	t.Run("monthly last friday", func(t *testing.T) {
+		monthly := event
+		monthly.Recurrence = "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2"
+
+		occurrences, err := monthly.Occurrences(start, start.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			time.Date(2024, time.June, 28, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, time.July, 26, 10, 0, 0, 0, time.UTC),
+		}, beginnings(occurrences))
+	})
+
+	t.Run("keeps wall clock across DST", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		weekly := event
+		weekly.Beginning = time.Date(2024, time.March, 25, 9, 0, 0, 0, loc)
+		weekly.Finish = weekly.Beginning.Add(time.Hour)
+		weekly.Recurrence = "FREQ=WEEKLY"
+
+		occurrences, err := weekly.Occurrences(time.Date(2024, time.March, 20, 0, 0, 0, 0, loc),
+			time.Date(2024, time.April, 2, 0, 0, 0, 0, loc))
+		require.NoError(t, err)
+		require.Len(t, occurrences, 2)
+		require.Equal(t, 9, occurrences[1].Beginning.Hour())
+	})
+
+	t.Run("expands in event time zone", func(t *testing.T) {
+		// Время начала хранится в UTC, как его возвращает база, а местное время задает часовой пояс события.
+		weekly := event
+		weekly.Beginning = time.Date(2024, time.March, 25, 8, 0, 0, 0, time.UTC)
+		weekly.Finish = weekly.Beginning.Add(time.Hour)
+		weekly.Recurrence = "FREQ=WEEKLY"
+		weekly.TimeZone = "Europe/Berlin"
+
+		occurrences, err := weekly.Occurrences(weekly.Beginning, weekly.Beginning.AddDate(0, 0, 8))
+		require.NoError(t, err)
+		require.Len(t, occurrences, 2)
+		require.Equal(t, 7, occurrences[1].Beginning.UTC().Hour())
+		require.Equal(t, time.Hour, occurrences[1].Finish.Sub(occurrences[1].Beginning))
+
+		weekly.TimeZone = "Mars/Olympus"
+		_, err = weekly.Occurrences(weekly.Beginning, weekly.Beginning.AddDate(0, 0, 8))
+		require.ErrorIs(t, err, ErrUnknownTimeZone)
+	})
+
+	t.Run("notified at", func(t *testing.T) {
+		weekly := event
+		weekly.Recurrence = "FREQ=WEEKLY"
+
+		occurrences, err := weekly.NotifiedAt(event.Notification.AddDate(0, 0, 14))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{start.AddDate(0, 0, 14)}, beginnings(occurrences))
+
+		occurrences, err = weekly.NotifiedAt(event.Notification.AddDate(0, 0, 13))
+		require.NoError(t, err)
+		require.Empty(t, occurrences)
+	})
+}
+
+func beginnings(events []Event) []time.Time {
+	result := make([]time.Time, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Beginning)
+	}
+	return result
+}
+
+func TestEventPendingNotifications(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := Event{
+		ID: "1", Beginning: start, Finish: start.Add(time.Hour), Notification: start.Add(-15 * time.Minute),
+	}
+
+	t.Run("single event", func(t *testing.T) {
+		pending, err := event.PendingNotifications(start.Add(-16*time.Minute), time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		// Опоздавший такт видит уведомление, даже если событие уже началось,
+		// например пока планировщик был остановлен.
+		for _, now := range []time.Time{start.Add(-15 * time.Minute), start, start.Add(2 * time.Hour)} {
+			pending, err = event.PendingNotifications(now, time.Time{})
+			require.NoError(t, err)
+			require.Equal(t, []time.Time{start}, beginnings(pending))
+		}
+
+		// Уведомление не позже since устарело.
+		now := start.Add(2 * time.Hour)
+		pending, err = event.PendingNotifications(now, now.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, pending)
+		pending, err = event.PendingNotifications(now, now.Add(-3*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{start}, beginnings(pending))
+
+		notified := event
+		notified.Notified = event.Notification
+		pending, err = notified.PendingNotifications(start.Add(-time.Minute), time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+
+	t.Run("recurring event", func(t *testing.T) {
+		daily := event
+		daily.Recurrence = "FREQ=DAILY"
+		daily.Notification = start.Add(-48 * time.Hour)
+
+		// Уведомления приходят за двое суток. Серия создана, когда повторение 3 июня уже началось:
+		// уведомление о нем пропускается, а уведомления о повторениях 4 и 5 июня наступили.
+		now := start.AddDate(0, 0, 1).Add(-time.Hour)
+		daily.Notified = daily.InitialNotified(start.Add(time.Minute))
+		pending, err := daily.PendingNotifications(now, time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{start.AddDate(0, 0, 1), start.AddDate(0, 0, 2)}, beginnings(pending))
+		require.Equal(t, start, pending[1].Notification)
+
+		daily.Notified = pending[0].Notification
+		pending, err = daily.PendingNotifications(now, time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{start.AddDate(0, 0, 2)}, beginnings(pending))
+
+		daily.Notification = time.Time{}
+		pending, err = daily.PendingNotifications(now, time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+}
+
+func TestEventNextNotification(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := Event{
+		ID: "1", Beginning: start, Finish: start.Add(time.Hour), Notification: start.Add(-15 * time.Minute),
+	}
+
+	next, err := event.NextNotification(time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, event.Notification, next)
+	next, err = event.NextNotification(event.Notification)
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+
+	weekly := event
+	weekly.Recurrence = "FREQ=WEEKLY;COUNT=3"
+	weekly.ExDates = []time.Time{start.AddDate(0, 0, 7)}
+	next, err = weekly.NextNotification(time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, event.Notification, next)
+	// Исключенное повторение пропускается.
+	next, err = weekly.NextNotification(event.Notification)
+	require.NoError(t, err)
+	require.Equal(t, event.Notification.AddDate(0, 0, 14), next)
+	next, err = weekly.NextNotification(event.Notification.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+}
@@ -0,0 +1,114 @@
+package model
+
+import "time"
+
+const (
+	// MaxReminders максимальное число напоминаний у события.
+	MaxReminders = 10
+	// MaxReminderOffset максимальный интервал между напоминанием и началом события.
+	MaxReminderOffset = 4 * 7 * 24 * time.Hour
+	// ReminderHorizon интервал после момента планирования, в котором ищется следующее повторение для напоминания.
+	ReminderHorizon = 5 * 366 * 24 * time.Hour
+
+	// ReminderMessage тип сообщения с напоминанием в общей очереди уведомлений.
+	ReminderMessage = "reminder"
+)
+
+// Channel канал доставки напоминания.
+type Channel string
+
+const (
+	// ChannelDefault канал по умолчанию, выбирается рассыльщиком.
+	ChannelDefault Channel = ""
+	ChannelEmail   Channel = "email"
+	ChannelWebhook Channel = "webhook"
+	ChannelLog     Channel = "log"
+)
+
+// Valid проверяет, что канал известен.
+func (c Channel) Valid() bool {
+	switch c {
+	case ChannelDefault, ChannelEmail, ChannelWebhook, ChannelLog:
+		return true
+	}
+	return false
+}
+
+// Reminder напоминание о событии за MinutesBefore минут до начала каждого повторения.
+type Reminder struct {
+	ID            string  `json:"id,omitempty"`
+	EventID       string  `json:"-"`
+	MinutesBefore int     `json:"minutesBefore"`
+	Channel       Channel `json:"channel,omitempty"`
+	// FireAt время следующего напоминания, нулевое, если повторений больше нет. Вычисляется приложением.
+	FireAt time.Time `json:"fireAt"`
+	// Occurrence начало повторения, к которому относится следующее напоминание.
+	Occurrence time.Time `json:"-"`
+	// SentAt время последней отправки напоминания.
+	SentAt time.Time `json:"sentAt"`
+}
+
+// Offset возвращает интервал между напоминанием и началом события.
+func (r Reminder) Offset() time.Duration {
+	return time.Duration(r.MinutesBefore) * time.Minute
+}
+
+// DueReminder напоминание, время которого наступило, вместе с событием.
+type DueReminder struct {
+	Reminder Reminder
+	Event    Event
+}
+
+// ReminderNotice напоминание о повторении события, публикуемое в брокер сообщений.
+type ReminderNotice struct {
+	// Type всегда ReminderMessage.
+	Type          string    `json:"type"`
+	ReminderID    string    `json:"reminderId"`
+	EventID       string    `json:"eventId"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Beginning     time.Time `json:"beginning"`
+	Finish        time.Time `json:"finish"`
+	UserID        string    `json:"userId"`
+	MinutesBefore int       `json:"minutesBefore"`
+	Channel       Channel   `json:"channel,omitempty"`
+}
+
+// ScheduleReminder возвращает напоминание о первом повторении события, которое начинается не раньше from.
+// FireAt может оказаться раньше from, тогда напоминание нужно отправить сразу.
+// Если повторений нет в пределах ReminderHorizon, FireAt и Occurrence обнуляются.
+func (event *Event) ScheduleReminder(reminder Reminder, from time.Time) (Reminder, error) {
+	reminder.FireAt, reminder.Occurrence = time.Time{}, time.Time{}
+
+	occurrences, err := event.Occurrences(from, from.Add(ReminderHorizon))
+	if err != nil {
+		return reminder, err
+	}
+	if len(occurrences) > 0 {
+		reminder.Occurrence = occurrences[0].Beginning
+		reminder.FireAt = reminder.Occurrence.Add(-reminder.Offset())
+	}
+	return reminder, nil
+}
+
+// Notice возвращает сообщение о повторении события, к которому относится напоминание.
+func (due DueReminder) Notice() ReminderNotice {
+	occurrence := due.Event
+	if !due.Reminder.Occurrence.IsZero() {
+		shift := due.Reminder.Occurrence.Sub(due.Event.Beginning)
+		occurrence.Beginning = due.Event.Beginning.Add(shift)
+		occurrence.Finish = due.Event.Finish.Add(shift)
+	}
+	return ReminderNotice{
+		Type:          ReminderMessage,
+		ReminderID:    due.Reminder.ID,
+		EventID:       occurrence.ID,
+		Title:         occurrence.Title,
+		Description:   occurrence.Description,
+		Beginning:     occurrence.Beginning,
+		Finish:        occurrence.Finish,
+		UserID:        occurrence.UserID,
+		MinutesBefore: due.Reminder.MinutesBefore,
+		Channel:       due.Reminder.Channel,
+	}
+}
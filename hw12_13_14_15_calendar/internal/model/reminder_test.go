@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventScheduleReminder(t *testing.T) {
+	// Понедельник.
+	start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := Event{ID: "1", Title: "Планерка", Beginning: start, Finish: start.Add(time.Hour), UserID: "2"}
+	reminder := Reminder{ID: "3", MinutesBefore: 30, Channel: ChannelEmail, SentAt: start}
+
+	t.Run("single event", func(t *testing.T) {
+		scheduled, err := event.ScheduleReminder(reminder, start.AddDate(0, 0, -1))
+		require.NoError(t, err)
+		require.Equal(t, start.Add(-30*time.Minute), scheduled.FireAt)
+		require.Equal(t, start, scheduled.Occurrence)
+		require.Equal(t, "3", scheduled.ID)
+		require.Equal(t, start, scheduled.SentAt)
+
+		// Время напоминания прошло, но событие еще не началось.
+		scheduled, err = event.ScheduleReminder(reminder, start.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, start.Add(-30*time.Minute), scheduled.FireAt)
+
+		scheduled, err = event.ScheduleReminder(reminder, start.Add(time.Second))
+		require.NoError(t, err)
+		require.True(t, scheduled.FireAt.IsZero())
+		require.True(t, scheduled.Occurrence.IsZero())
+	})
+
+	t.Run("next occurrence", func(t *testing.T) {
+		weekly := event
+		weekly.Recurrence = "FREQ=WEEKLY;COUNT=2"
+
+		scheduled, err := weekly.ScheduleReminder(reminder, start.Add(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, start.AddDate(0, 0, 7), scheduled.Occurrence)
+		require.Equal(t, start.AddDate(0, 0, 7).Add(-30*time.Minute), scheduled.FireAt)
+
+		scheduled, err = weekly.ScheduleReminder(reminder, start.AddDate(0, 0, 7).Add(time.Second))
+		require.NoError(t, err)
+		require.True(t, scheduled.FireAt.IsZero())
+	})
+
+	t.Run("notice", func(t *testing.T) {
+		weekly := event
+		weekly.Recurrence = "FREQ=WEEKLY"
+		scheduled, err := weekly.ScheduleReminder(reminder, start.Add(time.Second))
+		require.NoError(t, err)
+
+		notice := DueReminder{Reminder: scheduled, Event: weekly}.Notice()
+		require.Equal(t, ReminderNotice{
+			Type: ReminderMessage, ReminderID: "3", EventID: "1", Title: "Планерка",
+			Beginning: start.AddDate(0, 0, 7), Finish: start.AddDate(0, 0, 7).Add(time.Hour), UserID: "2",
+			MinutesBefore: 30, Channel: ChannelEmail,
+		}, notice)
+	})
+}
+
+func TestReminderKey(t *testing.T) {
+	occurrence := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	due := DueReminder{
+		Reminder: Reminder{ID: "1", MinutesBefore: 15, Channel: ChannelEmail, Occurrence: occurrence},
+		Event:    Event{ID: "event"},
+	}
+	key := ReminderKey(due)
+
+	// Напоминание, запланированное заново под другим идентификатором, сохраняет ключ.
+	due.Reminder.ID = "2"
+	require.Equal(t, key, ReminderKey(due))
+
+	due.Reminder.Occurrence = occurrence.AddDate(0, 0, 7)
+	require.NotEqual(t, key, ReminderKey(due))
+	due.Reminder.Occurrence = occurrence
+	due.Reminder.Channel = ChannelLog
+	require.NotEqual(t, key, ReminderKey(due))
+}
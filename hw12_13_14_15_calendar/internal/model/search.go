@@ -0,0 +1,31 @@
+package model
+
+const (
+	// DefaultSearchLimit число результатов поиска, если оно не указано в запросе.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit максимальное число результатов поиска.
+	MaxSearchLimit = 100
+
+	// HighlightStart и HighlightStop обрамляют найденные слова во фрагменте результата поиска.
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchQuery запрос полнотекстового поиска по названию и описанию событий.
+type SearchQuery struct {
+	// Text слова запроса, событие должно содержать каждое из них.
+	Text string
+	// UserID ограничивает поиск событиями пользователя, пустой - события всех пользователей.
+	UserID string
+	Limit  int
+	Visibility
+}
+
+// SearchResult найденное событие с релевантностью и фрагментом текста с подсвеченными словами запроса.
+// Совпадения в названии весят больше, чем в описании. Snippet экранирован для HTML,
+// теги в нем - только HighlightStart и HighlightStop.
+type SearchResult struct {
+	Event   Event   `json:"event"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownTimeZone часовой пояс не найден в базе IANA.
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// LoadLocation загружает часовой пояс IANA, пустое имя означает UTC.
+// В отличие от time.LoadLocation, имя "Local" не принимается: часовой пояс сервера
+// не должен влиять на то, в какой день попадает событие.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTimeZone, name)
+	}
+	return loc, nil
+}
+
+// DayStart возвращает полночь дня, в который попадает t, в часовом поясе loc.
+func DayStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocation(t *testing.T) {
+	loc, err := LoadLocation("")
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, loc)
+
+	loc, err = LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Tokyo", loc.String())
+
+	for _, name := range []string{"Local", "Mars/Olympus"} {
+		_, err = LoadLocation(name)
+		require.ErrorIs(t, err, ErrUnknownTimeZone, name)
+	}
+}
+
+func TestDayStart(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 20:00 UTC 1 июня - это уже 2 июня в Токио.
+	day := DayStart(time.Date(2024, time.June, 1, 20, 0, 0, 0, time.UTC), tokyo)
+	require.Equal(t, time.Date(2024, time.June, 2, 0, 0, 0, 0, tokyo), day)
+}
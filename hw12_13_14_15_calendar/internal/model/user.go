@@ -1,5 +1,17 @@
 package model
 
+import "errors"
+
+var (
+	// ErrUserNotFound пользователь с указанным идентификатором не существует.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrNotificationSettingsNotFound у пользователя не заданы настройки уведомлений.
+	ErrNotificationSettingsNotFound = errors.New("notification settings not found")
+)
+
+// DefaultChannels каналы доставки сообщений пользователю без настроек уведомлений.
+var DefaultChannels = []Channel{ChannelEmail}
+
 // IUser интерфейс для структуры User, предоставляющий методы доступа к полям.
 type IUser interface {
 	GetID() string
@@ -7,6 +19,7 @@ type IUser interface {
 	GetLastName() string
 	GetEmail() string
 	GetAge() int64
+	GetTimeZone() string
 }
 
 // User структура, представляющая пользователя.
@@ -16,6 +29,8 @@ type User struct {
 	LastName  string `json:"lastName"`
 	Email     string `json:"email"`
 	Age       int64  `json:"age"`
+	// TimeZone часовой пояс IANA, в котором пользователю показываются дни, недели и месяцы, пустой - UTC.
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // GetID возвращает ID пользователя.
@@ -42,3 +57,36 @@ func (user *User) GetEmail() string {
 func (user *User) GetAge() int64 {
 	return user.Age
 }
+
+// GetTimeZone возвращает часовой пояс пользователя.
+func (user *User) GetTimeZone() string {
+	return user.TimeZone
+}
+
+// NotificationSettings настройки уведомлений пользователя: каналы, по которым рассыльщик
+// доставляет ему уведомления, напоминания и приглашения.
+type NotificationSettings struct {
+	UserID string `json:"userId"`
+	// Channels каналы доставки без повторов, ChannelDefault не допускается.
+	Channels []Channel `json:"channels"`
+	// WebhookURL адрес, на который отправляются сообщения по каналу ChannelWebhook.
+	WebhookURL string `json:"webhookUrl,omitempty"`
+}
+
+// Route возвращает каналы доставки сообщения notice. Канал, явно указанный в напоминании,
+// заменяет настройки пользователя, без настроек используются DefaultChannels.
+func (settings NotificationSettings) Route(notice Notice) []Channel {
+	switch {
+	case notice.Channel != ChannelDefault:
+		return []Channel{notice.Channel}
+	case len(settings.Channels) > 0:
+		return settings.Channels
+	}
+	return DefaultChannels
+}
+
+// Recipient получатель сообщения вместе с его настройками уведомлений.
+type Recipient struct {
+	User     User
+	Settings NotificationSettings
+}
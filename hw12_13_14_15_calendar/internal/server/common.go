@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 )
 
@@ -25,17 +26,54 @@ type Logger interface {
 	Debug(string, ...interface{})
 }
 
+// Authenticator проверяет токен доступа (JWT или ключ API) и возвращает аутентифицированного пользователя.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (auth.Principal, error)
+}
+
 type Application interface {
-	CreateUser(context.Context, model.IUser) error
+	CreateUser(context.Context, model.IUser) (model.IUser, error)
 	SelectUsers(context.Context) ([]model.IUser, error)
+	ListUsers(context.Context, model.PageRequest) ([]model.IUser, string, error)
 	DeleteUser(context.Context, string) error
 
-	CreateEvent(context.Context, model.IEvent) error
+	CreateEvent(context.Context, model.IEvent) (model.IEvent, error)
 	SelectEvents(context.Context) ([]model.IEvent, error)
+	SelectEvent(context.Context, string) (model.IEvent, error)
+	ListEvents(context.Context, model.EventFilter, model.PageRequest) ([]model.IEvent, string, error)
+	SearchEvents(context.Context, string, string, int) ([]model.SearchResult, error)
 	UpdateEvent(context.Context, model.IEvent) error
 	DeleteEvent(context.Context, string) error
+	WatchEvents(context.Context, string, time.Time, time.Time, string) (<-chan model.EventChange, error)
+
+	CreateCalendar(context.Context, model.Calendar) (model.Calendar, error)
+	SelectCalendars(context.Context) ([]model.Calendar, error)
+	SelectCalendar(context.Context, string) (model.Calendar, error)
+	UpdateCalendar(context.Context, model.Calendar) error
+	DeleteCalendar(context.Context, string) error
+	ShareCalendar(context.Context, string, string, model.Role) error
+	UnshareCalendar(context.Context, string, string) error
+
+	SelectAttendees(context.Context, string) ([]model.Attendee, error)
+	InviteAttendee(context.Context, string, string) (model.Attendee, error)
+	RemoveAttendee(context.Context, string, string) error
+	RespondToInvitation(context.Context, string, string, model.AttendeeStatus) error
+
+	FreeBusy(context.Context, model.FreeBusyQuery) (model.FreeBusy, error)
+	SelectWorkingHours(context.Context, string) (model.WorkingHours, error)
+	SetWorkingHours(context.Context, model.WorkingHours) error
+	DeleteWorkingHours(context.Context, string) error
+	SelectNotificationSettings(context.Context, string) (model.NotificationSettings, error)
+	SetNotificationSettings(context.Context, model.NotificationSettings) error
+	DeleteNotificationSettings(context.Context, string) error
+
+	ExportEvents(context.Context, string) ([]byte, error)
+	// ImportEvents возвращает число созданных и пропущенных, уже загруженных событий.
+	ImportEvents(context.Context, string, []byte) (int, int, error)
 
 	SelectEventsByTime(context.Context, time.Time) ([]model.IEvent, error)
+	// Location возвращает часовой пояс запроса: указанный явно, пользователя из контекста или UTC.
+	Location(context.Context, string) (*time.Location, error)
 	SelectEventsForDay(context.Context, time.Time) ([]model.IEvent, error)
 	SelectEventsForWeek(context.Context, time.Time) ([]model.IEvent, error)
 	SelectEventsForMonth(context.Context, time.Time) ([]model.IEvent, error)
@@ -21,25 +21,62 @@ const _ = grpc.SupportPackageIsVersion8
 
 const (
 	EventService_SelectEvents_FullMethodName         = "/EventService/SelectEvents"
+	EventService_GetEvent_FullMethodName             = "/EventService/GetEvent"
 	EventService_CreateEvent_FullMethodName          = "/EventService/CreateEvent"
 	EventService_UpdateEvent_FullMethodName          = "/EventService/UpdateEvent"
+	EventService_PatchEvent_FullMethodName           = "/EventService/PatchEvent"
 	EventService_DeleteEvent_FullMethodName          = "/EventService/DeleteEvent"
+	EventService_SearchEvents_FullMethodName         = "/EventService/SearchEvents"
+	EventService_ListAttendees_FullMethodName        = "/EventService/ListAttendees"
+	EventService_InviteAttendee_FullMethodName       = "/EventService/InviteAttendee"
+	EventService_RemoveAttendee_FullMethodName       = "/EventService/RemoveAttendee"
+	EventService_RespondToInvitation_FullMethodName  = "/EventService/RespondToInvitation"
+	EventService_FreeBusy_FullMethodName             = "/EventService/FreeBusy"
+	EventService_ExportEvents_FullMethodName         = "/EventService/ExportEvents"
+	EventService_ImportEvents_FullMethodName         = "/EventService/ImportEvents"
 	EventService_SelectEventsForDay_FullMethodName   = "/EventService/SelectEventsForDay"
 	EventService_SelectEventsForWeek_FullMethodName  = "/EventService/SelectEventsForWeek"
 	EventService_SelectEventsForMonth_FullMethodName = "/EventService/SelectEventsForMonth"
+	EventService_WatchEvents_FullMethodName          = "/EventService/WatchEvents"
 )
 
 // EventServiceClient is the client API for EventService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HTTP/JSON шлюз публикует сервисы как REST API под префиксом /api/v1, маршруты задаются аннотациями google.api.http.
 type EventServiceClient interface {
-	SelectEvents(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Events, error)
-	CreateEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Void, error)
+	// SelectEvents возвращает страницу событий, подходящих под фильтр. Токен следующей страницы
+	// передается в Events.NextPageToken и пуст на последней странице.
+	SelectEvents(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (*Events, error)
+	// GetEvent возвращает событие по ID. Шлюз сопоставляет путь с методами в обратном порядке объявления,
+	// поэтому /api/v1/events/{ID} объявлен раньше путей /api/v1/events/search, day, week, month и watch.
+	GetEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error)
+	CreateEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error)
 	UpdateEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Void, error)
+	// PatchEvent обновляет поля события из UpdateMask и возвращает событие после обновления.
+	PatchEvent(ctx context.Context, in *EventPatch, opts ...grpc.CallOption) (*Event, error)
 	DeleteEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Void, error)
+	// SearchEvents полнотекстовый поиск событий, содержащих все слова Query, в порядке убывания релевантности.
+	SearchEvents(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error)
+	ListAttendees(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Attendees, error)
+	// InviteAttendee приглашает пользователя на событие, повторное приглашение возвращает текущее участие.
+	InviteAttendee(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Attendee, error)
+	RemoveAttendee(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Void, error)
+	// RespondToInvitation сохраняет ответ участника: accepted, declined или tentative.
+	RespondToInvitation(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Void, error)
+	// FreeBusy возвращает занятость пользователей и первые слоты длительностью DurationMinutes,
+	// в которые все пользователи свободны в пределах своего рабочего времени. Limit 0 - 5 слотов.
+	FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error)
+	ExportEvents(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ICalendar, error)
+	ImportEvents(ctx context.Context, in *ICalendar, opts ...grpc.CallOption) (*ImportResult, error)
 	SelectEventsForDay(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*Events, error)
 	SelectEventsForWeek(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*Events, error)
 	SelectEventsForMonth(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*Events, error)
+	// WatchEvents передает изменения событий пользователя (или всех пользователей, если UserID пуст),
+	// начинающихся в период [From, To). Без границ периода передаются изменения всех событий.
+	// Непустой LastChangeID возобновляет подписку после изменения с этим ID.
+	WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventService_WatchEventsClient, error)
 }
 
 type eventServiceClient struct {
@@ -50,7 +87,7 @@ func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
 	return &eventServiceClient{cc}
 }
 
-func (c *eventServiceClient) SelectEvents(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Events, error) {
+func (c *eventServiceClient) SelectEvents(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (*Events, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Events)
 	err := c.cc.Invoke(ctx, EventService_SelectEvents_FullMethodName, in, out, cOpts...)
@@ -60,9 +97,19 @@ func (c *eventServiceClient) SelectEvents(ctx context.Context, in *Void, opts ..
 	return out, nil
 }
 
-func (c *eventServiceClient) CreateEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Void, error) {
+func (c *eventServiceClient) GetEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Void)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_GetEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) CreateEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
 	err := c.cc.Invoke(ctx, EventService_CreateEvent_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -80,6 +127,16 @@ func (c *eventServiceClient) UpdateEvent(ctx context.Context, in *Event, opts ..
 	return out, nil
 }
 
+func (c *eventServiceClient) PatchEvent(ctx context.Context, in *EventPatch, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_PatchEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eventServiceClient) DeleteEvent(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Void, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Void)
@@ -90,6 +147,86 @@ func (c *eventServiceClient) DeleteEvent(ctx context.Context, in *Event, opts ..
 	return out, nil
 }
 
+func (c *eventServiceClient) SearchEvents(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResults)
+	err := c.cc.Invoke(ctx, EventService_SearchEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ListAttendees(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Attendees, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attendees)
+	err := c.cc.Invoke(ctx, EventService_ListAttendees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) InviteAttendee(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Attendee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attendee)
+	err := c.cc.Invoke(ctx, EventService_InviteAttendee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) RemoveAttendee(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, EventService_RemoveAttendee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) RespondToInvitation(ctx context.Context, in *Attendee, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, EventService_RespondToInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreeBusyResponse)
+	err := c.cc.Invoke(ctx, EventService_FreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ExportEvents(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ICalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ICalendar)
+	err := c.cc.Invoke(ctx, EventService_ExportEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ImportEvents(ctx context.Context, in *ICalendar, opts ...grpc.CallOption) (*ImportResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportResult)
+	err := c.cc.Invoke(ctx, EventService_ImportEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eventServiceClient) SelectEventsForDay(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*Events, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Events)
@@ -120,28 +257,91 @@ func (c *eventServiceClient) SelectEventsForMonth(ctx context.Context, in *DateR
 	return out, nil
 }
 
+func (c *eventServiceClient) WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventService_WatchEventsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceWatchEventsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventService_WatchEventsClient interface {
+	Recv() (*EventChange, error)
+	grpc.ClientStream
+}
+
+type eventServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceWatchEventsClient) Recv() (*EventChange, error) {
+	m := new(EventChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EventServiceServer is the server API for EventService service.
 // All implementations must embed UnimplementedEventServiceServer
 // for forward compatibility
+//
+// HTTP/JSON шлюз публикует сервисы как REST API под префиксом /api/v1, маршруты задаются аннотациями google.api.http.
 type EventServiceServer interface {
-	SelectEvents(context.Context, *Void) (*Events, error)
-	CreateEvent(context.Context, *Event) (*Void, error)
+	// SelectEvents возвращает страницу событий, подходящих под фильтр. Токен следующей страницы
+	// передается в Events.NextPageToken и пуст на последней странице.
+	SelectEvents(context.Context, *EventsRequest) (*Events, error)
+	// GetEvent возвращает событие по ID. Шлюз сопоставляет путь с методами в обратном порядке объявления,
+	// поэтому /api/v1/events/{ID} объявлен раньше путей /api/v1/events/search, day, week, month и watch.
+	GetEvent(context.Context, *Event) (*Event, error)
+	CreateEvent(context.Context, *Event) (*Event, error)
 	UpdateEvent(context.Context, *Event) (*Void, error)
+	// PatchEvent обновляет поля события из UpdateMask и возвращает событие после обновления.
+	PatchEvent(context.Context, *EventPatch) (*Event, error)
 	DeleteEvent(context.Context, *Event) (*Void, error)
+	// SearchEvents полнотекстовый поиск событий, содержащих все слова Query, в порядке убывания релевантности.
+	SearchEvents(context.Context, *SearchRequest) (*SearchResults, error)
+	ListAttendees(context.Context, *Event) (*Attendees, error)
+	// InviteAttendee приглашает пользователя на событие, повторное приглашение возвращает текущее участие.
+	InviteAttendee(context.Context, *Attendee) (*Attendee, error)
+	RemoveAttendee(context.Context, *Attendee) (*Void, error)
+	// RespondToInvitation сохраняет ответ участника: accepted, declined или tentative.
+	RespondToInvitation(context.Context, *Attendee) (*Void, error)
+	// FreeBusy возвращает занятость пользователей и первые слоты длительностью DurationMinutes,
+	// в которые все пользователи свободны в пределах своего рабочего времени. Limit 0 - 5 слотов.
+	FreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error)
+	ExportEvents(context.Context, *ExportRequest) (*ICalendar, error)
+	ImportEvents(context.Context, *ICalendar) (*ImportResult, error)
 	SelectEventsForDay(context.Context, *DateRequest) (*Events, error)
 	SelectEventsForWeek(context.Context, *DateRequest) (*Events, error)
 	SelectEventsForMonth(context.Context, *DateRequest) (*Events, error)
+	// WatchEvents передает изменения событий пользователя (или всех пользователей, если UserID пуст),
+	// начинающихся в период [From, To). Без границ периода передаются изменения всех событий.
+	// Непустой LastChangeID возобновляет подписку после изменения с этим ID.
+	WatchEvents(*WatchRequest, EventService_WatchEventsServer) error
 	mustEmbedUnimplementedEventServiceServer()
 }
 
 // UnimplementedEventServiceServer must be embedded to have forward compatible implementations.
 type UnimplementedEventServiceServer struct{}
 
-func (UnimplementedEventServiceServer) SelectEvents(context.Context, *Void) (*Events, error) {
+func (UnimplementedEventServiceServer) SelectEvents(context.Context, *EventsRequest) (*Events, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectEvents not implemented")
 }
 
-func (UnimplementedEventServiceServer) CreateEvent(context.Context, *Event) (*Void, error) {
+func (UnimplementedEventServiceServer) GetEvent(context.Context, *Event) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+
+func (UnimplementedEventServiceServer) CreateEvent(context.Context, *Event) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
 }
 
@@ -149,10 +349,46 @@ func (UnimplementedEventServiceServer) UpdateEvent(context.Context, *Event) (*Vo
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
 }
 
+func (UnimplementedEventServiceServer) PatchEvent(context.Context, *EventPatch) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchEvent not implemented")
+}
+
 func (UnimplementedEventServiceServer) DeleteEvent(context.Context, *Event) (*Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
 }
 
+func (UnimplementedEventServiceServer) SearchEvents(context.Context, *SearchRequest) (*SearchResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEvents not implemented")
+}
+
+func (UnimplementedEventServiceServer) ListAttendees(context.Context, *Event) (*Attendees, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttendees not implemented")
+}
+
+func (UnimplementedEventServiceServer) InviteAttendee(context.Context, *Attendee) (*Attendee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAttendee not implemented")
+}
+
+func (UnimplementedEventServiceServer) RemoveAttendee(context.Context, *Attendee) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAttendee not implemented")
+}
+
+func (UnimplementedEventServiceServer) RespondToInvitation(context.Context, *Attendee) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToInvitation not implemented")
+}
+
+func (UnimplementedEventServiceServer) FreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreeBusy not implemented")
+}
+
+func (UnimplementedEventServiceServer) ExportEvents(context.Context, *ExportRequest) (*ICalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+
+func (UnimplementedEventServiceServer) ImportEvents(context.Context, *ICalendar) (*ImportResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+
 func (UnimplementedEventServiceServer) SelectEventsForDay(context.Context, *DateRequest) (*Events, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectEventsForDay not implemented")
 }
@@ -164,6 +400,10 @@ func (UnimplementedEventServiceServer) SelectEventsForWeek(context.Context, *Dat
 func (UnimplementedEventServiceServer) SelectEventsForMonth(context.Context, *DateRequest) (*Events, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectEventsForMonth not implemented")
 }
+
+func (UnimplementedEventServiceServer) WatchEvents(*WatchRequest, EventService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
 func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
 
 // UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -178,7 +418,7 @@ func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer)
 }
 
 func _EventService_SelectEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Void)
+	in := new(EventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -190,7 +430,25 @@ func _EventService_SelectEvents_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: EventService_SelectEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EventServiceServer).SelectEvents(ctx, req.(*Void))
+		return srv.(EventServiceServer).SelectEvents(ctx, req.(*EventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Event)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetEvent(ctx, req.(*Event))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -231,6 +489,24 @@ func _EventService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_PatchEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventPatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).PatchEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_PatchEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).PatchEvent(ctx, req.(*EventPatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EventService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Event)
 	if err := dec(in); err != nil {
@@ -249,6 +525,150 @@ func _EventService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_SearchEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SearchEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SearchEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SearchEvents(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ListAttendees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Event)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ListAttendees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ListAttendees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ListAttendees(ctx, req.(*Event))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_InviteAttendee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Attendee)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).InviteAttendee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_InviteAttendee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).InviteAttendee(ctx, req.(*Attendee))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_RemoveAttendee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Attendee)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).RemoveAttendee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_RemoveAttendee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).RemoveAttendee(ctx, req.(*Attendee))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_RespondToInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Attendee)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).RespondToInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_RespondToInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).RespondToInvitation(ctx, req.(*Attendee))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_FreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).FreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_FreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).FreeBusy(ctx, req.(*FreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ExportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ExportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ExportEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ExportEvents(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ImportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ICalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ImportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ImportEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ImportEvents(ctx, req.(*ICalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EventService_SelectEventsForDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DateRequest)
 	if err := dec(in); err != nil {
@@ -303,17 +723,42 @@ func _EventService_SelectEventsForMonth_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var EventService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "EventService",
-	HandlerType: (*EventServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
+func _EventService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).WatchEvents(m, &eventServiceWatchEventsServer{ServerStream: stream})
+}
+
+type EventService_WatchEventsServer interface {
+	Send(*EventChange) error
+	grpc.ServerStream
+}
+
+type eventServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceWatchEventsServer) Send(m *EventChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
 			MethodName: "SelectEvents",
 			Handler:    _EventService_SelectEvents_Handler,
 		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _EventService_GetEvent_Handler,
+		},
 		{
 			MethodName: "CreateEvent",
 			Handler:    _EventService_CreateEvent_Handler,
@@ -322,10 +767,46 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateEvent",
 			Handler:    _EventService_UpdateEvent_Handler,
 		},
+		{
+			MethodName: "PatchEvent",
+			Handler:    _EventService_PatchEvent_Handler,
+		},
 		{
 			MethodName: "DeleteEvent",
 			Handler:    _EventService_DeleteEvent_Handler,
 		},
+		{
+			MethodName: "SearchEvents",
+			Handler:    _EventService_SearchEvents_Handler,
+		},
+		{
+			MethodName: "ListAttendees",
+			Handler:    _EventService_ListAttendees_Handler,
+		},
+		{
+			MethodName: "InviteAttendee",
+			Handler:    _EventService_InviteAttendee_Handler,
+		},
+		{
+			MethodName: "RemoveAttendee",
+			Handler:    _EventService_RemoveAttendee_Handler,
+		},
+		{
+			MethodName: "RespondToInvitation",
+			Handler:    _EventService_RespondToInvitation_Handler,
+		},
+		{
+			MethodName: "FreeBusy",
+			Handler:    _EventService_FreeBusy_Handler,
+		},
+		{
+			MethodName: "ExportEvents",
+			Handler:    _EventService_ExportEvents_Handler,
+		},
+		{
+			MethodName: "ImportEvents",
+			Handler:    _EventService_ImportEvents_Handler,
+		},
 		{
 			MethodName: "SelectEventsForDay",
 			Handler:    _EventService_SelectEventsForDay_Handler,
@@ -339,23 +820,42 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _EventService_SelectEventsForMonth_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _EventService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "internal/server/grpc/EventService.proto",
 }
 
 const (
-	UserService_SelectUsers_FullMethodName = "/UserService/SelectUsers"
-	UserService_CreateUser_FullMethodName  = "/UserService/CreateUser"
-	UserService_DeleteUser_FullMethodName  = "/UserService/DeleteUser"
+	UserService_SelectUsers_FullMethodName                = "/UserService/SelectUsers"
+	UserService_CreateUser_FullMethodName                 = "/UserService/CreateUser"
+	UserService_DeleteUser_FullMethodName                 = "/UserService/DeleteUser"
+	UserService_GetWorkingHours_FullMethodName            = "/UserService/GetWorkingHours"
+	UserService_SetWorkingHours_FullMethodName            = "/UserService/SetWorkingHours"
+	UserService_DeleteWorkingHours_FullMethodName         = "/UserService/DeleteWorkingHours"
+	UserService_GetNotificationSettings_FullMethodName    = "/UserService/GetNotificationSettings"
+	UserService_SetNotificationSettings_FullMethodName    = "/UserService/SetNotificationSettings"
+	UserService_DeleteNotificationSettings_FullMethodName = "/UserService/DeleteNotificationSettings"
 )
 
 // UserServiceClient is the client API for UserService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
-	SelectUsers(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Users, error)
-	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error)
+	// SelectUsers возвращает страницу пользователей.
+	SelectUsers(ctx context.Context, in *UsersRequest, opts ...grpc.CallOption) (*Users, error)
+	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
 	DeleteUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error)
+	GetWorkingHours(ctx context.Context, in *User, opts ...grpc.CallOption) (*WorkingHours, error)
+	SetWorkingHours(ctx context.Context, in *WorkingHours, opts ...grpc.CallOption) (*Void, error)
+	DeleteWorkingHours(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error)
+	GetNotificationSettings(ctx context.Context, in *User, opts ...grpc.CallOption) (*NotificationSettings, error)
+	SetNotificationSettings(ctx context.Context, in *NotificationSettings, opts ...grpc.CallOption) (*Void, error)
+	DeleteNotificationSettings(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error)
 }
 
 type userServiceClient struct {
@@ -366,7 +866,7 @@ func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
 	return &userServiceClient{cc}
 }
 
-func (c *userServiceClient) SelectUsers(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Users, error) {
+func (c *userServiceClient) SelectUsers(ctx context.Context, in *UsersRequest, opts ...grpc.CallOption) (*Users, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Users)
 	err := c.cc.Invoke(ctx, UserService_SelectUsers_FullMethodName, in, out, cOpts...)
@@ -376,9 +876,9 @@ func (c *userServiceClient) SelectUsers(ctx context.Context, in *Void, opts ...g
 	return out, nil
 }
 
-func (c *userServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error) {
+func (c *userServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Void)
+	out := new(User)
 	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -396,30 +896,121 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *User, opts ...gr
 	return out, nil
 }
 
+func (c *userServiceClient) GetWorkingHours(ctx context.Context, in *User, opts ...grpc.CallOption) (*WorkingHours, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkingHours)
+	err := c.cc.Invoke(ctx, UserService_GetWorkingHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetWorkingHours(ctx context.Context, in *WorkingHours, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, UserService_SetWorkingHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteWorkingHours(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, UserService_DeleteWorkingHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetNotificationSettings(ctx context.Context, in *User, opts ...grpc.CallOption) (*NotificationSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationSettings)
+	err := c.cc.Invoke(ctx, UserService_GetNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetNotificationSettings(ctx context.Context, in *NotificationSettings, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, UserService_SetNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteNotificationSettings(ctx context.Context, in *User, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, UserService_DeleteNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
 type UserServiceServer interface {
-	SelectUsers(context.Context, *Void) (*Users, error)
-	CreateUser(context.Context, *User) (*Void, error)
+	// SelectUsers возвращает страницу пользователей.
+	SelectUsers(context.Context, *UsersRequest) (*Users, error)
+	CreateUser(context.Context, *User) (*User, error)
 	DeleteUser(context.Context, *User) (*Void, error)
+	GetWorkingHours(context.Context, *User) (*WorkingHours, error)
+	SetWorkingHours(context.Context, *WorkingHours) (*Void, error)
+	DeleteWorkingHours(context.Context, *User) (*Void, error)
+	GetNotificationSettings(context.Context, *User) (*NotificationSettings, error)
+	SetNotificationSettings(context.Context, *NotificationSettings) (*Void, error)
+	DeleteNotificationSettings(context.Context, *User) (*Void, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
 // UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
 type UnimplementedUserServiceServer struct{}
 
-func (UnimplementedUserServiceServer) SelectUsers(context.Context, *Void) (*Users, error) {
+func (UnimplementedUserServiceServer) SelectUsers(context.Context, *UsersRequest) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectUsers not implemented")
 }
 
-func (UnimplementedUserServiceServer) CreateUser(context.Context, *User) (*Void, error) {
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *User) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
 }
 
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *User) (*Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+
+func (UnimplementedUserServiceServer) GetWorkingHours(context.Context, *User) (*WorkingHours, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkingHours not implemented")
+}
+
+func (UnimplementedUserServiceServer) SetWorkingHours(context.Context, *WorkingHours) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkingHours not implemented")
+}
+
+func (UnimplementedUserServiceServer) DeleteWorkingHours(context.Context, *User) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkingHours not implemented")
+}
+
+func (UnimplementedUserServiceServer) GetNotificationSettings(context.Context, *User) (*NotificationSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationSettings not implemented")
+}
+
+func (UnimplementedUserServiceServer) SetNotificationSettings(context.Context, *NotificationSettings) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNotificationSettings not implemented")
+}
+
+func (UnimplementedUserServiceServer) DeleteNotificationSettings(context.Context, *User) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNotificationSettings not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -434,7 +1025,7 @@ func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
 }
 
 func _UserService_SelectUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Void)
+	in := new(UsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -446,7 +1037,7 @@ func _UserService_SelectUsers_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: UserService_SelectUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).SelectUsers(ctx, req.(*Void))
+		return srv.(UserServiceServer).SelectUsers(ctx, req.(*UsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -487,6 +1078,114 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetWorkingHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetWorkingHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetWorkingHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetWorkingHours(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetWorkingHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkingHours)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetWorkingHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetWorkingHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetWorkingHours(ctx, req.(*WorkingHours))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteWorkingHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteWorkingHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteWorkingHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteWorkingHours(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetNotificationSettings(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationSettings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetNotificationSettings(ctx, req.(*NotificationSettings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteNotificationSettings(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -506,6 +1205,360 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "GetWorkingHours",
+			Handler:    _UserService_GetWorkingHours_Handler,
+		},
+		{
+			MethodName: "SetWorkingHours",
+			Handler:    _UserService_SetWorkingHours_Handler,
+		},
+		{
+			MethodName: "DeleteWorkingHours",
+			Handler:    _UserService_DeleteWorkingHours_Handler,
+		},
+		{
+			MethodName: "GetNotificationSettings",
+			Handler:    _UserService_GetNotificationSettings_Handler,
+		},
+		{
+			MethodName: "SetNotificationSettings",
+			Handler:    _UserService_SetNotificationSettings_Handler,
+		},
+		{
+			MethodName: "DeleteNotificationSettings",
+			Handler:    _UserService_DeleteNotificationSettings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/server/grpc/EventService.proto",
+}
+
+const (
+	CalendarService_SelectCalendars_FullMethodName = "/CalendarService/SelectCalendars"
+	CalendarService_GetCalendar_FullMethodName     = "/CalendarService/GetCalendar"
+	CalendarService_CreateCalendar_FullMethodName  = "/CalendarService/CreateCalendar"
+	CalendarService_UpdateCalendar_FullMethodName  = "/CalendarService/UpdateCalendar"
+	CalendarService_DeleteCalendar_FullMethodName  = "/CalendarService/DeleteCalendar"
+	CalendarService_ShareCalendar_FullMethodName   = "/CalendarService/ShareCalendar"
+	CalendarService_UnshareCalendar_FullMethodName = "/CalendarService/UnshareCalendar"
+)
+
+// CalendarServiceClient is the client API for CalendarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CalendarService управляет календарями и доступом к ним. Роли: read, write, owner.
+type CalendarServiceClient interface {
+	// SelectCalendars возвращает собственные календари пользователя и календари, к которым ему выдан доступ.
+	SelectCalendars(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Calendars, error)
+	GetCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error)
+	CreateCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error)
+	UpdateCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Void, error)
+	DeleteCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Void, error)
+	ShareCalendar(ctx context.Context, in *ACLEntry, opts ...grpc.CallOption) (*Void, error)
+	UnshareCalendar(ctx context.Context, in *ACLEntry, opts ...grpc.CallOption) (*Void, error)
+}
+
+type calendarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalendarServiceClient(cc grpc.ClientConnInterface) CalendarServiceClient {
+	return &calendarServiceClient{cc}
+}
+
+func (c *calendarServiceClient) SelectCalendars(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Calendars, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calendars)
+	err := c.cc.Invoke(ctx, CalendarService_SelectCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calendar)
+	err := c.cc.Invoke(ctx, CalendarService_GetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calendar)
+	err := c.cc.Invoke(ctx, CalendarService_CreateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ShareCalendar(ctx context.Context, in *ACLEntry, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, CalendarService_ShareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UnshareCalendar(ctx context.Context, in *ACLEntry, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, CalendarService_UnshareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceServer is the server API for CalendarService service.
+// All implementations must embed UnimplementedCalendarServiceServer
+// for forward compatibility
+//
+// CalendarService управляет календарями и доступом к ним. Роли: read, write, owner.
+type CalendarServiceServer interface {
+	// SelectCalendars возвращает собственные календари пользователя и календари, к которым ему выдан доступ.
+	SelectCalendars(context.Context, *Void) (*Calendars, error)
+	GetCalendar(context.Context, *Calendar) (*Calendar, error)
+	CreateCalendar(context.Context, *Calendar) (*Calendar, error)
+	UpdateCalendar(context.Context, *Calendar) (*Void, error)
+	DeleteCalendar(context.Context, *Calendar) (*Void, error)
+	ShareCalendar(context.Context, *ACLEntry) (*Void, error)
+	UnshareCalendar(context.Context, *ACLEntry) (*Void, error)
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+// UnimplementedCalendarServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCalendarServiceServer struct{}
+
+func (UnimplementedCalendarServiceServer) SelectCalendars(context.Context, *Void) (*Calendars, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectCalendars not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) GetCalendar(context.Context, *Calendar) (*Calendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) CreateCalendar(context.Context, *Calendar) (*Calendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCalendar not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) UpdateCalendar(context.Context, *Calendar) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCalendar not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) DeleteCalendar(context.Context, *Calendar) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) ShareCalendar(context.Context, *ACLEntry) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCalendar not implemented")
+}
+
+func (UnimplementedCalendarServiceServer) UnshareCalendar(context.Context, *ACLEntry) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) mustEmbedUnimplementedCalendarServiceServer() {}
+
+// UnsafeCalendarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServiceServer will
+// result in compilation errors.
+type UnsafeCalendarServiceServer interface {
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+func RegisterCalendarServiceServer(s grpc.ServiceRegistrar, srv CalendarServiceServer) {
+	s.RegisterService(&CalendarService_ServiceDesc, srv)
+}
+
+func _CalendarService_SelectCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).SelectCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_SelectCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).SelectCalendars(ctx, req.(*Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Calendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetCalendar(ctx, req.(*Calendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Calendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, req.(*Calendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Calendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, req.(*Calendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Calendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, req.(*Calendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ShareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACLEntry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ShareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ShareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ShareCalendar(ctx, req.(*ACLEntry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UnshareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACLEntry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UnshareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UnshareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UnshareCalendar(ctx, req.(*ACLEntry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CalendarService_ServiceDesc is the grpc.ServiceDesc for CalendarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalendarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "CalendarService",
+	HandlerType: (*CalendarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SelectCalendars",
+			Handler:    _CalendarService_SelectCalendars_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _CalendarService_GetCalendar_Handler,
+		},
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _CalendarService_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "UpdateCalendar",
+			Handler:    _CalendarService_UpdateCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _CalendarService_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "ShareCalendar",
+			Handler:    _CalendarService_ShareCalendar_Handler,
+		},
+		{
+			MethodName: "UnshareCalendar",
+			Handler:    _CalendarService_UnshareCalendar_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/server/grpc/EventService.proto",
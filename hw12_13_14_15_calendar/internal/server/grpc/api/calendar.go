@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
+)
+
+type CalendarServer struct {
+	UnimplementedCalendarServiceServer
+	app    server.Application
+	logger server.Logger
+}
+
+func NewCalendarServer(logger server.Logger, app server.Application) *CalendarServer {
+	return &CalendarServer{
+		app:    app,
+		logger: logger,
+	}
+}
+
+// SelectCalendars возвращает календари, доступные пользователю.
+func (s *CalendarServer) SelectCalendars(ctx context.Context, _ *Void) (*Calendars, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("SelectCalendars", ctx, start, duration)
+	}(time.Now())
+
+	calendars, err := s.app.SelectCalendars(ctx)
+	if err != nil {
+		return nil, statusError(err, "failed to select calendars")
+	}
+
+	protoCalendars := make([]*Calendar, len(calendars))
+	for i, calendar := range calendars {
+		protoCalendars[i] = newCalendar(calendar)
+	}
+	return &Calendars{Calendars: protoCalendars}, nil
+}
+
+// GetCalendar возвращает календарь по его идентификатору.
+func (s *CalendarServer) GetCalendar(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("GetCalendar", ctx, start, duration)
+	}(time.Now())
+
+	found, err := s.app.SelectCalendar(ctx, calendar.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to get calendar")
+	}
+	return newCalendar(found), nil
+}
+
+// CreateCalendar создает календарь и возвращает его с присвоенным идентификатором.
+func (s *CalendarServer) CreateCalendar(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("CreateCalendar", ctx, start, duration)
+	}(time.Now())
+
+	created, err := s.app.CreateCalendar(ctx, modelCalendar(calendar))
+	if err != nil {
+		return nil, statusError(err, "failed to create calendar")
+	}
+	return newCalendar(created), nil
+}
+
+// UpdateCalendar изменяет название, часовой пояс и цвет календаря.
+func (s *CalendarServer) UpdateCalendar(ctx context.Context, calendar *Calendar) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("UpdateCalendar", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.UpdateCalendar(ctx, modelCalendar(calendar)); err != nil {
+		return nil, statusError(err, "failed to update calendar")
+	}
+	return &Void{}, nil
+}
+
+// DeleteCalendar удаляет календарь вместе с его событиями.
+func (s *CalendarServer) DeleteCalendar(ctx context.Context, calendar *Calendar) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("DeleteCalendar", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.DeleteCalendar(ctx, calendar.GetID()); err != nil {
+		return nil, statusError(err, "failed to delete calendar")
+	}
+	return &Void{}, nil
+}
+
+// ShareCalendar выдает пользователю роль в календаре.
+func (s *CalendarServer) ShareCalendar(ctx context.Context, entry *ACLEntry) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("ShareCalendar", ctx, start, duration)
+	}(time.Now())
+
+	err := s.app.ShareCalendar(ctx, entry.GetCalendarID(), entry.GetUserID(), model.Role(entry.GetRole()))
+	if err != nil {
+		return nil, statusError(err, "failed to share calendar")
+	}
+	return &Void{}, nil
+}
+
+// UnshareCalendar отзывает доступ пользователя к календарю.
+func (s *CalendarServer) UnshareCalendar(ctx context.Context, entry *ACLEntry) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("UnshareCalendar", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.UnshareCalendar(ctx, entry.GetCalendarID(), entry.GetUserID()); err != nil {
+		return nil, statusError(err, "failed to unshare calendar")
+	}
+	return &Void{}, nil
+}
+
+// mustEmbedUnimplementedCalendarServiceServer требуется для реализации интерфейса gRPC.
+func (s *CalendarServer) mustEmbedUnimplementedCalendarServiceServer() {}
+
+// newCalendar преобразует календарь приложения в сообщение gRPC.
+func newCalendar(calendar model.Calendar) *Calendar {
+	result := &Calendar{
+		ID:       calendar.ID,
+		Name:     calendar.Name,
+		OwnerID:  calendar.OwnerID,
+		TimeZone: calendar.TimeZone,
+		Color:    calendar.Color,
+	}
+	for _, entry := range calendar.ACL {
+		result.ACL = append(result.ACL, &ACLEntry{
+			CalendarID: entry.CalendarID,
+			UserID:     entry.UserID,
+			Role:       string(entry.Role),
+		})
+	}
+	return result
+}
+
+// modelCalendar преобразует сообщение gRPC в календарь приложения, ACL не переносится.
+func modelCalendar(calendar *Calendar) model.Calendar {
+	return model.Calendar{
+		ID:       calendar.GetID(),
+		Name:     calendar.GetName(),
+		OwnerID:  calendar.GetOwnerID(),
+		TimeZone: calendar.GetTimeZone(),
+		Color:    calendar.GetColor(),
+	}
+}
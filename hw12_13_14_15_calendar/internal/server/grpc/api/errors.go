@@ -0,0 +1,51 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/ical"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError преобразует ошибку приложения в статус gRPC с соответствующим кодом.
+// Ошибки валидации дополняются errdetails.BadRequest со списком некорректных полей.
+func statusError(err error, msg string) error {
+	msg = fmt.Sprintf("%s: %v", msg, err)
+
+	var validationErr *app.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range validationErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+
+		st := status.New(codes.InvalidArgument, msg)
+		detailed, detailsErr := st.WithDetails(badRequest)
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	case errors.Is(err, ical.ErrInvalidCalendar):
+		return status.Error(codes.InvalidArgument, msg)
+	case errors.Is(err, model.ErrEventNotFound), errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, model.ErrCalendarNotFound), errors.Is(err, model.ErrAccessNotFound),
+		errors.Is(err, model.ErrAttendeeNotFound), errors.Is(err, model.ErrWorkingHoursNotFound),
+		errors.Is(err, model.ErrNotificationSettingsNotFound):
+		return status.Error(codes.NotFound, msg)
+	case errors.Is(err, model.ErrDateBusy):
+		return status.Error(codes.AlreadyExists, msg)
+	case errors.Is(err, app.ErrForbidden):
+		return status.Error(codes.PermissionDenied, msg)
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
@@ -2,11 +2,16 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -20,44 +25,65 @@ func NewEventServer(logger server.Logger, app server.Application) *EventServer {
 	return &EventServer{logger: logger, app: app}
 }
 
-// SelectEvents возвращает все события.
-func (s *EventServer) SelectEvents(ctx context.Context, _ *Void) (*Events, error) {
+// SelectEvents возвращает страницу событий, подходящих под фильтр, и токен следующей страницы.
+func (s *EventServer) SelectEvents(ctx context.Context, req *EventsRequest) (*Events, error) {
 	defer func(start time.Time) {
 		duration := time.Since(start)
 		s.logger.Info("SelectEvents", ctx, start, duration)
 	}(time.Now())
 
-	events, err := s.app.SelectEvents(ctx)
+	filter := model.EventFilter{UserID: req.GetUserID(), CalendarID: req.GetCalendarID(), Title: req.GetTitle()}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+	page := model.PageRequest{
+		OrderBy:   req.GetOrderBy(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+
+	events, next, err := s.app.ListEvents(ctx, filter, page)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select events: %v", err)
+		return nil, statusError(err, "failed to select events")
 	}
 
-	var result Events
+	result := Events{NextPageToken: next}
 	for _, event := range events {
-		e := Event{
-			ID:            event.GetID(),
-			Title:         event.GetTitle(),
-			Description:   event.GetDescription(),
-			UserID:        event.GetUserID(),
-			BeginningT:    timestamppb.New(event.GetBeginning()),
-			FinishT:       timestamppb.New(event.GetFinish()),
-			NotificationT: timestamppb.New(event.GetNotification()),
-		}
-		result.Events = append(result.Events, &e)
+		result.Events = append(result.Events, newEvent(event))
 	}
 
 	return &result, nil
 }
 
-// CreateEvent создает новое событие.
-func (s *EventServer) CreateEvent(ctx context.Context, event *Event) (*Void, error) {
+// GetEvent возвращает событие по его идентификатору.
+func (s *EventServer) GetEvent(ctx context.Context, event *Event) (*Event, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("GetEvent", ctx, start, duration)
+	}(time.Now())
+
+	selected, err := s.app.SelectEvent(ctx, event.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to select event")
+	}
+	return newEvent(selected), nil
+}
+
+// CreateEvent создает новое событие и возвращает его с присвоенным идентификатором.
+func (s *EventServer) CreateEvent(ctx context.Context, event *Event) (*Event, error) {
 	defer func(start time.Time) {
 		duration := time.Since(start)
 		s.logger.Info("CreateEvent", ctx, start, duration)
 	}(time.Now())
 
-	err := s.app.CreateEvent(ctx, event)
-	return &Void{}, err
+	created, err := s.app.CreateEvent(ctx, event)
+	if err != nil {
+		return nil, statusError(err, "failed to create event")
+	}
+	return newEvent(created), nil
 }
 
 // UpdateEvent обновляет существующее событие.
@@ -68,11 +94,41 @@ func (s *EventServer) UpdateEvent(ctx context.Context, event *Event) (*Void, err
 	}(time.Now())
 
 	if err := s.app.UpdateEvent(ctx, event); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update event: %v", err)
+		return nil, statusError(err, "failed to update event")
 	}
 	return &Void{}, nil
 }
 
+// PatchEvent переносит поля из UpdateMask в текущее событие и возвращает событие после обновления.
+// Событие читается заново, так как напоминания планирует приложение.
+func (s *EventServer) PatchEvent(ctx context.Context, patch *EventPatch) (*Event, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("PatchEvent", ctx, start, duration)
+	}(time.Now())
+
+	current, err := s.app.SelectEvent(ctx, patch.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to select event")
+	}
+
+	event := newEvent(current)
+	if err := applyMask(event, patch.GetEvent(), patch.GetUpdateMask()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	event.ID = patch.GetID()
+
+	if err := s.app.UpdateEvent(ctx, event); err != nil {
+		return nil, statusError(err, "failed to update event")
+	}
+
+	updated, err := s.app.SelectEvent(ctx, patch.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to select event")
+	}
+	return newEvent(updated), nil
+}
+
 // DeleteEvent удаляет событие по его идентификатору.
 func (s *EventServer) DeleteEvent(ctx context.Context, event *Event) (*Void, error) {
 	defer func(start time.Time) {
@@ -81,11 +137,164 @@ func (s *EventServer) DeleteEvent(ctx context.Context, event *Event) (*Void, err
 	}(time.Now())
 
 	if err := s.app.DeleteEvent(ctx, event.ID); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete event: %v", err)
+		return nil, statusError(err, "failed to delete event")
 	}
 	return &Void{}, nil
 }
 
+// ListAttendees возвращает участников события.
+func (s *EventServer) ListAttendees(ctx context.Context, event *Event) (*Attendees, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("ListAttendees", ctx, start, duration)
+	}(time.Now())
+
+	attendees, err := s.app.SelectAttendees(ctx, event.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to list attendees")
+	}
+
+	result := &Attendees{}
+	for _, attendee := range attendees {
+		result.Attendees = append(result.Attendees, newAttendee(attendee))
+	}
+	return result, nil
+}
+
+// InviteAttendee приглашает пользователя на событие.
+func (s *EventServer) InviteAttendee(ctx context.Context, attendee *Attendee) (*Attendee, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("InviteAttendee", ctx, start, duration)
+	}(time.Now())
+
+	invited, err := s.app.InviteAttendee(ctx, attendee.GetEventID(), attendee.GetUserID())
+	if err != nil {
+		return nil, statusError(err, "failed to invite attendee")
+	}
+	return newAttendee(invited), nil
+}
+
+// RemoveAttendee удаляет участника события.
+func (s *EventServer) RemoveAttendee(ctx context.Context, attendee *Attendee) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("RemoveAttendee", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.RemoveAttendee(ctx, attendee.GetEventID(), attendee.GetUserID()); err != nil {
+		return nil, statusError(err, "failed to remove attendee")
+	}
+	return &Void{}, nil
+}
+
+// RespondToInvitation сохраняет ответ участника на приглашение.
+func (s *EventServer) RespondToInvitation(ctx context.Context, attendee *Attendee) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("RespondToInvitation", ctx, start, duration)
+	}(time.Now())
+
+	err := s.app.RespondToInvitation(ctx, attendee.GetEventID(), attendee.GetUserID(),
+		model.AttendeeStatus(attendee.GetStatus()))
+	if err != nil {
+		return nil, statusError(err, "failed to respond to invitation")
+	}
+	return &Void{}, nil
+}
+
+// SearchEvents выполняет полнотекстовый поиск событий.
+func (s *EventServer) SearchEvents(ctx context.Context, req *SearchRequest) (*SearchResults, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("SearchEvents", ctx, start, duration)
+	}(time.Now())
+
+	results, err := s.app.SearchEvents(ctx, req.GetQuery(), req.GetUserID(), int(req.GetLimit()))
+	if err != nil {
+		return nil, statusError(err, "failed to search events")
+	}
+
+	var response SearchResults
+	for i := range results {
+		response.Results = append(response.Results, &SearchResult{
+			Event:   newEvent(&results[i].Event),
+			Rank:    results[i].Rank,
+			Snippet: results[i].Snippet,
+		})
+	}
+
+	return &response, nil
+}
+
+// FreeBusy возвращает занятость пользователей и свободные слоты для встречи.
+func (s *EventServer) FreeBusy(ctx context.Context, req *FreeBusyRequest) (*FreeBusyResponse, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("FreeBusy", ctx, start, duration)
+	}(time.Now())
+
+	query := model.FreeBusyQuery{
+		UserIDs:  req.GetUserIDs(),
+		Duration: time.Duration(req.GetDurationMinutes()) * time.Minute,
+		Limit:    int(req.GetLimit()),
+	}
+	if req.GetFrom() != nil {
+		query.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		query.To = req.GetTo().AsTime()
+	}
+
+	freeBusy, err := s.app.FreeBusy(ctx, query)
+	if err != nil {
+		return nil, statusError(err, "failed to select free/busy")
+	}
+
+	response := FreeBusyResponse{Slots: newIntervals(freeBusy.Slots)}
+	for _, user := range freeBusy.Users {
+		response.Users = append(response.Users, &UserBusy{UserID: user.UserID, Busy: newIntervals(user.Busy)})
+	}
+	return &response, nil
+}
+
+// ExportEvents выгружает события пользователя в формате iCalendar.
+func (s *EventServer) ExportEvents(ctx context.Context, req *ExportRequest) (*ICalendar, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("ExportEvents", ctx, start, duration)
+	}(time.Now())
+
+	if req.GetUserID() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing user ID")
+	}
+
+	data, err := s.app.ExportEvents(ctx, req.GetUserID())
+	if err != nil {
+		return nil, statusError(err, "failed to export events")
+	}
+	return &ICalendar{UserID: req.GetUserID(), Data: data}, nil
+}
+
+// ImportEvents загружает события пользователя из iCalendar: либо все, либо ни одного.
+// Уже загруженные события с тем же UID пропускаются.
+func (s *EventServer) ImportEvents(ctx context.Context, req *ICalendar) (*ImportResult, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("ImportEvents", ctx, start, duration)
+	}(time.Now())
+
+	if req.GetUserID() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing user ID")
+	}
+
+	count, skipped, err := s.app.ImportEvents(ctx, req.GetUserID(), req.GetData())
+	if err != nil {
+		return nil, statusError(err, "failed to import events")
+	}
+	return &ImportResult{Count: int32(count), Skipped: int32(skipped)}, nil
+}
+
 // SelectEventsForDay возвращает события за указанный день.
 func (s *EventServer) SelectEventsForDay(ctx context.Context, req *DateRequest) (*Events, error) {
 	defer func(start time.Time) {
@@ -93,23 +302,19 @@ func (s *EventServer) SelectEventsForDay(ctx context.Context, req *DateRequest)
 		s.logger.Info("SelectEventsForDay", ctx, start, duration)
 	}(time.Now())
 
-	events, err := s.app.SelectEventsForDay(ctx, req.Date.AsTime())
+	date, err := s.date(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select events for day: %v", err)
+		return nil, statusError(err, "failed to select events for day")
+	}
+
+	events, err := s.app.SelectEventsForDay(ctx, date)
+	if err != nil {
+		return nil, statusError(err, "failed to select events for day")
 	}
 
 	var result Events
 	for _, event := range events {
-		e := Event{
-			ID:            event.GetID(),
-			Title:         event.GetTitle(),
-			Description:   event.GetDescription(),
-			UserID:        event.GetUserID(),
-			BeginningT:    timestamppb.New(event.GetBeginning()),
-			FinishT:       timestamppb.New(event.GetFinish()),
-			NotificationT: timestamppb.New(event.GetNotification()),
-		}
-		result.Events = append(result.Events, &e)
+		result.Events = append(result.Events, newEvent(event))
 	}
 
 	return &result, nil
@@ -122,23 +327,19 @@ func (s *EventServer) SelectEventsForWeek(ctx context.Context, req *DateRequest)
 		s.logger.Info("SelectEventsForWeek", ctx, start, duration)
 	}(time.Now())
 
-	events, err := s.app.SelectEventsForWeek(ctx, req.Date.AsTime())
+	date, err := s.date(ctx, req)
+	if err != nil {
+		return nil, statusError(err, "failed to select events for week")
+	}
+
+	events, err := s.app.SelectEventsForWeek(ctx, date)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select events for week: %v", err)
+		return nil, statusError(err, "failed to select events for week")
 	}
 
 	var result Events
 	for _, event := range events {
-		e := Event{
-			ID:            event.GetID(),
-			Title:         event.GetTitle(),
-			Description:   event.GetDescription(),
-			UserID:        event.GetUserID(),
-			BeginningT:    timestamppb.New(event.GetBeginning()),
-			FinishT:       timestamppb.New(event.GetFinish()),
-			NotificationT: timestamppb.New(event.GetNotification()),
-		}
-		result.Events = append(result.Events, &e)
+		result.Events = append(result.Events, newEvent(event))
 	}
 
 	return &result, nil
@@ -151,42 +352,224 @@ func (s *EventServer) SelectEventsForMonth(ctx context.Context, req *DateRequest
 		s.logger.Info("SelectEventsForMonth", ctx, start, duration)
 	}(time.Now())
 
-	events, err := s.app.SelectEventsForMonth(ctx, req.Date.AsTime())
+	date, err := s.date(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select events for month: %v", err)
+		return nil, statusError(err, "failed to select events for month")
+	}
+
+	events, err := s.app.SelectEventsForMonth(ctx, date)
+	if err != nil {
+		return nil, statusError(err, "failed to select events for month")
 	}
 
 	var result Events
 	for _, event := range events {
-		e := Event{
-			ID:            event.GetID(),
-			Title:         event.GetTitle(),
-			Description:   event.GetDescription(),
-			UserID:        event.GetUserID(),
-			BeginningT:    timestamppb.New(event.GetBeginning()),
-			FinishT:       timestamppb.New(event.GetFinish()),
-			NotificationT: timestamppb.New(event.GetNotification()),
-		}
-		result.Events = append(result.Events, &e)
+		result.Events = append(result.Events, newEvent(event))
 	}
 
 	return &result, nil
 }
 
+// WatchEvents передает клиенту изменения событий до отмены вызова.
+// Если клиент не успевает читать изменения, поток завершается с кодом ResourceExhausted.
+func (s *EventServer) WatchEvents(req *WatchRequest, stream EventService_WatchEventsServer) error {
+	ctx := stream.Context()
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("WatchEvents", ctx, start, duration)
+	}(time.Now())
+
+	var from, to time.Time
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+
+	changes, err := s.app.WatchEvents(ctx, req.GetUserID(), from, to, req.GetLastChangeID())
+	if err != nil {
+		return statusError(err, "failed to watch events")
+	}
+	// Заголовки отправляются сразу после подписки: клиент, получивший их, не пропустит последующие изменения.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for change := range changes {
+		if err := stream.Send(newEventChange(change)); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return status.Error(codes.ResourceExhausted, "watcher is too slow, resubscribe")
+}
+
 // mustEmbedUnimplementedEventServiceServer требуется для реализации интерфейса gRPC.
 func (s *EventServer) mustEmbedUnimplementedEventServiceServer() {}
 
 // GetBeginning возвращает время начала события.
 func (x *Event) GetBeginning() time.Time {
-	return x.BeginningT.AsTime()
+	return asTime(x.GetBeginningT())
 }
 
 // GetFinish возвращает время окончания события.
 func (x *Event) GetFinish() time.Time {
-	return x.FinishT.AsTime()
+	return asTime(x.GetFinishT())
 }
 
-// GetNotification возвращает время уведомления события.
+// GetNotification возвращает время уведомления события, нулевое, если уведомление не задано.
 func (x *Event) GetNotification() time.Time {
-	return x.NotificationT.AsTime()
+	return asTime(x.GetNotificationT())
+}
+
+// GetExDates возвращает исключенные из серии повторения события.
+func (x *Event) GetExDates() []time.Time {
+	exDates := make([]time.Time, 0, len(x.GetExDatesT()))
+	for _, exDate := range x.GetExDatesT() {
+		exDates = append(exDates, exDate.AsTime())
+	}
+	return exDates
+}
+
+// GetReminders возвращает напоминания события. FireAt и SentAt вычисляет сервер, поэтому они не передаются.
+func (x *Event) GetReminders() []model.Reminder {
+	var reminders []model.Reminder
+	for _, reminder := range x.GetRemindersT() {
+		reminders = append(reminders, model.Reminder{
+			ID:            reminder.GetID(),
+			MinutesBefore: int(reminder.GetMinutesBefore()),
+			Channel:       model.Channel(reminder.GetChannel()),
+		})
+	}
+	return reminders
+}
+
+// date возвращает полночь даты запроса в часовом поясе запроса.
+// Год, месяц и день берутся из Date в UTC, как их задает шлюз для даты без времени.
+func (s *EventServer) date(ctx context.Context, req *DateRequest) (time.Time, error) {
+	loc, err := s.app.Location(ctx, req.GetTimeZone())
+	if err != nil {
+		return time.Time{}, err
+	}
+	date := req.GetDate().AsTime()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc), nil
+}
+
+// applyMask переносит в event поля patch из mask, а при пустой mask - все заданные поля patch.
+// Повторяющиеся поля заменяются целиком, вложенные пути не поддерживаются.
+func applyMask(event, patch *Event, mask *fieldmaskpb.FieldMask) error {
+	src, dst := patch.ProtoReflect(), event.ProtoReflect()
+	if len(mask.GetPaths()) == 0 {
+		src.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			dst.Set(fd, value)
+			return true
+		})
+		return nil
+	}
+
+	fields := src.Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		fd := fields.ByName(protoreflect.Name(path))
+		if fd == nil {
+			return fmt.Errorf("unknown field %q in update mask", path)
+		}
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+	}
+	return nil
+}
+
+// asTime преобразует timestamppb во время, незаданное время - в нулевое.
+func asTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+// newEvent преобразует событие приложения в сообщение gRPC.
+func newEvent(event model.IEvent) *Event {
+	return &Event{
+		ID:            event.GetID(),
+		Title:         event.GetTitle(),
+		Description:   event.GetDescription(),
+		UserID:        event.GetUserID(),
+		BeginningT:    timestamppb.New(event.GetBeginning()),
+		FinishT:       timestamppb.New(event.GetFinish()),
+		NotificationT: timestamppb.New(event.GetNotification()),
+		Recurrence:    event.GetRecurrence(),
+		ExDatesT:      newTimestamps(event.GetExDates()),
+		CalendarID:    event.GetCalendarID(),
+		TimeZone:      event.GetTimeZone(),
+		RemindersT:    newReminders(event.GetReminders()),
+	}
+}
+
+// newReminders преобразует напоминания события в сообщения gRPC.
+func newReminders(reminders []model.Reminder) []*Reminder {
+	result := make([]*Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		result = append(result, &Reminder{
+			ID:            reminder.ID,
+			MinutesBefore: int32(reminder.MinutesBefore),
+			Channel:       string(reminder.Channel),
+			FireAt:        timestamppb.New(reminder.FireAt),
+			SentAt:        timestamppb.New(reminder.SentAt),
+		})
+	}
+	return result
+}
+
+// newAttendee преобразует участника события в сообщение gRPC.
+func newAttendee(attendee model.Attendee) *Attendee {
+	return &Attendee{EventID: attendee.EventID, UserID: attendee.UserID, Status: string(attendee.Status)}
+}
+
+// newTimestamps преобразует список времени в список timestamppb.
+func newTimestamps(times []time.Time) []*timestamppb.Timestamp {
+	timestamps := make([]*timestamppb.Timestamp, 0, len(times))
+	for _, t := range times {
+		timestamps = append(timestamps, timestamppb.New(t))
+	}
+	return timestamps
+}
+
+// newEventChange преобразует изменение события в сообщение gRPC. Сброс передается без события.
+func newEventChange(change model.EventChange) *EventChange {
+	result := &EventChange{ID: change.ID, Type: newChangeType(change.Type)}
+	if change.Type != model.ChangeReset {
+		result.Event = newEvent(&change.Event)
+	}
+	return result
+}
+
+// newChangeType преобразует тип изменения события в перечисление gRPC.
+func newChangeType(changeType model.ChangeType) ChangeType {
+	switch changeType {
+	case model.ChangeCreated:
+		return ChangeType_CHANGE_TYPE_CREATED
+	case model.ChangeUpdated:
+		return ChangeType_CHANGE_TYPE_UPDATED
+	case model.ChangeDeleted:
+		return ChangeType_CHANGE_TYPE_DELETED
+	case model.ChangeReset:
+		return ChangeType_CHANGE_TYPE_RESET
+	default:
+		return ChangeType_CHANGE_TYPE_UNSPECIFIED
+	}
+}
+
+func newIntervals(intervals []model.Interval) []*Interval {
+	protoIntervals := make([]*Interval, len(intervals))
+	for i, interval := range intervals {
+		protoIntervals[i] = &Interval{Start: timestamppb.New(interval.Start), End: timestamppb.New(interval.End)}
+	}
+	return protoIntervals
 }
@@ -4,9 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type UserServer struct {
@@ -22,44 +21,42 @@ func NewUserServer(logger server.Logger, app server.Application) *UserServer {
 	}
 }
 
-// SelectUsers возвращает всех пользователей.
-func (s *UserServer) SelectUsers(ctx context.Context, _ *Void) (*Users, error) {
+// SelectUsers возвращает страницу пользователей и токен следующей страницы.
+func (s *UserServer) SelectUsers(ctx context.Context, req *UsersRequest) (*Users, error) {
 	defer func(start time.Time) {
 		duration := time.Since(start)
 		s.logger.Info("SelectUsers", ctx, start, duration)
 	}(time.Now())
 
-	users, err := s.app.SelectUsers(ctx)
+	users, next, err := s.app.ListUsers(ctx, model.PageRequest{
+		OrderBy:   req.GetOrderBy(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select users: %v", err)
+		return nil, statusError(err, "failed to select users")
 	}
 
 	protoUsers := make([]*User, len(users))
 	for i, user := range users {
-		protoUsers[i] = &User{
-			ID:        user.GetID(),
-			FirstName: user.GetFirstName(),
-			LastName:  user.GetLastName(),
-			Email:     user.GetEmail(),
-			Age:       user.GetAge(),
-		}
+		protoUsers[i] = newUser(user)
 	}
 
-	return &Users{Users: protoUsers}, nil
+	return &Users{Users: protoUsers, NextPageToken: next}, nil
 }
 
-// CreateUser создает нового пользователя.
-func (s *UserServer) CreateUser(ctx context.Context, user *User) (*Void, error) {
+// CreateUser создает нового пользователя и возвращает его с присвоенным идентификатором.
+func (s *UserServer) CreateUser(ctx context.Context, user *User) (*User, error) {
 	defer func(start time.Time) {
 		duration := time.Since(start)
 		s.logger.Info("CreateUser", ctx, start, duration)
 	}(time.Now())
 
-	err := s.app.CreateUser(ctx, user)
+	created, err := s.app.CreateUser(ctx, modelUser(user))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+		return nil, statusError(err, "failed to create user")
 	}
-	return &Void{}, nil
+	return newUser(created), nil
 }
 
 // DeleteUser удаляет пользователя по его идентификатору.
@@ -71,10 +68,153 @@ func (s *UserServer) DeleteUser(ctx context.Context, user *User) (*Void, error)
 
 	err := s.app.DeleteUser(ctx, user.ID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
+		return nil, statusError(err, "failed to delete user")
+	}
+	return &Void{}, nil
+}
+
+// GetWorkingHours возвращает рабочее время пользователя.
+func (s *UserServer) GetWorkingHours(ctx context.Context, user *User) (*WorkingHours, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("GetWorkingHours", ctx, start, duration)
+	}(time.Now())
+
+	hours, err := s.app.SelectWorkingHours(ctx, user.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to get working hours")
+	}
+
+	days := make([]int32, len(hours.Days))
+	for i, day := range hours.Days {
+		days[i] = int32(day)
+	}
+	return &WorkingHours{
+		UserID:   hours.UserID,
+		TimeZone: hours.TimeZone,
+		Start:    hours.Start,
+		End:      hours.End,
+		Days:     days,
+	}, nil
+}
+
+// SetWorkingHours задает или заменяет рабочее время пользователя.
+func (s *UserServer) SetWorkingHours(ctx context.Context, hours *WorkingHours) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("SetWorkingHours", ctx, start, duration)
+	}(time.Now())
+
+	days := make([]int, len(hours.GetDays()))
+	for i, day := range hours.GetDays() {
+		days[i] = int(day)
+	}
+	err := s.app.SetWorkingHours(ctx, model.WorkingHours{
+		UserID:   hours.GetUserID(),
+		TimeZone: hours.GetTimeZone(),
+		Start:    hours.GetStart(),
+		End:      hours.GetEnd(),
+		Days:     days,
+	})
+	if err != nil {
+		return nil, statusError(err, "failed to set working hours")
+	}
+	return &Void{}, nil
+}
+
+// DeleteWorkingHours удаляет рабочее время пользователя.
+func (s *UserServer) DeleteWorkingHours(ctx context.Context, user *User) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("DeleteWorkingHours", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.DeleteWorkingHours(ctx, user.GetID()); err != nil {
+		return nil, statusError(err, "failed to delete working hours")
+	}
+	return &Void{}, nil
+}
+
+// GetNotificationSettings возвращает настройки уведомлений пользователя.
+func (s *UserServer) GetNotificationSettings(ctx context.Context, user *User) (*NotificationSettings, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("GetNotificationSettings", ctx, start, duration)
+	}(time.Now())
+
+	settings, err := s.app.SelectNotificationSettings(ctx, user.GetID())
+	if err != nil {
+		return nil, statusError(err, "failed to get notification settings")
+	}
+
+	channels := make([]string, len(settings.Channels))
+	for i, channel := range settings.Channels {
+		channels[i] = string(channel)
+	}
+	return &NotificationSettings{
+		UserID:     settings.UserID,
+		Channels:   channels,
+		WebhookURL: settings.WebhookURL,
+	}, nil
+}
+
+// SetNotificationSettings задает или заменяет настройки уведомлений пользователя.
+func (s *UserServer) SetNotificationSettings(ctx context.Context, settings *NotificationSettings) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("SetNotificationSettings", ctx, start, duration)
+	}(time.Now())
+
+	channels := make([]model.Channel, len(settings.GetChannels()))
+	for i, channel := range settings.GetChannels() {
+		channels[i] = model.Channel(channel)
+	}
+	err := s.app.SetNotificationSettings(ctx, model.NotificationSettings{
+		UserID:     settings.GetUserID(),
+		Channels:   channels,
+		WebhookURL: settings.GetWebhookURL(),
+	})
+	if err != nil {
+		return nil, statusError(err, "failed to set notification settings")
+	}
+	return &Void{}, nil
+}
+
+// DeleteNotificationSettings удаляет настройки уведомлений пользователя.
+func (s *UserServer) DeleteNotificationSettings(ctx context.Context, user *User) (*Void, error) {
+	defer func(start time.Time) {
+		duration := time.Since(start)
+		s.logger.Info("DeleteNotificationSettings", ctx, start, duration)
+	}(time.Now())
+
+	if err := s.app.DeleteNotificationSettings(ctx, user.GetID()); err != nil {
+		return nil, statusError(err, "failed to delete notification settings")
 	}
 	return &Void{}, nil
 }
 
 // mustEmbedUnimplementedUserServiceServer требуется для реализации интерфейса gRPC.
 func (s *UserServer) mustEmbedUnimplementedUserServiceServer() {}
+
+func newUser(user model.IUser) *User {
+	return &User{
+		ID:        user.GetID(),
+		FirstName: user.GetFirstName(),
+		LastName:  user.GetLastName(),
+		Email:     user.GetEmail(),
+		Age:       int32(user.GetAge()),
+		TimeZone:  user.GetTimeZone(),
+	}
+}
+
+// modelUser преобразует сообщение gRPC в пользователя приложения.
+func modelUser(user *User) *model.User {
+	return &model.User{
+		ID:        user.GetID(),
+		FirstName: user.GetFirstName(),
+		LastName:  user.GetLastName(),
+		Email:     user.GetEmail(),
+		Age:       int64(user.GetAge()),
+		TimeZone:  user.GetTimeZone(),
+	}
+}
@@ -0,0 +1,136 @@
+package servergrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// GatewayPrefix префикс путей REST API, которые обслуживает HTTP/JSON шлюз.
+// Совпадает с аннотациями google.api.http в EventService.proto.
+const GatewayPrefix = "/api/"
+
+// collections пути коллекций REST API. Ресурс в ответе на запрос к коллекции - созданный ресурс,
+// списки возвращаются сообщениями без ID.
+var collections = map[string]bool{
+	"/api/v1/events":    true,
+	"/api/v1/users":     true,
+	"/api/v1/calendars": true,
+}
+
+// NewGateway создает HTTP/JSON шлюз к gRPC серверу с указанной конфигурацией.
+// Запросы шлюза проходят через интерцепторы gRPC сервера, соединение закрывается при отмене ctx.
+func NewGateway(ctx context.Context, config server.Config) (http.Handler, error) {
+	conn, err := grpc.NewClient(net.JoinHostPort(config.GetHost(), config.GetPort()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return newGatewayMux(ctx, conn)
+}
+
+// newGatewayMux регистрирует обработчики шлюза для всех сервисов поверх соединения conn.
+// Ответы содержат все поля сообщений, включая пустые.
+func newGatewayMux(ctx context.Context, conn *grpc.ClientConn) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(routingErrorHandler),
+		runtime.WithForwardResponseOption(created),
+	)
+
+	if err := api.RegisterEventServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := api.RegisterUserServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := api.RegisterCalendarServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
+
+// created отвечает на создание ресурса кодом 201 с адресом ресурса в заголовке Location.
+func created(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+	pattern, _ := runtime.HTTPPathPattern(ctx)
+	resource, ok := msg.(interface{ GetID() string })
+	if !collections[pattern] || !ok {
+		return nil
+	}
+
+	w.Header().Set("Location", pattern+"/"+resource.GetID())
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+// routingErrorHandler отправляет ошибку маршрутизации шлюза с ее кодом HTTP: 404 для неизвестного пути
+// и 405 для неподдерживаемого метода.
+func routingErrorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler,
+	w http.ResponseWriter, _ *http.Request, code int,
+) {
+	http.Error(w, http.StatusText(code), code)
+}
+
+// errorHandler отправляет ошибку вызова с кодом HTTP, соответствующим коду gRPC.
+// Ошибки валидации отправляются с кодом 422 в формате JSON со списком всех некорректных полей,
+// остальные ошибки - текстом.
+func errorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler,
+	w http.ResponseWriter, _ *http.Request, err error,
+) {
+	code := 0
+	var statusErr *runtime.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		code, err = statusErr.HTTPStatus, statusErr.Err
+	}
+
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		fields := make([]app.FieldError, 0, len(badRequest.GetFieldViolations()))
+		for _, violation := range badRequest.GetFieldViolations() {
+			fields = append(fields, app.FieldError{Field: violation.GetField(), Message: violation.GetDescription()})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(struct {
+			Error  string           `json:"error"`
+			Fields []app.FieldError `json:"fields"`
+		}{
+			Error:  "validation failed",
+			Fields: fields,
+		})
+		return
+	}
+
+	if code == 0 {
+		code = runtime.HTTPStatusFromCode(st.Code())
+	}
+	http.Error(w, st.Message(), code)
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -35,3 +39,135 @@ func LoggingInterceptor(logger server.Logger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamLoggingInterceptor - middleware gRPC интерцептор для логирования потоковых вызовов.
+func StreamLoggingInterceptor(logger server.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		logger.Info("gRPC stream started", "method", info.FullMethod)
+
+		err := handler(srv, ss)
+
+		logger.Info("gRPC stream finished", "method", info.FullMethod,
+			"error", err, "duration", time.Since(start))
+
+		if err != nil {
+			st, _ := status.FromError(err)
+			logger.Error("gRPC stream error",
+				"method", info.FullMethod, "error", st.Message(), "code", st.Code())
+		}
+
+		return err
+	}
+}
+
+// AuthInterceptor - middleware gRPC интерцептор, проверяющий JWT из метаданных authorization.
+// Аутентифицированный пользователь передается обработчику через контекст.
+func AuthInterceptor(authenticator server.Authenticator) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor - middleware gRPC интерцептор, проверяющий JWT в потоковых вызовах.
+func StreamAuthInterceptor(authenticator server.Authenticator) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// methodScopes области доступа, необходимые для вызова методов.
+var methodScopes = map[string]string{
+	api.EventService_SelectEvents_FullMethodName:              auth.ScopeEventsRead,
+	api.EventService_GetEvent_FullMethodName:                  auth.ScopeEventsRead,
+	api.EventService_SearchEvents_FullMethodName:              auth.ScopeEventsRead,
+	api.EventService_ExportEvents_FullMethodName:              auth.ScopeEventsRead,
+	api.EventService_SelectEventsForDay_FullMethodName:        auth.ScopeEventsRead,
+	api.EventService_SelectEventsForWeek_FullMethodName:       auth.ScopeEventsRead,
+	api.EventService_SelectEventsForMonth_FullMethodName:      auth.ScopeEventsRead,
+	api.EventService_WatchEvents_FullMethodName:               auth.ScopeEventsRead,
+	api.EventService_CreateEvent_FullMethodName:               auth.ScopeEventsWrite,
+	api.EventService_UpdateEvent_FullMethodName:               auth.ScopeEventsWrite,
+	api.EventService_PatchEvent_FullMethodName:                auth.ScopeEventsWrite,
+	api.EventService_DeleteEvent_FullMethodName:               auth.ScopeEventsWrite,
+	api.EventService_ImportEvents_FullMethodName:              auth.ScopeEventsWrite,
+	api.EventService_ListAttendees_FullMethodName:             auth.ScopeEventsRead,
+	api.EventService_InviteAttendee_FullMethodName:            auth.ScopeEventsWrite,
+	api.EventService_RemoveAttendee_FullMethodName:            auth.ScopeEventsWrite,
+	api.EventService_RespondToInvitation_FullMethodName:       auth.ScopeEventsWrite,
+	api.EventService_FreeBusy_FullMethodName:                  auth.ScopeEventsRead,
+	api.CalendarService_SelectCalendars_FullMethodName:        auth.ScopeEventsRead,
+	api.CalendarService_GetCalendar_FullMethodName:            auth.ScopeEventsRead,
+	api.CalendarService_CreateCalendar_FullMethodName:         auth.ScopeEventsWrite,
+	api.CalendarService_UpdateCalendar_FullMethodName:         auth.ScopeEventsWrite,
+	api.CalendarService_DeleteCalendar_FullMethodName:         auth.ScopeEventsWrite,
+	api.CalendarService_ShareCalendar_FullMethodName:          auth.ScopeEventsWrite,
+	api.CalendarService_UnshareCalendar_FullMethodName:        auth.ScopeEventsWrite,
+	api.UserService_SelectUsers_FullMethodName:                auth.ScopeUsersAdmin,
+	api.UserService_CreateUser_FullMethodName:                 auth.ScopeUsersAdmin,
+	api.UserService_DeleteUser_FullMethodName:                 auth.ScopeUsersAdmin,
+	api.UserService_GetWorkingHours_FullMethodName:            auth.ScopeEventsRead,
+	api.UserService_SetWorkingHours_FullMethodName:            auth.ScopeEventsWrite,
+	api.UserService_DeleteWorkingHours_FullMethodName:         auth.ScopeEventsWrite,
+	api.UserService_GetNotificationSettings_FullMethodName:    auth.ScopeEventsRead,
+	api.UserService_SetNotificationSettings_FullMethodName:    auth.ScopeEventsWrite,
+	api.UserService_DeleteNotificationSettings_FullMethodName: auth.ScopeEventsWrite,
+}
+
+// authenticate проверяет токен из метаданных "authorization: Bearer <token>" и область доступа метода
+// и возвращает контекст с аутентифицированным пользователем. Методы без области доступа в methodScopes
+// недоступны клиентам, ограниченным областями доступа.
+func authenticate(ctx context.Context, authenticator server.Authenticator, method string) (context.Context, error) {
+	var header string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		header = values[0]
+	}
+
+	token, err := auth.BearerToken(header)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	principal, err := authenticator.Authenticate(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if scope, ok := methodScopes[method]; principal.Scopes != nil && (!ok || !principal.HasScope(scope)) {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed for the api key scopes", method)
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// authenticatedStream подменяет контекст потока контекстом с аутентифицированным пользователем.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,113 @@
+package servergrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc/api"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tokens аутентификация по заранее известным токенам.
+type tokens map[string]auth.Principal
+
+func (t tokens) Authenticate(_ context.Context, token string) (auth.Principal, error) {
+	principal, ok := t[token]
+	if !ok {
+		return auth.Principal{}, errors.New("unknown token")
+	}
+	return principal, nil
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	application := app.New(memorystorage.New(), *log)
+	authenticator := tokens{
+		"alice":  {UserID: "alice"},
+		"writer": {KeyID: "writer", Admin: true, Scopes: []string{auth.ScopeEventsWrite}},
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(AuthInterceptor(authenticator)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(authenticator)),
+	)
+	api.RegisterEventServiceServer(grpcServer, api.NewEventServer(log, application))
+	listener := bufconn.Listen(bufSize)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := api.NewEventServiceClient(conn)
+
+	ctx := context.Background()
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+
+	t.Run("missing or invalid token", func(t *testing.T) {
+		for _, ctx := range []context.Context{ctx, withToken("unknown")} {
+			_, err := client.SelectEvents(ctx, &api.EventsRequest{})
+			require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			stream, err := client.WatchEvents(ctx, &api.WatchRequest{})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+	})
+
+	t.Run("principal is passed to application", func(t *testing.T) {
+		beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+		created, err := client.CreateEvent(withToken("alice"), &api.Event{
+			Title:      "Планерка",
+			BeginningT: timestamppb.New(beginning),
+			FinishT:    timestamppb.New(beginning.Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "alice", created.GetUserID())
+
+		stream, err := client.WatchEvents(withToken("alice"), &api.WatchRequest{UserID: "bob"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("api key scopes", func(t *testing.T) {
+		beginning := time.Date(2024, time.June, 4, 10, 0, 0, 0, time.UTC)
+		created, err := client.CreateEvent(withToken("writer"), &api.Event{
+			Title:      "Выгрузка",
+			BeginningT: timestamppb.New(beginning),
+			FinishT:    timestamppb.New(beginning.Add(time.Hour)),
+			UserID:     "alice",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "alice", created.GetUserID())
+
+		_, err = client.SelectEvents(withToken("writer"), &api.EventsRequest{})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		stream, err := client.WatchEvents(withToken("writer"), &api.WatchRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
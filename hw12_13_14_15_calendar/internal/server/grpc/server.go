@@ -17,9 +17,20 @@ type Server struct {
 	srv     *grpc.Server
 }
 
-func NewServer(logger server.Logger, app server.Application, config server.Config) *Server {
+// NewServer создает gRPC сервер. Если authenticator равен nil, вызовы обрабатываются без аутентификации.
+func NewServer(
+	logger server.Logger, app server.Application, config server.Config, authenticator server.Authenticator,
+) *Server {
+	unary := []grpc.UnaryServerInterceptor{LoggingInterceptor(logger)}
+	stream := []grpc.StreamServerInterceptor{StreamLoggingInterceptor(logger)}
+	if authenticator != nil {
+		unary = append(unary, AuthInterceptor(authenticator))
+		stream = append(stream, StreamAuthInterceptor(authenticator))
+	}
+
 	srv := grpc.NewServer(
-		grpc.UnaryInterceptor(LoggingInterceptor(logger)),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
 	)
 
 	eventServer := api.NewEventServer(logger, app)
@@ -28,6 +39,9 @@ func NewServer(logger server.Logger, app server.Application, config server.Confi
 	userServer := api.NewUserServer(logger, app)
 	api.RegisterUserServiceServer(srv, userServer)
 
+	calendarServer := api.NewCalendarServer(logger, app)
+	api.RegisterCalendarServiceServer(srv, calendarServer)
+
 	return &Server{
 		logger:  logger,
 		app:     app,
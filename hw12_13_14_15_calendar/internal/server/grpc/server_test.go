@@ -1,8 +1,12 @@
 package servergrpc
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -13,8 +17,11 @@ import (
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc/api"
 	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -38,6 +45,9 @@ func startTestGRPCServer(t *testing.T, logger server.Logger, application *app.Ca
 	userServer := api.NewUserServer(logger, application)
 	api.RegisterUserServiceServer(grpcServer, userServer)
 
+	calendarServer := api.NewCalendarServer(logger, application)
+	api.RegisterCalendarServiceServer(grpcServer, calendarServer)
+
 	errChan := make(chan error, 1)
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -87,6 +97,38 @@ func TestGRPCServer(t *testing.T) {
 		eventCase(ctx, t, client, userClient)
 	})
 
+	t.Run("CalendarCase", func(t *testing.T) {
+		calendarCase(ctx, t, api.NewCalendarServiceClient(conn), client, userClient)
+	})
+
+	t.Run("AttendeeCase", func(t *testing.T) {
+		attendeeCase(ctx, t, client, userClient)
+	})
+
+	t.Run("FreeBusyCase", func(t *testing.T) {
+		freeBusyCase(ctx, t, client, userClient)
+	})
+
+	t.Run("TimeZoneCase", func(t *testing.T) {
+		timeZoneCase(ctx, t, client, userClient)
+	})
+
+	t.Run("ReminderCase", func(t *testing.T) {
+		reminderCase(ctx, t, client, userClient)
+	})
+
+	t.Run("ValidationCase", func(t *testing.T) {
+		validationCase(ctx, t, client)
+	})
+
+	t.Run("WatchCase", func(t *testing.T) {
+		watchCase(ctx, t, client)
+	})
+
+	t.Run("GatewayCase", func(t *testing.T) {
+		gatewayCase(ctx, t, conn)
+	})
+
 	grpcServer.GracefulStop()
 }
 
@@ -100,11 +142,12 @@ func userCase(ctx context.Context, t *testing.T, client api.UserServiceClient) {
 		Age:       30,
 	}
 
-	_, err := client.CreateUser(ctx, user)
+	created, err := client.CreateUser(ctx, user)
 	require.NoError(t, err)
+	require.NotEmpty(t, created.GetID())
 
 	// Select users
-	response, err := client.SelectUsers(ctx, &api.Void{})
+	response, err := client.SelectUsers(ctx, &api.UsersRequest{})
 	require.NoError(t, err)
 
 	users := response.Users
@@ -129,7 +172,7 @@ func eventCase(ctx context.Context, t *testing.T, client api.EventServiceClient,
 	_, err := userClient.CreateUser(ctx, user)
 	require.NoError(t, err)
 
-	response, err := userClient.SelectUsers(ctx, &api.Void{})
+	response, err := userClient.SelectUsers(ctx, &api.UsersRequest{})
 	require.NoError(t, err)
 
 	users := response.Users
@@ -142,22 +185,413 @@ func eventCase(ctx context.Context, t *testing.T, client api.EventServiceClient,
 		Description:   "desc",
 		BeginningT:    timestamppb.New(time.Now()),
 		FinishT:       timestamppb.New(time.Now().Add(time.Hour)),
-		NotificationT: timestamppb.New(time.Now().Add(30 * time.Minute)),
+		NotificationT: timestamppb.New(time.Now().Add(-30 * time.Minute)),
 		UserID:        userID,
 	}
 
-	_, err = client.CreateEvent(ctx, event)
+	created, err := client.CreateEvent(ctx, event)
 	require.NoError(t, err)
+	require.NotEmpty(t, created.GetID())
+	require.Equal(t, event.GetTitle(), created.GetTitle())
 
 	// Select events
-	eventResponse, err := client.SelectEvents(ctx, &api.Void{})
+	eventResponse, err := client.SelectEvents(ctx, &api.EventsRequest{})
 	require.NoError(t, err)
 
 	events := eventResponse.Events
 	require.NotEmpty(t, events)
 
+	// Select events page by page
+	second := &api.Event{
+		Title:      "testevent 2",
+		BeginningT: timestamppb.New(event.GetBeginning().Add(2 * time.Hour)),
+		FinishT:    timestamppb.New(event.GetFinish().Add(2 * time.Hour)),
+		UserID:     userID,
+	}
+	secondCreated, err := client.CreateEvent(ctx, second)
+	require.NoError(t, err)
+
+	request := &api.EventsRequest{UserID: userID, Title: "TESTEVENT", OrderBy: "-beginning", PageSize: 1}
+	eventResponse, err = client.SelectEvents(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, eventResponse.Events, 1)
+	require.Equal(t, secondCreated.GetID(), eventResponse.Events[0].GetID())
+	require.NotEmpty(t, eventResponse.GetNextPageToken())
+
+	request.PageToken = eventResponse.GetNextPageToken()
+	eventResponse, err = client.SelectEvents(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, eventResponse.Events, 1)
+	require.Equal(t, created.GetID(), eventResponse.Events[0].GetID())
+	require.Empty(t, eventResponse.GetNextPageToken())
+
+	_, err = client.SelectEvents(ctx, &api.EventsRequest{OrderBy: "finish"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Search events
+	searchResponse, err := client.SearchEvents(ctx, &api.SearchRequest{Query: "testevent 2", UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, searchResponse.GetResults(), 1)
+	require.Equal(t, secondCreated.GetID(), searchResponse.GetResults()[0].GetEvent().GetID())
+	require.Equal(t, "<mark>testevent</mark> <mark>2</mark>", searchResponse.GetResults()[0].GetSnippet())
+
+	_, err = client.SearchEvents(ctx, &api.SearchRequest{Query: " "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteEvent(ctx, &api.Event{ID: secondCreated.GetID()})
+	require.NoError(t, err)
+
 	// Delete event
 	eventID := &api.Event{ID: events[0].ID}
 	_, err = client.DeleteEvent(ctx, eventID)
 	require.NoError(t, err)
 }
+
+func calendarCase(ctx context.Context, t *testing.T, client api.CalendarServiceClient,
+	eventClient api.EventServiceClient, userClient api.UserServiceClient,
+) {
+	t.Helper()
+	owner, err := userClient.CreateUser(ctx, &api.User{FirstName: "owner"})
+	require.NoError(t, err)
+	reader, err := userClient.CreateUser(ctx, &api.User{FirstName: "reader"})
+	require.NoError(t, err)
+
+	// Create and share calendar
+	created, err := client.CreateCalendar(ctx, &api.Calendar{Name: "team", OwnerID: owner.GetID()})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.GetID())
+	require.Equal(t, "UTC", created.GetTimeZone())
+
+	_, err = client.ShareCalendar(ctx, &api.ACLEntry{CalendarID: created.GetID(), UserID: reader.GetID(), Role: "write"})
+	require.NoError(t, err)
+	_, err = client.ShareCalendar(ctx, &api.ACLEntry{CalendarID: created.GetID(), UserID: reader.GetID(), Role: "none"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	selected, err := client.GetCalendar(ctx, &api.Calendar{ID: created.GetID()})
+	require.NoError(t, err)
+	require.Len(t, selected.GetACL(), 1)
+	require.Equal(t, "write", selected.GetACL()[0].GetRole())
+
+	// Event of calendar
+	beginning := time.Date(2024, time.August, 1, 9, 0, 0, 0, time.UTC)
+	event, err := eventClient.CreateEvent(ctx, &api.Event{
+		Title: "sync", UserID: reader.GetID(), CalendarID: created.GetID(),
+		BeginningT: timestamppb.New(beginning), FinishT: timestamppb.New(beginning.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, created.GetID(), event.GetCalendarID())
+
+	events, err := eventClient.SelectEvents(ctx, &api.EventsRequest{CalendarID: created.GetID()})
+	require.NoError(t, err)
+	require.Len(t, events.GetEvents(), 1)
+
+	// Unshare and delete calendar
+	_, err = client.UnshareCalendar(ctx, &api.ACLEntry{CalendarID: created.GetID(), UserID: reader.GetID()})
+	require.NoError(t, err)
+	_, err = client.UnshareCalendar(ctx, &api.ACLEntry{CalendarID: created.GetID(), UserID: reader.GetID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteCalendar(ctx, &api.Calendar{ID: created.GetID()})
+	require.NoError(t, err)
+	_, err = client.GetCalendar(ctx, &api.Calendar{ID: created.GetID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	calendars, err := client.SelectCalendars(ctx, &api.Void{})
+	require.NoError(t, err)
+	require.Empty(t, calendars.GetCalendars())
+}
+
+func attendeeCase(ctx context.Context, t *testing.T, client api.EventServiceClient, userClient api.UserServiceClient) {
+	t.Helper()
+	guest, err := userClient.CreateUser(ctx, &api.User{FirstName: "guest"})
+	require.NoError(t, err)
+
+	beginning := time.Date(2024, time.September, 1, 18, 0, 0, 0, time.UTC)
+	event, err := client.CreateEvent(ctx, &api.Event{
+		Title: "party", UserID: "host",
+		BeginningT: timestamppb.New(beginning), FinishT: timestamppb.New(beginning.Add(4 * time.Hour)),
+	})
+	require.NoError(t, err)
+
+	attendee, err := client.InviteAttendee(ctx, &api.Attendee{EventID: event.GetID(), UserID: guest.GetID()})
+	require.NoError(t, err)
+	require.Equal(t, "needs-action", attendee.GetStatus())
+
+	_, err = client.RespondToInvitation(ctx, &api.Attendee{EventID: event.GetID(), UserID: guest.GetID(), Status: "maybe"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.RespondToInvitation(ctx, &api.Attendee{
+		EventID: event.GetID(), UserID: guest.GetID(), Status: "tentative",
+	})
+	require.NoError(t, err)
+
+	attendees, err := client.ListAttendees(ctx, &api.Event{ID: event.GetID()})
+	require.NoError(t, err)
+	require.Len(t, attendees.GetAttendees(), 1)
+	require.Equal(t, "tentative", attendees.GetAttendees()[0].GetStatus())
+
+	_, err = client.RemoveAttendee(ctx, &api.Attendee{EventID: event.GetID(), UserID: guest.GetID()})
+	require.NoError(t, err)
+	_, err = client.RemoveAttendee(ctx, &api.Attendee{EventID: event.GetID(), UserID: guest.GetID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteEvent(ctx, &api.Event{ID: event.GetID()})
+	require.NoError(t, err)
+}
+
+func freeBusyCase(ctx context.Context, t *testing.T, client api.EventServiceClient, userClient api.UserServiceClient) {
+	t.Helper()
+	user, err := userClient.CreateUser(ctx, &api.User{FirstName: "busy"})
+	require.NoError(t, err)
+
+	_, err = userClient.GetWorkingHours(ctx, &api.User{ID: user.GetID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = userClient.SetWorkingHours(ctx, &api.WorkingHours{
+		UserID: user.GetID(), TimeZone: "Europe/Moscow", Start: "09:00", End: "18:00", Days: []int32{1, 2, 3, 4, 5, 6, 7},
+	})
+	require.NoError(t, err)
+	hours, err := userClient.GetWorkingHours(ctx, &api.User{ID: user.GetID()})
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", hours.GetTimeZone())
+	require.Len(t, hours.GetDays(), 7)
+
+	// Рабочий день 06:00-15:00 UTC.
+	day := time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)
+	_, err = client.CreateEvent(ctx, &api.Event{
+		Title: "focus", UserID: user.GetID(),
+		BeginningT: timestamppb.New(day.Add(6 * time.Hour)), FinishT: timestamppb.New(day.Add(13 * time.Hour)),
+	})
+	require.NoError(t, err)
+
+	freeBusy, err := client.FreeBusy(ctx, &api.FreeBusyRequest{
+		UserIDs: []string{user.GetID()}, From: timestamppb.New(day), To: timestamppb.New(day.AddDate(0, 0, 1)),
+		DurationMinutes: 60,
+	})
+	require.NoError(t, err)
+	require.Len(t, freeBusy.GetUsers(), 1)
+	require.Len(t, freeBusy.GetUsers()[0].GetBusy(), 1)
+	require.Len(t, freeBusy.GetSlots(), 2)
+	require.Equal(t, day.Add(13*time.Hour), freeBusy.GetSlots()[0].GetStart().AsTime())
+
+	_, err = client.FreeBusy(ctx, &api.FreeBusyRequest{UserIDs: []string{user.GetID()}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = userClient.DeleteWorkingHours(ctx, &api.User{ID: user.GetID()})
+	require.NoError(t, err)
+}
+
+func timeZoneCase(ctx context.Context, t *testing.T, client api.EventServiceClient, userClient api.UserServiceClient) {
+	t.Helper()
+	user, err := userClient.CreateUser(ctx, &api.User{FirstName: "tokyo", TimeZone: "Asia/Tokyo"})
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Tokyo", user.GetTimeZone())
+
+	// 20:00 UTC 1 октября - это утро 2 октября в Токио.
+	beginning := time.Date(2024, time.October, 1, 20, 0, 0, 0, time.UTC)
+	event, err := client.CreateEvent(ctx, &api.Event{
+		Title: "tokyo call", UserID: user.GetID(),
+		BeginningT: timestamppb.New(beginning), FinishT: timestamppb.New(beginning.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Tokyo", event.GetTimeZone())
+
+	contains := func(events *api.Events) bool {
+		for _, e := range events.GetEvents() {
+			if e.GetID() == event.GetID() {
+				return true
+			}
+		}
+		return false
+	}
+	date := timestamppb.New(time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC))
+	events, err := client.SelectEventsForDay(ctx, &api.DateRequest{Date: date, TimeZone: "Asia/Tokyo"})
+	require.NoError(t, err)
+	require.True(t, contains(events))
+	events, err = client.SelectEventsForDay(ctx, &api.DateRequest{Date: date})
+	require.NoError(t, err)
+	require.False(t, contains(events))
+
+	_, err = client.SelectEventsForMonth(ctx, &api.DateRequest{Date: date, TimeZone: "Mars/Olympus"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteEvent(ctx, &api.Event{ID: event.GetID()})
+	require.NoError(t, err)
+}
+
+func reminderCase(ctx context.Context, t *testing.T, client api.EventServiceClient, userClient api.UserServiceClient) {
+	t.Helper()
+	user, err := userClient.CreateUser(ctx, &api.User{FirstName: "reminded"})
+	require.NoError(t, err)
+
+	beginning := time.Date(2030, time.March, 4, 10, 0, 0, 0, time.UTC)
+	event, err := client.CreateEvent(ctx, &api.Event{
+		Title: "reminded", UserID: user.GetID(),
+		BeginningT: timestamppb.New(beginning), FinishT: timestamppb.New(beginning.Add(time.Hour)),
+		RemindersT: []*api.Reminder{{MinutesBefore: 60, Channel: "email"}, {MinutesBefore: 10}},
+	})
+	require.NoError(t, err)
+	require.Len(t, event.GetRemindersT(), 2)
+	require.NotEmpty(t, event.GetRemindersT()[0].GetID())
+	require.Equal(t, "email", event.GetRemindersT()[0].GetChannel())
+	require.Equal(t, beginning.Add(-time.Hour), event.GetRemindersT()[0].GetFireAt().AsTime())
+
+	_, err = client.CreateEvent(ctx, &api.Event{
+		Title: "reminded", UserID: user.GetID(),
+		BeginningT: timestamppb.New(beginning.AddDate(0, 0, 1)), FinishT: timestamppb.New(beginning.AddDate(0, 0, 1)),
+		RemindersT: []*api.Reminder{{MinutesBefore: 10, Channel: "sms"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteEvent(ctx, &api.Event{ID: event.GetID()})
+	require.NoError(t, err)
+}
+
+func validationCase(ctx context.Context, t *testing.T, client api.EventServiceClient) {
+	t.Helper()
+	beginning := time.Now()
+
+	// Create invalid event
+	event := &api.Event{
+		BeginningT:    timestamppb.New(beginning),
+		FinishT:       timestamppb.New(beginning.Add(-time.Hour)),
+		NotificationT: timestamppb.New(beginning.Add(time.Minute)),
+	}
+
+	_, err := client.CreateEvent(ctx, event)
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+
+	fields := make([]string, 0, len(badRequest.GetFieldViolations()))
+	for _, violation := range badRequest.GetFieldViolations() {
+		fields = append(fields, violation.GetField())
+	}
+	require.Equal(t, []string{"title", "finish", "notification"}, fields)
+}
+
+func watchCase(ctx context.Context, t *testing.T, client api.EventServiceClient) {
+	t.Helper()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.WatchEvents(watchCtx, &api.WatchRequest{
+		UserID: "watcher",
+		From:   timestamppb.New(beginning),
+		To:     timestamppb.New(beginning.AddDate(0, 0, 1)),
+	})
+	require.NoError(t, err)
+	// Заголовки приходят после подписки на изменения.
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	// Create events outside of and inside the window
+	_, err = client.CreateEvent(ctx, &api.Event{
+		Title:      "next day",
+		BeginningT: timestamppb.New(beginning.AddDate(0, 0, 1)),
+		FinishT:    timestamppb.New(beginning.AddDate(0, 0, 1).Add(time.Hour)),
+		UserID:     "watcher",
+	})
+	require.NoError(t, err)
+
+	created, err := client.CreateEvent(ctx, &api.Event{
+		Title:      "watched",
+		BeginningT: timestamppb.New(beginning),
+		FinishT:    timestamppb.New(beginning.Add(time.Hour)),
+		UserID:     "watcher",
+	})
+	require.NoError(t, err)
+
+	_, err = client.DeleteEvent(ctx, &api.Event{ID: created.GetID()})
+	require.NoError(t, err)
+
+	change, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, api.ChangeType_CHANGE_TYPE_CREATED, change.GetType())
+	require.Equal(t, created.GetID(), change.GetEvent().GetID())
+
+	change, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, api.ChangeType_CHANGE_TYPE_DELETED, change.GetType())
+	require.Equal(t, "watched", change.GetEvent().GetTitle())
+
+	// Invalid window
+	stream, err = client.WatchEvents(ctx, &api.WatchRequest{From: timestamppb.New(beginning)})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func gatewayCase(ctx context.Context, t *testing.T, conn *grpc.ClientConn) {
+	t.Helper()
+	gateway, err := newGatewayMux(ctx, conn)
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		gateway.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var created struct {
+		ID     string `json:"id"`
+		Title  string `json:"title"`
+		UserID string `json:"userId"`
+	}
+
+	// Create user
+	rec := do(http.MethodPost, "/api/v1/users", `{"firstName": "gateway", "email": "gateway@test.com"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.NotEmpty(t, created.ID)
+	userID := created.ID
+
+	// Create event
+	rec = do(http.MethodPost, "/api/v1/events", `{"title": "gateway event", "userId": "`+userID+`",
+		"beginning": "2024-03-01T10:00:00Z", "finish": "2024-03-01T11:00:00Z", "notification": "2024-03-01T09:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.NotEmpty(t, created.ID)
+	require.Equal(t, "gateway event", created.Title)
+	require.Equal(t, userID, created.UserID)
+	eventID := created.ID
+	require.Equal(t, "/api/v1/events/"+eventID, rec.Header().Get("Location"))
+
+	// Patch event
+	rec = do(http.MethodPatch, "/api/v1/events/"+eventID, `{"title": "patched event"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.Equal(t, "patched event", created.Title)
+	require.Equal(t, userID, created.UserID)
+
+	// Get event
+	rec = do(http.MethodGet, "/api/v1/events/"+eventID, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), `"beginning":"2024-03-01T10:00:00Z"`)
+
+	// Select events for day
+	rec = do(http.MethodGet, "/api/v1/events/day?date=2024-03-01T00:00:00Z", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), eventID)
+
+	// Invalid update
+	rec = do(http.MethodPut, "/api/v1/events/"+eventID, `{"title": "gateway event",
+		"beginning": "2024-03-01T10:00:00Z", "finish": "2024-03-01T09:00:00Z", "notification": "2024-03-01T09:00:00Z"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	require.JSONEq(t, `{"error": "validation failed", "fields": [{"field": "finish",
+		"message": "must not be before beginning"}]}`, rec.Body.String())
+
+	// Delete event
+	rec = do(http.MethodDelete, "/api/v1/events/"+eventID, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = do(http.MethodDelete, "/api/v1/events/"+eventID, "")
+	require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,262 @@
+package serverhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// maxCalendarSize ограничивает размер загружаемого файла iCalendar.
+const maxCalendarSize = 10 << 20
+
+// alias устаревший маршрут с глаголом в пути, оставленный как псевдоним REST API.
+// Псевдоним принимает любой метод, в method указан метод из спецификации.
+// rewrite переводит запрос в запрос к маршруту REST API, который обслуживает шлюз gRPC.
+type alias struct {
+	method  string
+	path    string
+	rewrite func(w http.ResponseWriter, r *http.Request) (*http.Request, error)
+}
+
+// legacyAliases возвращает устаревшие маршруты. Параметр {id} в конце пути соответствует поддереву ServeMux.
+func legacyAliases() []alias {
+	return []alias{
+		{http.MethodPost, "/create/user", moveTo(http.MethodPost, "/users")},
+		{http.MethodDelete, "/delete/user/{id}", moveToID(http.MethodDelete, "/users/")},
+		{http.MethodPost, "/create/event", moveTo(http.MethodPost, "/events")},
+
+		{http.MethodGet, "/export/events", exportEvents},
+		{http.MethodPost, "/import/events", importEvents},
+		{http.MethodPut, "/update/event", updateEvent},
+		{http.MethodDelete, "/delete/event/{id}", moveToID(http.MethodDelete, "/events/")},
+	}
+}
+
+// legacySelects возвращает устаревшие выборки. В отличие от REST API они отвечают полным списком
+// без пагинации и обертки, поэтому обслуживаются приложением напрямую, а не шлюзом gRPC.
+func legacySelects(h *handler) []endpoint {
+	return []endpoint{
+		{http.MethodGet, "/select/users", auth.ScopeUsersAdmin, h.selectUsers},
+		{http.MethodGet, "/select/events", auth.ScopeEventsRead, h.selectEvents},
+		{
+			http.MethodGet, "/select/events/day", auth.ScopeEventsRead,
+			h.selectEventsFor("date", h.app.SelectEventsForDay),
+		},
+		{
+			http.MethodGet, "/select/events/week", auth.ScopeEventsRead,
+			h.selectEventsFor("startDate", h.app.SelectEventsForWeek),
+		},
+		{
+			http.MethodGet, "/select/events/month", auth.ScopeEventsRead,
+			h.selectEventsFor("startDate", h.app.SelectEventsForMonth),
+		},
+	}
+}
+
+// selectUsers обрабатывает запрос на получение списка всех пользователей.
+func (h *handler) selectUsers(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("Selecting users")
+	users, err := h.app.SelectUsers(r.Context())
+	if err != nil {
+		h.logger.Error("selectUsers: " + err.Error())
+		sendError(w, err)
+		return
+	}
+	if err := sendList(w, users); err != nil {
+		h.logger.Error("selectUsers: " + err.Error())
+	}
+	h.logger.Info("Users selected")
+}
+
+// selectEvents обрабатывает запрос на получение списка всех событий.
+func (h *handler) selectEvents(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("Selecting events")
+	events, err := h.app.SelectEvents(r.Context())
+	if err != nil {
+		h.logger.Error("selectEvents: " + err.Error())
+		sendError(w, err)
+		return
+	}
+	if err := sendList(w, events); err != nil {
+		h.logger.Error("selectEvents: " + err.Error())
+	}
+	h.logger.Info("Events selected")
+}
+
+// selectEventsFor возвращает обработчик выборки событий за период, начинающийся в дату из параметра key
+// в формате 2006-01-02. Дата отсчитывается в часовом поясе timeZone, без него - пользователя или UTC.
+func (h *handler) selectEventsFor(
+	key string, sel func(context.Context, time.Time) ([]model.IEvent, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		loc, err := h.app.Location(ctx, r.URL.Query().Get("timeZone"))
+		if err != nil {
+			h.logger.Error(r.URL.Path + ": " + err.Error())
+			sendError(w, err)
+			return
+		}
+		date, err := parseDateFromQuery(r, key, loc)
+		if err != nil {
+			h.logger.Error(r.URL.Path + ": " + err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		h.logger.Debug("Selecting events for period starting: " + date.String())
+		events, err := sel(ctx, date)
+		if err != nil {
+			h.logger.Error(r.URL.Path + ": " + err.Error())
+			sendError(w, err)
+			return
+		}
+		if err := sendList(w, events); err != nil {
+			h.logger.Error(r.URL.Path + ": " + err.Error())
+		}
+		h.logger.Info("Events for period selected")
+	}
+}
+
+// serveAlias переписывает запрос к устаревшему маршруту и передает его маршруту REST API.
+// Ошибка разбора запроса отправляется с кодом 400.
+func (s *Server) serveAlias(a alias) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rewritten, err := a.rewrite(w, r)
+		if err != nil {
+			s.logger.Error(a.path + ": " + err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mux.ServeHTTP(w, rewritten)
+	}
+}
+
+// moveTo возвращает переписывание запроса в метод method и путь REST API path с теми же параметрами и телом.
+func moveTo(method, path string) func(http.ResponseWriter, *http.Request) (*http.Request, error) {
+	return func(_ http.ResponseWriter, r *http.Request) (*http.Request, error) {
+		return rewrite(r, method, apiPrefix+path, r.URL.RawQuery, r.Body), nil
+	}
+}
+
+// moveToID возвращает переписывание запроса в метод method и путь REST API с ID ресурса из последнего сегмента.
+func moveToID(method, prefix string) func(http.ResponseWriter, *http.Request) (*http.Request, error) {
+	return func(_ http.ResponseWriter, r *http.Request) (*http.Request, error) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if id == "" {
+			return nil, errors.New("missing ID in path")
+		}
+		return rewrite(r, method, apiPrefix+prefix+url.PathEscape(id), r.URL.RawQuery, r.Body), nil
+	}
+}
+
+// updateEvent переписывает замену события с ID в теле запроса в PUT /api/v1/events/{id}.
+func updateEvent(_ http.ResponseWriter, r *http.Request) (*http.Request, error) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	if event.ID == "" {
+		return nil, errors.New("missing event ID in body")
+	}
+	return rewrite(r, http.MethodPut, apiPrefix+"/events/"+url.PathEscape(event.ID), "", bytes.NewReader(body)), nil
+}
+
+// exportEvents переписывает выгрузку событий пользователя userId в GET /api/v1/users/{userId}/ical.
+func exportEvents(_ http.ResponseWriter, r *http.Request) (*http.Request, error) {
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		return nil, errors.New("missing userId in query")
+	}
+	return rewrite(r, http.MethodGet, apiPrefix+"/users/"+url.PathEscape(userID)+"/ical", "", http.NoBody), nil
+}
+
+// importEvents переписывает загрузку файла iCalendar для пользователя userId
+// в POST /api/v1/users/{userId}/ical с содержимым файла в поле data.
+func importEvents(w http.ResponseWriter, r *http.Request) (*http.Request, error) {
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		return nil, errors.New("missing userId in query")
+	}
+	data, err := readCalendarFromBody(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(struct {
+		Data []byte `json:"data"`
+	}{Data: data})
+	if err != nil {
+		return nil, err
+	}
+	rewritten := rewrite(r, http.MethodPost, apiPrefix+"/users/"+url.PathEscape(userID)+"/ical", "",
+		bytes.NewReader(body))
+	rewritten.Header.Set("Content-Type", "application/json")
+	return rewritten, nil
+}
+
+// rewrite возвращает копию запроса с указанными методом, путем, параметрами и телом.
+func rewrite(r *http.Request, method, path, rawQuery string, body io.Reader) *http.Request {
+	rewritten := r.Clone(r.Context())
+	rewritten.Method = method
+	rewritten.URL.Path, rewritten.URL.RawPath, rewritten.URL.RawQuery = path, "", rawQuery
+	rewritten.RequestURI = rewritten.URL.RequestURI()
+	rewritten.Body = io.NopCloser(body)
+	rewritten.ContentLength = -1
+	return rewritten
+}
+
+// readCalendarFromBody читает файл iCalendar из тела запроса или из поля "file" multipart-формы.
+func readCalendarFromBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	r.Body = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	return io.ReadAll(r.Body)
+}
+
+// parseDateFromQuery извлекает и парсит дату из параметров запроса как полночь в часовом поясе loc.
+func parseDateFromQuery(r *http.Request, key string, loc *time.Location) (time.Time, error) {
+	dateStr := r.URL.Query().Get(key)
+	if dateStr == "" {
+		return time.Time{}, errors.New("missing " + key + " in query")
+	}
+	return time.ParseInLocation("2006-01-02", dateStr, loc)
+}
+
+// sendList отправляет список в формате JSON. Пустой список отправляется как [], а не null.
+func sendList[T any](w http.ResponseWriter, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+	return sendData(w, data)
+}
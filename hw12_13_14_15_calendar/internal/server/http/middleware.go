@@ -1,11 +1,18 @@
 package serverhttp
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
 )
 
@@ -50,6 +57,136 @@ func (m *middleware) logging() *middleware {
 	return m
 }
 
+// validation добавляет middleware, проверяющий запросы и ответы по спецификации OpenAPI.
+// Запрос, не соответствующий схеме, отклоняется с кодом 400. Ответ, не соответствующий схеме,
+// заменяется ошибкой 500. Маршруты и методы, которых нет в спецификации, пропускаются без проверки.
+// Потоковые ответы операций с расширением x-stream передаются клиенту сразу и не проверяются.
+func (m *middleware) validation(specRouter routers.Router) *middleware {
+	curHandler := m.Handler
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	m.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := specRouter.FindRoute(r)
+		if err != nil {
+			curHandler.ServeHTTP(w, r)
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), requestInput); err != nil {
+			m.logger.Error(fmt.Sprintf("invalid request %s %s: %s", r.Method, r.URL.Path, err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if stream, _ := route.Operation.Extensions["x-stream"].(bool); stream {
+			curHandler.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &recordingResponseWriter{header: make(http.Header), statusCode: http.StatusOK}
+		curHandler.ServeHTTP(recorder, r)
+
+		err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 recorder.statusCode,
+			Header:                 recorder.header,
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options:                options,
+		})
+		if err != nil {
+			m.logger.Error(fmt.Sprintf("invalid response %s %s: %s", r.Method, r.URL.Path, err))
+			http.Error(w, "invalid response", http.StatusInternalServerError)
+			return
+		}
+
+		for key, values := range recorder.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(recorder.statusCode)
+		if _, err := w.Write(recorder.body.Bytes()); err != nil {
+			m.logger.Error(fmt.Sprintf("error writing response: %s", err))
+		}
+	})
+
+	return m
+}
+
+// publicPaths служебные пути, доступные без токена доступа.
+var publicPaths = map[string]bool{
+	"/route":        true,
+	"/health":       true,
+	"/openapi.json": true,
+}
+
+// authentication добавляет middleware, проверяющий JWT из заголовка Authorization и передающий
+// аутентифицированного пользователя обработчикам через контекст запроса.
+// Запрос без действительного токена отклоняется с кодом 401, кроме запросов к publicPaths.
+func (m *middleware) authentication(authenticator server.Authenticator) *middleware {
+	curHandler := m.Handler
+
+	m.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			curHandler.ServeHTTP(w, r)
+			return
+		}
+
+		token, err := auth.BearerToken(r.Header.Get("Authorization"))
+		if err == nil {
+			var principal auth.Principal
+			if principal, err = authenticator.Authenticate(r.Context(), token); err == nil {
+				curHandler.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+				return
+			}
+		}
+
+		m.logger.Error(fmt.Sprintf("unauthenticated request %s %s: %s", r.Method, r.URL.Path, err))
+		w.Header().Set("WWW-Authenticate", `Bearer realm="calendar"`)
+		http.Error(w, auth.ErrUnauthenticated.Error(), http.StatusUnauthorized)
+	})
+
+	return m
+}
+
+// scoped проверяет, что аутентифицированному клиенту разрешена область доступа маршрута.
+// Пустая область доступа не проверяется.
+func scoped(scope string, next http.HandlerFunc) http.HandlerFunc {
+	if scope == "" {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := auth.FromContext(r.Context()); ok && !principal.HasScope(scope) {
+			http.Error(w, fmt.Sprintf("scope %s is required", scope), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// recordingResponseWriter накапливает ответ обработчика для проверки перед отправкой клиенту.
+type recordingResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *recordingResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	return rw.body.Write(b)
+}
+
 // responseWriter представляет обертку для http.ResponseWriter для отслеживания статус-кода.
 type responseWriter struct {
 	http.ResponseWriter
@@ -61,3 +198,21 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Hijack передает соединение обработчику, например для перехода на WebSocket.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking is not supported")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// Flush отправляет клиенту накопленные данные, если исходный http.ResponseWriter это поддерживает.
+// Нужен потоковым ответам: /api/v1/events/stream и WatchEvents через шлюз gRPC.
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,99 @@
+package serverhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	servergrpc "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// tokens аутентификация по заранее известным токенам.
+type tokens map[string]auth.Principal
+
+func (t tokens) Authenticate(_ context.Context, token string) (auth.Principal, error) {
+	principal, ok := t[token]
+	if !ok {
+		return auth.Principal{}, errors.New("unknown token")
+	}
+	return principal, nil
+}
+
+func TestAuthentication(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	application := app.New(memorystorage.New(), *log)
+	authenticator := tokens{
+		"alice":  {UserID: "alice"},
+		"admin":  {UserID: "admin", Admin: true},
+		"reader": {KeyID: "reader", Admin: true, Scopes: []string{auth.ScopeEventsRead}},
+	}
+	serv := NewServer(log, application, &config.ServerConfig{Host: "localhost", Port: "0"}, authenticator)
+
+	// Маршруты REST API обслуживает шлюз gRPC, сервер gRPC проверяет токен повторно.
+	grpcConfig := config.ServerConfig{Host: "localhost", Port: "50062"}
+	grpcServ := servergrpc.NewServer(log, application, &grpcConfig, authenticator)
+	go grpcServ.Start()
+	defer grpcServ.Stop(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gateway, err := servergrpc.NewGateway(ctx, &grpcConfig)
+	require.NoError(t, err)
+	serv.Mount(servergrpc.GatewayPrefix, gateway)
+
+	do := func(method, target, token string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		serv.srv.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("public paths", func(t *testing.T) {
+		for _, path := range []string{"/health", "/openapi.json"} {
+			require.Equal(t, http.StatusOK, do(http.MethodGet, path, "", nil).Code, path)
+		}
+	})
+
+	t.Run("missing or invalid token", func(t *testing.T) {
+		for _, token := range []string{"", "unknown"} {
+			rec := do(http.MethodGet, "/api/v1/events", token, nil)
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+		}
+	})
+
+	t.Run("principal is passed to application", func(t *testing.T) {
+		event := []byte(`{"title":"Планерка","beginning":"2024-06-03T10:00:00Z","finish":"2024-06-03T11:00:00Z"}`)
+		rec := do(http.MethodPost, "/api/v1/events", "alice", event)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		require.Contains(t, rec.Body.String(), `"userId":"alice"`)
+
+		location := rec.Header().Get("Location")
+		require.Equal(t, http.StatusOK, do(http.MethodGet, location, "alice", nil).Code)
+		require.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/v1/events?userId=bob", "alice", nil).Code)
+		require.Equal(t, http.StatusForbidden, do(http.MethodDelete, "/api/v1/users/bob", "alice", nil).Code)
+		require.Equal(t, http.StatusOK, do(http.MethodDelete, location, "admin", nil).Code)
+	})
+
+	t.Run("api key scopes", func(t *testing.T) {
+		event := []byte(`{"title":"Обед","beginning":"2024-06-03T12:00:00Z","finish":"2024-06-03T13:00:00Z"}`)
+		require.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/events?userId=alice", "reader", nil).Code)
+		require.Equal(t, http.StatusOK, do(http.MethodGet, "/select/events", "reader", nil).Code)
+		require.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/events", "reader", event).Code)
+		require.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/v1/users", "reader", nil).Code)
+		require.Equal(t, http.StatusForbidden, do(http.MethodPost, "/create/event", "reader", event).Code)
+	})
+}
@@ -0,0 +1,40 @@
+package serverhttp
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// openAPISpec спецификация OpenAPI 3 HTTP API, отдается по /openapi.json.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+func init() {
+	// Ошибки проверки отправляются клиенту, поэтому схема целиком в них не нужна.
+	openapi3.SchemaErrorDetailsDisabled = true
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.FileBodyDecoder)
+}
+
+// loadSpec разбирает и проверяет встроенную спецификацию OpenAPI.
+func loadSpec() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// handleOpenAPI обрабатывает запросы к /openapi.json.
+func (h *handler) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	if err := sendData(w, openAPISpec); err != nil {
+		h.logger.Error("openapi: " + err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package serverhttp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc/api"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	spec, err := loadSpec()
+	require.NoError(t, err)
+
+	type route struct{ method, path string }
+	var routes []route
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	h := newHandler(log, app.New(memorystorage.New(), *log))
+	for _, e := range append(endpoints(h), legacySelects(h)...) {
+		routes = append(routes, route{e.method, e.path})
+	}
+	for _, a := range legacyAliases() {
+		routes = append(routes, route{a.method, a.path})
+	}
+
+	// Маршруты шлюза gRPC задаются аннотациями google.api.http в EventService.proto.
+	services := api.File_internal_server_grpc_EventService_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			rule, ok := proto.GetExtension(methods.Get(j).Options(), annotations.E_Http).(*annotations.HttpRule)
+			require.True(t, ok, "no HTTP rule for %s", methods.Get(j).FullName())
+			switch pattern := rule.GetPattern().(type) {
+			case *annotations.HttpRule_Get:
+				routes = append(routes, route{http.MethodGet, pattern.Get})
+			case *annotations.HttpRule_Post:
+				routes = append(routes, route{http.MethodPost, pattern.Post})
+			case *annotations.HttpRule_Put:
+				routes = append(routes, route{http.MethodPut, pattern.Put})
+			case *annotations.HttpRule_Patch:
+				routes = append(routes, route{http.MethodPatch, pattern.Patch})
+			case *annotations.HttpRule_Delete:
+				routes = append(routes, route{http.MethodDelete, pattern.Delete})
+			default:
+				t.Fatalf("unexpected HTTP rule for %s", methods.Get(j).FullName())
+			}
+		}
+	}
+
+	registered := make(map[*openapi3.PathItem]map[string]bool)
+	for _, r := range routes {
+		pathItem := spec.Paths.Find(r.path)
+		require.NotNil(t, pathItem, "no spec entry for %s", r.path)
+		require.NotNil(t, pathItem.GetOperation(r.method), "no spec entry for %s %s", r.method, r.path)
+
+		if registered[pathItem] == nil {
+			registered[pathItem] = make(map[string]bool)
+		}
+		registered[pathItem][r.method] = true
+	}
+
+	for path, pathItem := range spec.Paths {
+		for method := range pathItem.Operations() {
+			require.True(t, registered[pathItem][method], "spec entry %s %s has no route", method, path)
+		}
+	}
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	application := app.New(memorystorage.New(), *log)
+	handler := NewServer(log, application, &config.ServerConfig{Host: "localhost", Port: "0"}, nil).srv.Handler
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			method, path, contentType, body string
+		}{
+			"unknown field":      {http.MethodPost, "/api/v1/events", "application/json", `{"title": "x", "date": "1"}`},
+			"wrong type":         {http.MethodPost, "/api/v1/users", "application/json", `{"firstName": "x", "age": "old"}`},
+			"invalid date-time":  {http.MethodPost, "/create/event", "application/json", `{"beginning": "tomorrow"}`},
+			"missing body":       {http.MethodPost, "/api/v1/events", "application/json", ""},
+			"wrong content type": {http.MethodPost, "/api/v1/events", "text/plain", `{"title": "x"}`},
+			"missing parameter":  {http.MethodGet, "/export/events", "", ""},
+			"invalid date":       {http.MethodGet, "/select/events/day?date=01.06.2024", "", ""},
+		} {
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusBadRequest, rec.Code, name)
+		}
+	})
+
+	t.Run("spec is served", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.JSONEq(t, string(openAPISpec), rec.Body.String())
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		spec, err := loadSpec()
+		require.NoError(t, err)
+		specRouter, err := gorillamux.NewRouter(spec)
+		require.NoError(t, err)
+
+		broken := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"id": 1}]`))
+		})
+		validated := newMiddleware(log, broken).validation(specRouter).Handler
+
+		rec := httptest.NewRecorder()
+		validated.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
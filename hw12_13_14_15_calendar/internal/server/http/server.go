@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/auth"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server"
 )
 
@@ -15,41 +18,75 @@ import (
 type Server struct {
 	app    server.Application
 	logger server.Logger
+	mux    *http.ServeMux
 	srv    *http.Server
 }
 
+// apiPrefix префикс версионированного REST API. Маршруты REST API обслуживает шлюз gRPC,
+// который подключается к серверу через Mount.
+const apiPrefix = "/api/v1"
+
+// endpoint маршрут, который HTTP сервер обслуживает сам. Для каждого маршрута должна быть операция в openapi.json.
+// scope - область доступа, необходимая ключу API, пустая для служебных маршрутов.
+type endpoint struct {
+	method  string
+	path    string
+	scope   string
+	handler http.HandlerFunc
+}
+
+// endpoints возвращает поток изменений событий для браузеров, которого нет в шлюзе gRPC, и служебные маршруты.
+func endpoints(h *handler) []endpoint {
+	return []endpoint{
+		{http.MethodGet, apiPrefix + "/events/stream", auth.ScopeEventsRead, h.streamEvents},
+		{http.MethodGet, "/route", "", h.handleRoute},
+		{http.MethodGet, "/health", "", h.handleHealth},
+		{http.MethodGet, "/openapi.json", "", h.handleOpenAPI},
+	}
+}
+
 // NewServer создает новый HTTP сервер с указанным логгером, приложением и конфигурацией.
-func NewServer(logger server.Logger, app server.Application, config server.Config) *Server {
+// Если authenticator равен nil, запросы обрабатываются без аутентификации.
+func NewServer(
+	logger server.Logger, app server.Application, config server.Config, authenticator server.Authenticator,
+) *Server {
 	handler := newHandler(logger, app)
+	s := &Server{logger: logger, app: app, mux: http.NewServeMux()}
+
+	for _, e := range append(endpoints(handler), legacySelects(handler)...) {
+		s.mux.HandleFunc(e.path, scoped(e.scope, e.handler))
+	}
+	for _, a := range legacyAliases() {
+		s.mux.HandleFunc(strings.TrimSuffix(a.path, "{id}"), s.serveAlias(a))
+	}
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/create/user", handler.createUser)
-	mux.HandleFunc("/select/users", handler.selectUsers)
-	mux.HandleFunc("/delete/user/", handler.deleteUser)
-	mux.HandleFunc("/create/event", handler.createEvent)
-
-	mux.HandleFunc("/select/events", handler.selectEvents)
-	mux.HandleFunc("/update/event", handler.updateEvent)
-	mux.HandleFunc("/delete/event/", handler.deleteEvent)
-
-	mux.HandleFunc("/select/events/day", handler.selectEventsForDay)
-	mux.HandleFunc("/select/events/week", handler.selectEventsForWeek)
-	mux.HandleFunc("/select/events/month", handler.selectEventsForMonth)
-
-	mux.HandleFunc("/route", handler.handleRoute)
-	mux.HandleFunc("/health", handler.handleHealth)
-
-	middleWare := newMiddleware(logger, mux).logging()
-
-	return &Server{
-		logger: logger,
-		app:    app,
-		srv: &http.Server{
-			Addr:              net.JoinHostPort(config.GetHost(), config.GetPort()),
-			Handler:           middleWare.Handler,
-			ReadHeaderTimeout: 10 * time.Second,
-		},
+	spec, err := loadSpec()
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("invalid OpenAPI specification: %s", err))
 	}
+	specRouter, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("invalid OpenAPI specification: %s", err))
+	}
+
+	middleWare := newMiddleware(logger, s.mux).validation(specRouter)
+	if authenticator != nil {
+		middleWare = middleWare.authentication(authenticator)
+	}
+	middleWare = middleWare.logging()
+
+	s.srv = &http.Server{
+		Addr:              net.JoinHostPort(config.GetHost(), config.GetPort()),
+		Handler:           middleWare.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Mount подключает обработчик ко всем путям, начинающимся с prefix, например шлюз gRPC к /api/.
+// Вызывается до Start. Запросы к подключенным обработчикам и устаревшим маршрутам проходят через middleware сервера.
+func (s *Server) Mount(prefix string, h http.Handler) {
+	s.mux.Handle(prefix, h)
 }
 
 // Start запускает HTTP сервер.
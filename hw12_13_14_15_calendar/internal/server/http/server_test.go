@@ -13,6 +13,8 @@ import (
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	servergrpc "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/server/grpc"
 	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
 	"github.com/stretchr/testify/require"
 )
@@ -23,13 +25,13 @@ func userCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address stri
 	defer mutex.Unlock()
 
 	// Create user
-	userData := `{"name": "testuser"}`
+	userData := `{"firstName": "testuser"}`
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/user", bytes.NewBuffer([]byte(userData)))
 	require.Nil(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	require.Nil(t, err)
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
 	resp.Body.Close()
 
 	// Select users
@@ -61,15 +63,22 @@ func eventCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address str
 	defer mutex.Unlock()
 
 	// Create event
-	eventData := `{"title": "testevent", "date": "2023-01-01"}`
+	eventData := `{"title": "testevent", "beginning": "2023-01-01T10:00:00Z", "finish": "2023-01-01T11:00:00Z"}`
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
 		bytes.NewBuffer([]byte(eventData)))
 	require.Nil(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	require.Nil(t, err)
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&created)
 	resp.Body.Close()
+	require.Nil(t, err)
+	createdID, _ := created["id"].(string)
+	require.NotEmpty(t, createdID)
+	require.Equal(t, "/api/v1/events/"+createdID, resp.Header.Get("Location"))
 
 	// Select events
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, address+"/select/events", nil)
@@ -86,6 +95,7 @@ func eventCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address str
 
 	// Delete event
 	eventID := events[0]["id"].(string)
+	require.Equal(t, createdID, eventID)
 	req, err = http.NewRequestWithContext(ctx, http.MethodDelete, address+"/delete/event/"+eventID, nil)
 	require.Nil(t, err)
 	resp, err = http.DefaultClient.Do(req)
@@ -94,6 +104,708 @@ func eventCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address str
 	resp.Body.Close()
 }
 
+func calendarCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Import calendar
+	calendarData := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n" +
+		"DTSTART:20240603T100000Z\r\nDTEND:20240603T110000Z\r\nSUMMARY:Планерка\r\n" +
+		"DESCRIPTION:Еженедельная\r\nBEGIN:VALARM\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\n" +
+		"END:VEVENT\r\nEND:VCALENDAR\r\n"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/import/events?userId=ical-user",
+		bytes.NewBufferString(calendarData))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "text/calendar")
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]int
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	require.Nil(t, err)
+	require.Equal(t, 1, result["count"])
+
+	// Export calendar
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, address+"/export/events?userId=ical-user", nil)
+	require.Nil(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var ical struct {
+		Data []byte `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&ical)
+	resp.Body.Close()
+	require.Nil(t, err)
+	exported := ical.Data
+	require.Contains(t, string(exported), "SUMMARY:Планерка\r\n")
+	require.Contains(t, string(exported), "DESCRIPTION:Еженедельная\r\n")
+	require.Contains(t, string(exported), "DTSTART:20240603T100000Z\r\n")
+	require.Contains(t, string(exported), "DTEND:20240603T110000Z\r\n")
+	require.Contains(t, string(exported), "TRIGGER:-PT15M\r\n")
+
+	// Import invalid calendar
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, address+"/import/events?userId=ical-user",
+		bytes.NewBufferString("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n"))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "text/calendar")
+	resp, err = http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func conflictCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Create event
+	eventData := `{"title": "meeting", "userId": "busy-user",
+		"beginning": "2024-06-03T10:00:00Z", "finish": "2024-06-03T11:00:00Z"}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
+		bytes.NewBufferString(eventData))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Create overlapping event
+	overlappingData := `{"title": "overlapping", "userId": "busy-user",
+		"beginning": "2024-06-03T10:30:00Z", "finish": "2024-06-03T11:30:00Z"}`
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
+		bytes.NewBufferString(overlappingData))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func validationCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Create invalid event
+	eventData := `{"title": " ", "beginning": "2024-06-03T10:00:00Z", "finish": "2024-06-03T09:00:00Z",
+		"recurrence": "FREQ=SECONDLY"}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/create/event",
+		bytes.NewBufferString(eventData))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var body struct {
+		Error  string           `json:"error"`
+		Fields []app.FieldError `json:"fields"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	require.Nil(t, err)
+
+	fields := make([]string, 0, len(body.Fields))
+	for _, field := range body.Fields {
+		fields = append(fields, field.Field)
+	}
+	require.Equal(t, []string{"title", "finish", "recurrence"}, fields)
+}
+
+func restCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+
+	// Create event
+	resp := do(http.MethodPost, "/api/v1/events", `{"title": "standup", "userId": "rest-user",
+		"beginning": "2024-07-01T09:00:00Z", "finish": "2024-07-01T09:15:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	// Patch event
+	resp = do(http.MethodPatch, location, `{"title": "daily standup"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Get event
+	resp = do(http.MethodGet, location, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var event map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&event))
+	resp.Body.Close()
+	require.Equal(t, "daily standup", event["title"])
+	require.Equal(t, "2024-07-01T09:00:00Z", event["beginning"])
+
+	// List events for period and user
+	resp = do(http.MethodGet,
+		"/api/v1/events?from=2024-07-01T00:00:00Z&to=2024-07-02T00:00:00Z&userId=rest-user", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var events struct {
+		Events        []map[string]interface{} `json:"events"`
+		NextPageToken string                   `json:"nextPageToken"`
+	}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&events))
+	resp.Body.Close()
+	require.Len(t, events.Events, 1)
+
+	resp = do(http.MethodGet, "/api/v1/events?from=2024-07-01T00:00:00Z", "")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Replace event with overlapping time of another event
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "review", "userId": "rest-user",
+		"beginning": "2024-07-01T10:00:00Z", "finish": "2024-07-01T11:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	reviewLocation := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	resp = do(http.MethodPut, reviewLocation, `{"title": "review", "userId": "rest-user",
+		"beginning": "2024-07-01T09:10:00Z", "finish": "2024-07-01T10:00:00Z"}`)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Paginated list sorted by title
+	var titles []string
+	next := ""
+	for pages := 0; pages == 0 || next != ""; pages++ {
+		require.Less(t, pages, 3)
+		resp = do(http.MethodGet, "/api/v1/events?userId=rest-user&orderBy=-title&pageSize=1&pageToken="+next, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&events))
+		resp.Body.Close()
+		require.Len(t, events.Events, 1)
+		titles = append(titles, events.Events[0]["title"].(string))
+		next = events.NextPageToken
+	}
+	require.Equal(t, []string{"review", "daily standup"}, titles)
+
+	resp = do(http.MethodGet, "/api/v1/events?title=STAND", "")
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&events))
+	resp.Body.Close()
+	require.Len(t, events.Events, 1)
+
+	resp = do(http.MethodGet, "/api/v1/events?orderBy=title&pageToken=garbage", "")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Full-text search
+	resp = do(http.MethodGet, "/api/v1/events/search?q=Standup&userId=rest-user", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var search struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&search))
+	resp.Body.Close()
+	require.Len(t, search.Results, 1)
+	require.Equal(t, "daily <mark>standup</mark>", search.Results[0]["snippet"])
+
+	resp = do(http.MethodGet, "/api/v1/events/search?q=standup&limit=0", "")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	// Unsupported method
+	resp = do(http.MethodPost, location, "")
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp.Body.Close()
+
+	// Delete events
+	for _, path := range []string{location, reviewLocation} {
+		resp = do(http.MethodDelete, path, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// Missing resources
+	for _, path := range []string{location, "/api/v1/unknown"} {
+		resp = do(http.MethodGet, path, "")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		resp.Body.Close()
+	}
+	resp = do(http.MethodPut, location, `{"title": "standup",
+		"beginning": "2024-07-01T09:00:00Z", "finish": "2024-07-01T09:15:00Z"}`)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func sharedCalendarCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+	decode := func(resp *http.Response, v interface{}) {
+		t.Helper()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(v))
+		resp.Body.Close()
+	}
+
+	var userIDs []string
+	for _, name := range []string{"owner", "reader"} {
+		resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "`+name+`"}`)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var user map[string]interface{}
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+		resp.Body.Close()
+		userIDs = append(userIDs, user["id"].(string))
+	}
+	ownerID, readerID := userIDs[0], userIDs[1]
+
+	// Create calendar
+	resp := do(http.MethodPost, "/api/v1/calendars", `{"name": "team", "ownerId": "`+ownerID+`"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	var calendar map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&calendar))
+	resp.Body.Close()
+	require.Equal(t, "/api/v1/calendars/"+calendar["id"].(string), location)
+	require.Equal(t, "UTC", calendar["timeZone"])
+
+	resp = do(http.MethodPost, "/api/v1/calendars", `{"name": "team", "ownerId": "`+ownerID+`", "timeZone": "Moon"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Update and share calendar
+	resp = do(http.MethodPut, location, `{"name": "team", "timeZone": "Europe/Moscow", "color": "#336699"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodPut, location+"/acl/"+readerID, `{"role": "read"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, location+"/acl/"+readerID, `{"role": "admin"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	decode(do(http.MethodGet, location, ""), &calendar)
+	require.Equal(t, "Europe/Moscow", calendar["timeZone"])
+	require.Equal(t, []interface{}{map[string]interface{}{
+		"calendarId": calendar["id"], "userId": readerID, "role": "read",
+	}}, calendar["acl"])
+
+	var calendars struct {
+		Calendars []map[string]interface{} `json:"calendars"`
+	}
+	decode(do(http.MethodGet, "/api/v1/calendars", ""), &calendars)
+	require.Len(t, calendars.Calendars, 1)
+
+	// Events of calendar
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "sync", "userId": "`+ownerID+`",
+		"calendarId": "`+calendar["id"].(string)+`", "beginning": "2024-08-01T09:00:00Z", "finish": "2024-08-01T10:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	eventLocation := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	var events struct {
+		Events []map[string]interface{} `json:"events"`
+	}
+	decode(do(http.MethodGet, "/api/v1/events?calendarId="+calendar["id"].(string), ""), &events)
+	require.Len(t, events.Events, 1)
+	require.Equal(t, calendar["id"], events.Events[0]["calendarId"])
+
+	// Revoke access and delete calendar
+	resp = do(http.MethodDelete, location+"/acl/"+readerID, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodDelete, location+"/acl/"+readerID, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodDelete, location, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	for _, path := range []string{location, eventLocation} {
+		resp = do(http.MethodGet, path, "")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		resp.Body.Close()
+	}
+}
+
+func attendeeCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+
+	resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "guest"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	guestID := user["id"].(string)
+
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "party", "userId": "host",
+		"beginning": "2024-09-01T18:00:00Z", "finish": "2024-09-01T22:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	// Invite attendee
+	resp = do(http.MethodPut, location+"/attendees/"+guestID, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var attendee map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&attendee))
+	resp.Body.Close()
+	require.Equal(t, "needs-action", attendee["status"])
+
+	// Respond to invitation
+	resp = do(http.MethodPut, location+"/attendees/"+guestID+"/rsvp", `{"status": "accepted"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, location+"/attendees/"+guestID+"/rsvp", `{"status": "needs-action"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, location+"/attendees/unknown/rsvp", `{"status": "declined"}`)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodGet, location+"/attendees", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var attendees struct {
+		Attendees []map[string]interface{} `json:"attendees"`
+	}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&attendees))
+	resp.Body.Close()
+	require.Len(t, attendees.Attendees, 1)
+	require.Equal(t, "accepted", attendees.Attendees[0]["status"])
+
+	// Remove attendee and event
+	resp = do(http.MethodDelete, location+"/attendees/"+guestID, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodDelete, location+"/attendees/"+guestID, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodDelete, location, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func freeBusyCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+
+	resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "busy"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	userID := user["id"].(string)
+	hoursPath := "/api/v1/users/" + userID + "/working-hours"
+
+	resp = do(http.MethodGet, hoursPath, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, hoursPath, `{"timeZone": "UTC", "start": "18:00", "end": "09:00", "days": [1]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, hoursPath, `{"timeZone": "UTC", "start": "09:00", "end": "18:00", "days": [8]}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, hoursPath, `{"timeZone": "UTC", "start": "09:00", "end": "18:00", "days": [1, 2, 3, 4, 5]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodGet, hoursPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var hours map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&hours))
+	resp.Body.Close()
+	require.Equal(t, "09:00", hours["start"])
+
+	// Понедельник.
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "focus", "userId": "`+userID+`",
+		"beginning": "2024-09-02T09:00:00Z", "finish": "2024-09-02T12:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	resp = do(http.MethodPost, "/api/v1/freebusy", `{"userIds": ["`+userID+`"], "from": "2024-09-01T00:00:00Z",
+		"to": "2024-09-03T00:00:00Z", "durationMinutes": 120, "limit": 2}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var freeBusy struct {
+		Users []struct {
+			UserID string                   `json:"userId"`
+			Busy   []map[string]interface{} `json:"busy"`
+		} `json:"users"`
+		Slots []map[string]interface{} `json:"slots"`
+	}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&freeBusy))
+	resp.Body.Close()
+	require.Len(t, freeBusy.Users, 1)
+	require.Equal(t, []map[string]interface{}{
+		{"start": "2024-09-02T09:00:00Z", "end": "2024-09-02T12:00:00Z"},
+	}, freeBusy.Users[0].Busy)
+	require.Equal(t, []map[string]interface{}{
+		{"start": "2024-09-02T12:00:00Z", "end": "2024-09-02T14:00:00Z"},
+		{"start": "2024-09-02T14:00:00Z", "end": "2024-09-02T16:00:00Z"},
+	}, freeBusy.Slots)
+
+	resp = do(http.MethodPost, "/api/v1/freebusy", `{"userIds": ["unknown"], "from": "2024-09-01T00:00:00Z",
+		"to": "2024-09-03T00:00:00Z", "durationMinutes": 30}`)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPost, "/api/v1/freebusy", `{"userIds": ["`+userID+`"], "from": "2024-09-03T00:00:00Z",
+		"to": "2024-09-01T00:00:00Z", "durationMinutes": 30}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodDelete, hoursPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodDelete, location, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func notificationSettingsCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+
+	resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "notified"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	settingsPath := "/api/v1/users/" + user["id"].(string) + "/notification-settings"
+
+	resp = do(http.MethodGet, settingsPath, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, settingsPath, `{"channels": ["sms"]}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, settingsPath, `{"channels": ["email", "webhook"]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPut, settingsPath, `{"channels": ["email", "webhook"], "webhookUrl": "https://example.com/hook"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodGet, settingsPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var settings map[string]interface{}
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&settings))
+	resp.Body.Close()
+	require.Equal(t, []interface{}{"email", "webhook"}, settings["channels"])
+	require.Equal(t, "https://example.com/hook", settings["webhookUrl"])
+
+	resp = do(http.MethodDelete, settingsPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodDelete, settingsPath, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func timeZoneCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+	// titles возвращает названия событий из ответа устаревшего маршрута со списком
+	// или, если page, из ответа REST API со страницей событий.
+	titles := func(resp *http.Response, page bool) []string {
+		t.Helper()
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var list struct {
+			Events []model.Event `json:"events"`
+		}
+		if page {
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&list))
+		} else {
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&list.Events))
+		}
+		result := make([]string, 0, len(list.Events))
+		for _, event := range list.Events {
+			result = append(result, event.Title)
+		}
+		return result
+	}
+
+	resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "tokyo", "timeZone": "Asia/Tokyo"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user model.User
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	require.Equal(t, "Asia/Tokyo", user.TimeZone)
+
+	// 20:00 UTC 1 октября - это утро 2 октября в Токио.
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "tokyo call", "userId": "`+user.ID+`",
+		"beginning": "2024-10-01T20:00:00Z", "finish": "2024-10-01T21:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var event model.Event
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&event))
+	resp.Body.Close()
+	require.Equal(t, "Asia/Tokyo", event.TimeZone)
+
+	require.Contains(t, titles(do(http.MethodGet, "/select/events/day?date=2024-10-02&timeZone=Asia/Tokyo", ""), false),
+		"tokyo call")
+	require.NotContains(t, titles(do(http.MethodGet, "/select/events/day?date=2024-10-02", ""), false), "tokyo call")
+	require.Contains(t, titles(do(http.MethodGet, "/select/events/day?date=2024-10-01", ""), false), "tokyo call")
+
+	require.Equal(t, []string{"tokyo call"}, titles(do(http.MethodGet,
+		"/api/v1/events?userId="+user.ID+"&from=2024-10-02T00:00:00%2B09:00&to=2024-10-03T00:00:00%2B09:00", ""), true))
+	require.Contains(t, titles(do(http.MethodGet,
+		"/api/v1/events/day?date=2024-10-02T00:00:00Z&timeZone=Asia/Tokyo", ""), true), "tokyo call")
+
+	resp = do(http.MethodGet, "/select/events/week?startDate=2024-10-01&timeZone=Mars/Olympus", "")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodDelete, "/api/v1/events/"+event.ID, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func reminderCase(ctx context.Context, t *testing.T, mutex *sync.Mutex, address string) {
+	t.Helper()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, address+path, bytes.NewBufferString(body))
+		require.Nil(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return resp
+	}
+	decode := func(resp *http.Response) model.Event {
+		t.Helper()
+		defer resp.Body.Close()
+		var event model.Event
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&event))
+		return event
+	}
+
+	resp := do(http.MethodPost, "/api/v1/users", `{"firstName": "reminded"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user model.User
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+
+	resp = do(http.MethodPost, "/api/v1/events", `{"title": "reminded", "userId": "`+user.ID+`",
+		"beginning": "2030-03-04T10:00:00Z", "finish": "2030-03-04T11:00:00Z",
+		"reminders": [{"minutesBefore": 60, "channel": "email"}, {"minutesBefore": 10}]}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	event := decode(resp)
+	require.Len(t, event.Reminders, 2)
+	require.NotEmpty(t, event.Reminders[0].ID)
+	require.Equal(t, model.ChannelEmail, event.Reminders[0].Channel)
+	require.Equal(t, time.Date(2030, time.March, 4, 9, 0, 0, 0, time.UTC), event.Reminders[0].FireAt)
+
+	// Напоминания пересчитываются при переносе события и сохраняются, если их не передали.
+	resp = do(http.MethodPatch, "/api/v1/events/"+event.ID,
+		`{"beginning": "2030-03-05T10:00:00Z", "finish": "2030-03-05T11:00:00Z"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	event = decode(resp)
+	require.Len(t, event.Reminders, 2)
+	require.Equal(t, time.Date(2030, time.March, 5, 9, 50, 0, 0, time.UTC), event.Reminders[1].FireAt)
+
+	resp = do(http.MethodPatch, "/api/v1/events/"+event.ID, `{"reminders": [{"minutesBefore": 5}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	event = decode(resp)
+	require.Len(t, event.Reminders, 1)
+	require.Equal(t, 5, event.Reminders[0].MinutesBefore)
+	require.Equal(t, model.ChannelDefault, event.Reminders[0].Channel)
+
+	resp = do(http.MethodPatch, "/api/v1/events/"+event.ID,
+		`{"reminders": [{"minutesBefore": 5}, {"minutesBefore": 5}]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	resp = do(http.MethodPatch, "/api/v1/events/"+event.ID, `{"reminders": [{"minutesBefore": 5, "channel": "sms"}]}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = do(http.MethodDelete, "/api/v1/events/"+event.ID, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
 func TestServer(t *testing.T) {
 	logConfig := config.LoggerConfig{
 		Level: "info",
@@ -110,11 +822,25 @@ func TestServer(t *testing.T) {
 		Port: port,
 	}
 
-	serv := NewServer(log, application, &servConfig)
+	serv := NewServer(log, application, &servConfig, nil)
+
+	// Маршруты REST API обслуживает шлюз gRPC.
+	grpcConfig := config.ServerConfig{Host: host, Port: "50061"}
+	grpcServ := servergrpc.NewServer(log, application, &grpcConfig, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gateway, err := servergrpc.NewGateway(ctx, &grpcConfig)
+	require.NoError(t, err)
+	serv.Mount(servergrpc.GatewayPrefix, gateway)
 
 	wg := sync.WaitGroup{}
-	wg.Add(1)
+	wg.Add(2)
 
+	go func() {
+		defer wg.Done()
+		_ = grpcServ.Start()
+	}()
 	go func() {
 		defer wg.Done()
 		_ = serv.Start()
@@ -122,15 +848,24 @@ func TestServer(t *testing.T) {
 
 	mutex := sync.Mutex{}
 	address := "http://" + net.JoinHostPort(host, port)
-	ctx := context.Background()
 
 	time.Sleep(1 * time.Second)
 	userCase(ctx, t, &mutex, address)
 	time.Sleep(1 * time.Second)
 	eventCase(ctx, t, &mutex, address)
+	calendarCase(ctx, t, &mutex, address)
+	conflictCase(ctx, t, &mutex, address)
+	validationCase(ctx, t, &mutex, address)
+	restCase(ctx, t, &mutex, address)
+	sharedCalendarCase(ctx, t, &mutex, address)
+	attendeeCase(ctx, t, &mutex, address)
+	freeBusyCase(ctx, t, &mutex, address)
+	notificationSettingsCase(ctx, t, &mutex, address)
+	timeZoneCase(ctx, t, &mutex, address)
+	reminderCase(ctx, t, &mutex, address)
 
-	err := serv.Stop(ctx)
-	require.Nil(t, err)
+	require.Nil(t, serv.Stop(ctx))
+	require.Nil(t, grpcServ.Stop(ctx))
 
 	wg.Wait()
 }
@@ -0,0 +1,159 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+const (
+	// heartbeatInterval период отправки heartbeat в потоке изменений,
+	// чтобы прокси не закрывали простаивающее соединение.
+	heartbeatInterval = 15 * time.Second
+	// streamWriteTimeout время на отправку одного кадра WebSocket.
+	streamWriteTimeout = 10 * time.Second
+)
+
+// upgrader переводит запрос на WebSocket. Запросы с чужого Origin отклоняются.
+var upgrader = websocket.Upgrader{}
+
+// streamEvents обрабатывает подписку на изменения событий. Изменения передаются как Server-Sent Events
+// или кадрами WebSocket, если клиент запросил переход на этот протокол. Фильтры совпадают с listEvents.
+// Подписка возобновляется после изменения из заголовка Last-Event-ID или параметра lastEventId.
+func (h *handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	loc, err := h.app.Location(r.Context(), r.URL.Query().Get("timeZone"))
+	if err != nil {
+		h.logger.Error("streamEvents: " + err.Error())
+		sendError(w, err)
+		return
+	}
+	from, to, err := parsePeriodFromQuery(r, loc)
+	if err != nil {
+		h.logger.Error("streamEvents: " + err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("lastEventId")
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	changes, err := h.app.WatchEvents(ctx, r.URL.Query().Get("userId"), from, to, lastID)
+	if err != nil {
+		h.logger.Error("streamEvents: " + err.Error())
+		sendError(w, err)
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(r) {
+		h.streamWebSocket(ctx, cancel, w, r, changes)
+		return
+	}
+	h.streamSSE(ctx, w, changes)
+}
+
+// streamSSE передает изменения в формате Server-Sent Events.
+// Когда подписчик отключается как медленный, поток завершается, и клиент переподключается с Last-Event-ID.
+func (h *handler) streamSSE(ctx context.Context, w http.ResponseWriter, changes <-chan model.EventChange) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			err = writeSSE(w, change)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err != nil {
+			h.logger.Error("streamEvents: " + err.Error())
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE записывает изменение как событие SSE с типом изменения и его идентификатором.
+func writeSSE(w http.ResponseWriter, change model.EventChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.ID, change.Type, data)
+	return err
+}
+
+// streamWebSocket передает изменения кадрами WebSocket в формате JSON, heartbeat - ping-кадрами.
+// Подписка отменяется, когда клиент закрывает соединение.
+func (h *handler) streamWebSocket(ctx context.Context, cancel context.CancelFunc, w http.ResponseWriter,
+	r *http.Request, changes <-chan model.EventChange,
+) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Error("streamEvents: " + err.Error())
+		return
+	}
+	defer conn.Close()
+
+	// Входящие сообщения не ожидаются, чтение нужно для обработки управляющих кадров и закрытия.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber is too slow")
+				conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(streamWriteTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			err = conn.WriteJSON(change)
+		case <-heartbeat.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+		}
+		if err != nil {
+			h.logger.Error("streamEvents: " + err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package serverhttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/app"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/config"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/logger"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+	memorystorage "github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// sseMessage событие или комментарий из потока Server-Sent Events.
+type sseMessage struct {
+	id, event, data, comment string
+}
+
+// readSSE читает следующее сообщение потока до пустой строки.
+func readSSE(t *testing.T, reader *bufio.Reader) sseMessage {
+	t.Helper()
+	var msg sseMessage
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return msg
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			msg.comment = value
+		case "id":
+			msg.id = value
+		case "event":
+			msg.event = value
+		case "data":
+			msg.data = value
+		}
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	application := app.New(memorystorage.New(), *log)
+	serv := NewServer(log, application, &config.ServerConfig{Host: "localhost", Port: "0"}, nil)
+	// Close не ждет обработчиков перехваченных соединений WebSocket, которые еще пишут в журнал.
+	var handlers sync.WaitGroup
+	defer handlers.Wait()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlers.Add(1)
+		defer handlers.Done()
+		serv.srv.Handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	newEvent := func(userID string, shift time.Duration) model.IEvent {
+		created, err := application.CreateEvent(ctx, &model.Event{
+			Title:     "stream",
+			UserID:    userID,
+			Beginning: beginning.Add(shift),
+			Finish:    beginning.Add(shift + time.Hour),
+		})
+		require.NoError(t, err)
+		return created
+	}
+
+	subscribe := func(query, lastEventID string) (*bufio.Reader, func()) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/events/stream"+query, nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return bufio.NewReader(resp.Body), func() { resp.Body.Close() }
+	}
+
+	t.Run("server-sent events", func(t *testing.T) {
+		reader, closeStream := subscribe("?userId=sse&from=2024-06-03&to=2024-06-04", "")
+
+		newEvent("other", 0)
+		newEvent("sse", 24*time.Hour)
+		created := newEvent("sse", 0)
+
+		msg := readSSE(t, reader)
+		require.Equal(t, "created", msg.event)
+		require.NotEmpty(t, msg.id)
+
+		var change model.EventChange
+		require.NoError(t, json.Unmarshal([]byte(msg.data), &change))
+		require.Equal(t, msg.id, change.ID)
+		require.Equal(t, created.GetID(), change.Event.ID)
+
+		// Resume after the last received change
+		closeStream()
+		require.NoError(t, application.DeleteEvent(ctx, created.GetID()))
+
+		reader, closeStream = subscribe("?userId=sse", msg.id)
+		defer closeStream()
+
+		msg = readSSE(t, reader)
+		require.Equal(t, "deleted", msg.event)
+		require.Contains(t, msg.data, created.GetID())
+	})
+
+	t.Run("lost history", func(t *testing.T) {
+		reader, closeStream := subscribe("", "unknown-1")
+		defer closeStream()
+
+		msg := readSSE(t, reader)
+		require.Equal(t, "reset", msg.event)
+		require.NotEmpty(t, msg.id)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v1/events/stream?from=2024-06-03")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/events/stream?userId=ws"
+		conn, resp, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		defer conn.Close()
+
+		created := newEvent("ws", 0)
+
+		var change model.EventChange
+		require.NoError(t, conn.ReadJSON(&change))
+		require.Equal(t, model.ChangeCreated, change.Type)
+		require.Equal(t, created.GetID(), change.Event.ID)
+	})
+}
+
+func TestStreamHeartbeat(t *testing.T) {
+	log := logger.New(&config.LoggerConfig{Level: "error"})
+	h := newHandler(log, app.New(memorystorage.New(), *log))
+	h.heartbeat = 10 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(h.streamEvents))
+	defer srv.Close()
+
+	t.Run("server-sent events", func(t *testing.T) {
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		msg := readSSE(t, bufio.NewReader(resp.Body))
+		require.Equal(t, "heartbeat", msg.comment)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		defer conn.Close()
+
+		pinged := make(chan struct{}, 1)
+		conn.SetPingHandler(func(string) error {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		go conn.ReadMessage()
+
+		select {
+		case <-pinged:
+		case <-time.After(time.Second):
+			t.Fatal("no ping")
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package memorystorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// CreateAPIKey сохраняет ключ API и возвращает его с присвоенным идентификатором и временем создания.
+func (s *Storage) CreateAPIKey(_ context.Context, key model.APIKey) (model.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key.ID = uuid.New().String()
+	key.CreatedAt = time.Now().UTC()
+	key.RevokedAt = time.Time{}
+	key.Scopes = append([]string(nil), key.Scopes...)
+	s.apiKeys[key.ID] = key
+
+	return key, nil
+}
+
+// SelectAPIKeys возвращает все ключи API в порядке создания.
+func (s *Storage) SelectAPIKeys(_ context.Context) ([]model.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]model.APIKey, 0, len(s.apiKeys))
+	for _, key := range s.apiKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// SelectAPIKeyByHash возвращает ключ API по хешу или model.ErrAPIKeyNotFound.
+func (s *Storage) SelectAPIKeyByHash(_ context.Context, hash string) (model.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return model.APIKey{}, model.ErrAPIKeyNotFound
+}
+
+// RevokeAPIKey отзывает ключ API. Повторный отзыв не меняет время отзыва.
+// Возвращает model.ErrAPIKeyNotFound, если ключа нет.
+func (s *Storage) RevokeAPIKey(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[id]
+	if !ok {
+		return model.ErrAPIKeyNotFound
+	}
+	if !key.Revoked() {
+		key.RevokedAt = time.Now().UTC()
+		s.apiKeys[id] = key
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package memorystorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectAttendees возвращает участников события в порядке идентификаторов пользователей.
+func (s *Storage) SelectAttendees(_ context.Context, eventID string) ([]model.Attendee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.events[eventID]; !ok {
+		return nil, ErrEventNotFound
+	}
+
+	attendees := make([]model.Attendee, 0, len(s.attendees[eventID]))
+	for _, attendee := range s.attendees[eventID] {
+		attendees = append(attendees, attendee)
+	}
+	sortAttendees(attendees)
+	return attendees, nil
+}
+
+// SelectAttendances возвращает участие пользователя userID в событиях.
+func (s *Storage) SelectAttendances(_ context.Context, userID string) ([]model.Attendee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attendances := make([]model.Attendee, 0)
+	for _, attendees := range s.attendees {
+		if attendee, ok := attendees[userID]; ok {
+			attendances = append(attendances, attendee)
+		}
+	}
+	sortAttendees(attendances)
+	return attendances, nil
+}
+
+// SetAttendee добавляет участника события или меняет его статус.
+// Сообщения outbox сохраняются вместе с участником.
+// Возвращает model.ErrEventNotFound или model.ErrUserNotFound, если события или пользователя нет.
+func (s *Storage) SetAttendee(_ context.Context, attendee model.Attendee, outbox ...model.OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.events[attendee.EventID]; !ok {
+		return ErrEventNotFound
+	}
+	if _, ok := s.users[attendee.UserID]; !ok {
+		return ErrUserNotFound
+	}
+
+	if s.attendees[attendee.EventID] == nil {
+		s.attendees[attendee.EventID] = make(map[string]model.Attendee)
+	}
+	s.attendees[attendee.EventID][attendee.UserID] = attendee
+	s.addOutbox(outbox)
+	return nil
+}
+
+// DeleteAttendee удаляет участника события.
+// Возвращает model.ErrAttendeeNotFound, если пользователь не участвует в событии.
+func (s *Storage) DeleteAttendee(_ context.Context, eventID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.attendees[eventID][userID]; !ok {
+		return model.ErrAttendeeNotFound
+	}
+	delete(s.attendees[eventID], userID)
+	if len(s.attendees[eventID]) == 0 {
+		delete(s.attendees, eventID)
+	}
+	return nil
+}
+
+// sortAttendees упорядочивает участников по событию и пользователю.
+func sortAttendees(attendees []model.Attendee) {
+	sort.Slice(attendees, func(i, j int) bool {
+		if attendees[i].EventID != attendees[j].EventID {
+			return attendees[i].EventID < attendees[j].EventID
+		}
+		return attendees[i].UserID < attendees[j].UserID
+	})
+}
@@ -0,0 +1,159 @@
+package memorystorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// CreateCalendar сохраняет календарь без ACL и возвращает его с присвоенным идентификатором.
+// Возвращает model.ErrUserNotFound, если владельца нет.
+func (s *Storage) CreateCalendar(_ context.Context, calendar model.Calendar) (model.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[calendar.OwnerID]; !ok {
+		return model.Calendar{}, ErrUserNotFound
+	}
+
+	calendar.ID = uuid.New().String()
+	calendar.ACL = nil
+	s.calendars[calendar.ID] = calendar
+
+	return calendar, nil
+}
+
+// SelectCalendar возвращает календарь вместе с ACL.
+func (s *Storage) SelectCalendar(_ context.Context, id string) (model.Calendar, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	calendar, ok := s.calendars[id]
+	if !ok {
+		return model.Calendar{}, model.ErrCalendarNotFound
+	}
+	return copyCalendar(calendar), nil
+}
+
+// SelectCalendars возвращает календари, которыми владеет пользователь userID или к которым
+// ему выдан доступ, в порядке названия. Пустой userID - все календари.
+func (s *Storage) SelectCalendars(_ context.Context, userID string) ([]model.Calendar, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	calendars := make([]model.Calendar, 0)
+	for _, calendar := range s.calendars {
+		if userID == "" || calendar.RoleOf(userID) != "" {
+			calendars = append(calendars, copyCalendar(calendar))
+		}
+	}
+	sort.Slice(calendars, func(i, j int) bool {
+		if calendars[i].Name != calendars[j].Name {
+			return calendars[i].Name < calendars[j].Name
+		}
+		return calendars[i].ID < calendars[j].ID
+	})
+
+	return calendars, nil
+}
+
+// UpdateCalendar изменяет название, часовой пояс и цвет календаря.
+func (s *Storage) UpdateCalendar(_ context.Context, calendar model.Calendar) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.calendars[calendar.ID]
+	if !ok {
+		return model.ErrCalendarNotFound
+	}
+
+	current.Name = calendar.Name
+	current.TimeZone = calendar.TimeZone
+	current.Color = calendar.Color
+	s.calendars[calendar.ID] = current
+	return nil
+}
+
+// DeleteCalendar удаляет календарь вместе с его событиями, их участниками и ACL.
+func (s *Storage) DeleteCalendar(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.calendars[id]; !ok {
+		return model.ErrCalendarNotFound
+	}
+
+	for eventID, event := range s.events {
+		if event.CalendarID == id {
+			delete(s.events, eventID)
+			delete(s.attendees, eventID)
+			s.search.remove(event)
+		}
+	}
+	delete(s.calendars, id)
+	return nil
+}
+
+// SetAccess выдает пользователю доступ к календарю или меняет его роль.
+// Возвращает model.ErrCalendarNotFound или model.ErrUserNotFound, если календаря или пользователя нет.
+func (s *Storage) SetAccess(_ context.Context, entry model.ACLEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendar, ok := s.calendars[entry.CalendarID]
+	if !ok {
+		return model.ErrCalendarNotFound
+	}
+	if _, ok := s.users[entry.UserID]; !ok {
+		return ErrUserNotFound
+	}
+
+	acl := make([]model.ACLEntry, 0, len(calendar.ACL)+1)
+	for _, existing := range calendar.ACL {
+		if existing.UserID != entry.UserID {
+			acl = append(acl, existing)
+		}
+	}
+	acl = append(acl, entry)
+	sort.Slice(acl, func(i, j int) bool {
+		return acl[i].UserID < acl[j].UserID
+	})
+
+	calendar.ACL = acl
+	s.calendars[entry.CalendarID] = calendar
+	return nil
+}
+
+// RevokeAccess отзывает доступ пользователя к календарю.
+// Возвращает model.ErrAccessNotFound, если доступа не было.
+func (s *Storage) RevokeAccess(_ context.Context, calendarID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendar, ok := s.calendars[calendarID]
+	if !ok {
+		return model.ErrCalendarNotFound
+	}
+
+	acl := make([]model.ACLEntry, 0, len(calendar.ACL))
+	for _, entry := range calendar.ACL {
+		if entry.UserID != userID {
+			acl = append(acl, entry)
+		}
+	}
+	if len(acl) == len(calendar.ACL) {
+		return model.ErrAccessNotFound
+	}
+
+	calendar.ACL = acl
+	s.calendars[calendarID] = calendar
+	return nil
+}
+
+// copyCalendar возвращает календарь с копией ACL, чтобы вызывающий не изменил хранилище.
+func copyCalendar(calendar model.Calendar) model.Calendar {
+	calendar.ACL = append([]model.ACLEntry(nil), calendar.ACL...)
+	return calendar
+}
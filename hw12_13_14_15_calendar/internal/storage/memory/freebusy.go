@@ -0,0 +1,100 @@
+package memorystorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectBusy возвращает интервалы занятости пользователей userIDs в [from, to): их собственные события
+// и события, приглашение на которые они не отклонили. Повторяющиеся события разворачиваются в повторения.
+// Возвращает model.ErrUserNotFound, если одного из пользователей нет.
+func (s *Storage) SelectBusy(_ context.Context, userIDs []string, from, to time.Time) (
+	[]model.BusyInterval, error,
+) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requested := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := s.users[userID]; !ok {
+			return nil, ErrUserNotFound
+		}
+		requested[userID] = true
+	}
+
+	busy := make([]model.BusyInterval, 0)
+	for _, event := range s.events {
+		var busyUsers []string
+		if requested[event.UserID] {
+			busyUsers = append(busyUsers, event.UserID)
+		}
+		for userID, attendee := range s.attendees[event.ID] {
+			if requested[userID] && attendee.Status != model.StatusDeclined {
+				busyUsers = append(busyUsers, userID)
+			}
+		}
+		if len(busyUsers) == 0 {
+			continue
+		}
+
+		intervals, err := event.BusyIntervals(from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range busyUsers {
+			for _, interval := range intervals {
+				busy = append(busy, model.BusyInterval{UserID: userID, Interval: interval})
+			}
+		}
+	}
+	return busy, nil
+}
+
+// SelectWorkingHours возвращает рабочее время тех пользователей из userIDs, у которых оно задано,
+// в порядке идентификаторов пользователей.
+func (s *Storage) SelectWorkingHours(_ context.Context, userIDs []string) ([]model.WorkingHours, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hours := make([]model.WorkingHours, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if h, ok := s.workingHours[userID]; ok {
+			h.Days = append([]int(nil), h.Days...)
+			hours = append(hours, h)
+		}
+	}
+	sort.Slice(hours, func(i, j int) bool {
+		return hours[i].UserID < hours[j].UserID
+	})
+	return hours, nil
+}
+
+// SetWorkingHours задает или заменяет рабочее время пользователя.
+// Возвращает model.ErrUserNotFound, если пользователя нет.
+func (s *Storage) SetWorkingHours(_ context.Context, hours model.WorkingHours) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[hours.UserID]; !ok {
+		return ErrUserNotFound
+	}
+	hours.Days = append([]int(nil), hours.Days...)
+	s.workingHours[hours.UserID] = hours
+	return nil
+}
+
+// DeleteWorkingHours удаляет рабочее время пользователя.
+// Возвращает model.ErrWorkingHoursNotFound, если оно не было задано.
+func (s *Storage) DeleteWorkingHours(_ context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.workingHours[userID]; !ok {
+		return model.ErrWorkingHoursNotFound
+	}
+	delete(s.workingHours, userID)
+	return nil
+}
@@ -0,0 +1,48 @@
+package memorystorage
+
+import (
+	"context"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectNotificationSettings возвращает настройки уведомлений пользователя.
+// Возвращает model.ErrNotificationSettingsNotFound, если они не заданы.
+func (s *Storage) SelectNotificationSettings(_ context.Context, userID string) (model.NotificationSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings, ok := s.notificationSettings[userID]
+	if !ok {
+		return model.NotificationSettings{}, model.ErrNotificationSettingsNotFound
+	}
+	settings.Channels = append([]model.Channel(nil), settings.Channels...)
+	return settings, nil
+}
+
+// SetNotificationSettings задает или заменяет настройки уведомлений пользователя.
+// Возвращает model.ErrUserNotFound, если пользователя нет.
+func (s *Storage) SetNotificationSettings(_ context.Context, settings model.NotificationSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[settings.UserID]; !ok {
+		return ErrUserNotFound
+	}
+	settings.Channels = append([]model.Channel(nil), settings.Channels...)
+	s.notificationSettings[settings.UserID] = settings
+	return nil
+}
+
+// DeleteNotificationSettings удаляет настройки уведомлений пользователя.
+// Возвращает model.ErrNotificationSettingsNotFound, если они не были заданы.
+func (s *Storage) DeleteNotificationSettings(_ context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.notificationSettings[userID]; !ok {
+		return model.ErrNotificationSettingsNotFound
+	}
+	delete(s.notificationSettings, userID)
+	return nil
+}
@@ -0,0 +1,110 @@
+package memorystorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// addOutbox добавляет сообщения в outbox, пропуская ключи, которые уже ждут публикации.
+// Вызывается под блокировкой на запись.
+func (s *Storage) addOutbox(outbox []model.OutboxMessage) {
+	for _, message := range outbox {
+		if s.outboxQueued(message.Key) {
+			continue
+		}
+		s.outboxID++
+		message.ID = s.outboxID
+		message.CreatedAt = time.Now()
+		s.outbox = append(s.outbox, message)
+	}
+}
+
+// outboxQueued сообщает, есть ли в outbox сообщение с ключом key. Вызывается под блокировкой.
+func (s *Storage) outboxQueued(key string) bool {
+	for _, message := range s.outbox {
+		if message.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// RelayOutbox передает publish до limit первых сообщений outbox и удаляет опубликованные.
+// Публикация останавливается на первой ошибке, которая возвращается вместе с числом опубликованных.
+func (s *Storage) RelayOutbox(_ context.Context, limit int, publish func(model.OutboxMessage) error) (int, error) {
+	s.relayMu.Lock()
+	defer s.relayMu.Unlock()
+
+	s.mu.RLock()
+	if limit > len(s.outbox) {
+		limit = len(s.outbox)
+	}
+	batch := append([]model.OutboxMessage(nil), s.outbox[:limit]...)
+	s.mu.RUnlock()
+
+	relayed := make(map[int64]bool, len(batch))
+	var err error
+	for _, message := range batch {
+		if err = publish(message); err != nil {
+			break
+		}
+		relayed[message.ID] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	outbox := s.outbox[:0]
+	for _, message := range s.outbox {
+		if !relayed[message.ID] {
+			outbox = append(outbox, message)
+		}
+	}
+	s.outbox = outbox
+	return len(relayed), err
+}
+
+// SelectDeliveredChannels возвращает каналы, по которым уже доставлено сообщение с ключом key.
+func (s *Storage) SelectDeliveredChannels(_ context.Context, key string) ([]model.Channel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	channels := make([]model.Channel, 0, len(s.deliveries[key]))
+	for channel := range s.deliveries[key] {
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// MarkDelivered отмечает доставку сообщения с ключом key по каналу channel.
+func (s *Storage) MarkDelivered(_ context.Context, key string, channel model.Channel, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deliveries[key] == nil {
+		s.deliveries[key] = make(map[model.Channel]time.Time)
+	}
+	if _, ok := s.deliveries[key][channel]; !ok {
+		s.deliveries[key][channel] = at
+	}
+	return nil
+}
+
+// DeleteDeliveries удаляет отметки о доставках, сделанных раньше before.
+func (s *Storage) DeleteDeliveries(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, channels := range s.deliveries {
+		for channel, at := range channels {
+			if at.Before(before) {
+				delete(channels, channel)
+			}
+		}
+		if len(channels) == 0 {
+			delete(s.deliveries, key)
+		}
+	}
+	return nil
+}
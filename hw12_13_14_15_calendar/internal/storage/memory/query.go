@@ -0,0 +1,59 @@
+package memorystorage
+
+import (
+	"context"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// ListEvents возвращает страницу событий, подходящих под фильтр, в порядке query.OrderBy.
+// С интервалом времени повторяющиеся события разворачиваются в повторения из интервала.
+func (s *Storage) ListEvents(_ context.Context, query model.EventQuery) ([]model.Event, *model.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]model.Event, 0)
+	for _, event := range s.events {
+		if !query.Match(event) {
+			continue
+		}
+
+		if !query.Period() {
+			events = append(events, event)
+			continue
+		}
+
+		if event.Recurrence != "" {
+			occurrences, err := event.Occurrences(query.From, query.To)
+			if err != nil {
+				return nil, nil, err
+			}
+			events = append(events, occurrences...)
+			continue
+		}
+
+		if !event.Beginning.Before(query.From) && event.Beginning.Before(query.To) {
+			events = append(events, event)
+		}
+	}
+
+	page, next := model.PageEvents(events, query)
+	return page, next, nil
+}
+
+// ListUsers возвращает страницу пользователей в порядке query.OrderBy.
+func (s *Storage) ListUsers(_ context.Context, query model.UserQuery) ([]model.User, *model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]model.User, 0, len(s.users))
+	for _, user := range s.users {
+		if query.UserID != "" && user.ID != query.UserID {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	page, next := model.PageUsers(users, query)
+	return page, next, nil
+}
@@ -0,0 +1,78 @@
+package memorystorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectDueReminders возвращает напоминания со временем FireAt не позже t вместе с их событиями
+// в порядке времени напоминания.
+func (s *Storage) SelectDueReminders(_ context.Context, t time.Time) ([]model.DueReminder, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	due := make([]model.DueReminder, 0)
+	for _, event := range s.events {
+		for _, reminder := range event.Reminders {
+			if !reminder.FireAt.IsZero() && !reminder.FireAt.After(t) {
+				due = append(due, model.DueReminder{Reminder: reminder, Event: event})
+			}
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].Reminder.FireAt.Before(due[j].Reminder.FireAt)
+	})
+	return due, nil
+}
+
+// ClaimReminder заменяет напоминание current на next, если его FireAt не изменился.
+// Сообщения outbox сохраняются, только если напоминание удалось занять.
+func (s *Storage) ClaimReminder(
+	_ context.Context, current, next model.Reminder, outbox ...model.OutboxMessage,
+) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, ok := s.events[current.EventID]
+	if !ok {
+		return false, nil
+	}
+	for i, reminder := range event.Reminders {
+		if reminder.ID != current.ID {
+			continue
+		}
+		if !reminder.FireAt.Equal(current.FireAt) {
+			return false, nil
+		}
+		// Выданные ранее копии события разделяют список напоминаний, поэтому он копируется.
+		reminders := append([]model.Reminder(nil), event.Reminders...)
+		next.ID, next.EventID = reminder.ID, reminder.EventID
+		reminders[i] = next
+		event.Reminders = reminders
+		s.events[event.ID] = event
+		s.addOutbox(outbox)
+		return true, nil
+	}
+	return false, nil
+}
+
+// withReminderIDs возвращает копию напоминаний события, присваивая идентификаторы новым напоминаниям.
+// Напоминания, сохраненные приложением без изменений, сохраняют свои идентификаторы.
+func withReminderIDs(event model.Event) []model.Reminder {
+	if len(event.Reminders) == 0 {
+		return nil
+	}
+	reminders := make([]model.Reminder, 0, len(event.Reminders))
+	for _, reminder := range event.Reminders {
+		if reminder.ID == "" {
+			reminder.ID = uuid.New().String()
+		}
+		reminder.EventID = event.ID
+		reminders = append(reminders, reminder)
+	}
+	return reminders
+}
@@ -0,0 +1,194 @@
+package memorystorage
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+const (
+	// Веса совпадений в названии и описании, как веса A и B в ts_rank PostgreSQL.
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+
+	// snippetWords число слов во фрагменте результата, snippetContext - слов до первого совпадения.
+	snippetWords   = 20
+	snippetContext = 5
+)
+
+// searchIndex инвертированный индекс: слово названия или описания -> идентификаторы событий.
+type searchIndex map[string]map[string]struct{}
+
+// add добавляет слова события в индекс.
+func (index searchIndex) add(event model.Event) {
+	for _, term := range tokenize(event.Title + " " + event.Description) {
+		if index[term] == nil {
+			index[term] = make(map[string]struct{})
+		}
+		index[term][event.ID] = struct{}{}
+	}
+}
+
+// remove удаляет слова события из индекса.
+func (index searchIndex) remove(event model.Event) {
+	for _, term := range tokenize(event.Title + " " + event.Description) {
+		delete(index[term], event.ID)
+		if len(index[term]) == 0 {
+			delete(index, term)
+		}
+	}
+}
+
+// SearchEvents возвращает события, название или описание которых содержит все слова запроса,
+// в порядке убывания релевантности.
+func (s *Storage) SearchEvents(_ context.Context, query model.SearchQuery) ([]model.SearchResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]model.SearchResult, 0)
+	terms := unique(tokenize(query.Text))
+	if len(terms) == 0 {
+		return results, nil
+	}
+
+	for id := range s.search[terms[0]] {
+		event := s.events[id]
+		if (query.UserID != "" && event.UserID != query.UserID) || !query.Allows(event) || !s.indexed(id, terms[1:]) {
+			continue
+		}
+		results = append(results, model.SearchResult{
+			Event:   event,
+			Rank:    rank(event, terms),
+			Snippet: snippet(event.Title+" "+event.Description, terms),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return model.CompareEvents(results[i].Event, results[j].Event, model.OrderByBeginning) < 0
+	})
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+// indexed проверяет, что событие содержит все слова terms.
+func (s *Storage) indexed(id string, terms []string) bool {
+	for _, term := range terms {
+		if _, ok := s.search[term][id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rank считает вхождения слов запроса в название и описание с их весами.
+func rank(event model.Event, terms []string) float64 {
+	var result float64
+	for _, term := range tokenize(event.Title) {
+		if contains(terms, term) {
+			result += titleWeight
+		}
+	}
+	for _, term := range tokenize(event.Description) {
+		if contains(terms, term) {
+			result += descriptionWeight
+		}
+	}
+	return result
+}
+
+// snippet возвращает фрагмент текста вокруг первого совпадения, в котором слова запроса
+// обрамлены model.HighlightStart и model.HighlightStop. Текст фрагмента экранируется для HTML.
+func snippet(text string, terms []string) string {
+	type word struct {
+		start, end int
+		match      bool
+	}
+
+	words := make([]word, 0)
+	first := -1
+	start := -1
+	for i, r := range text + " " {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			w := word{start: start, end: i, match: contains(terms, strings.ToLower(text[start:i]))}
+			if w.match && first < 0 {
+				first = len(words)
+			}
+			words = append(words, w)
+			start = -1
+		}
+	}
+	if len(words) == 0 {
+		return ""
+	}
+
+	from := first - snippetContext
+	if from < 0 {
+		from = 0
+	}
+	to := from + snippetWords
+	if to > len(words) {
+		to = len(words)
+		from = to - snippetWords
+		if from < 0 {
+			from = 0
+		}
+	}
+
+	var b strings.Builder
+	pos := words[from].start
+	for _, w := range words[from:to] {
+		b.WriteString(html.EscapeString(text[pos:w.start]))
+		if w.match {
+			b.WriteString(model.HighlightStart + html.EscapeString(text[w.start:w.end]) + model.HighlightStop)
+		} else {
+			b.WriteString(html.EscapeString(text[w.start:w.end]))
+		}
+		pos = w.end
+	}
+	return b.String()
+}
+
+// tokenize разбивает текст на слова в нижнем регистре.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func unique(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
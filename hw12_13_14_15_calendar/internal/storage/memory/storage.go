@@ -2,7 +2,7 @@ package memorystorage
 
 import (
 	"context"
-	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,32 +11,59 @@ import (
 )
 
 type Storage struct {
-	mu     sync.RWMutex
-	events map[string]model.Event
-	users  map[string]model.User
+	mu      sync.RWMutex
+	events  map[string]model.Event
+	users   map[string]model.User
+	apiKeys map[string]model.APIKey
+	search  searchIndex
+	// calendars календари вместе с их ACL.
+	calendars map[string]model.Calendar
+	// attendees участники событий по идентификатору события и пользователя.
+	attendees map[string]map[string]model.Attendee
+	// workingHours рабочее время по идентификатору пользователя.
+	workingHours map[string]model.WorkingHours
+	// notificationSettings настройки уведомлений по идентификатору пользователя.
+	notificationSettings map[string]model.NotificationSettings
+	// outbox сообщения для брокера в порядке добавления, outboxID последний выданный идентификатор.
+	outbox   []model.OutboxMessage
+	outboxID int64
+	// relayMu не дает двум ретрансляторам опубликовать одни и те же сообщения outbox.
+	relayMu sync.Mutex
+	// deliveries время доставки сообщения по ключу идемпотентности и каналу.
+	deliveries map[string]map[model.Channel]time.Time
 }
 
 var (
-	ErrEventNotFound = errors.New("event not found")
-	ErrUserNotFound  = errors.New("user not found")
+	ErrEventNotFound = model.ErrEventNotFound
+	ErrUserNotFound  = model.ErrUserNotFound
 )
 
 func New() *Storage {
 	return &Storage{
-		events: make(map[string]model.Event),
-		users:  make(map[string]model.User),
+		events:  make(map[string]model.Event),
+		users:   make(map[string]model.User),
+		apiKeys: make(map[string]model.APIKey),
+		search:  make(searchIndex),
+
+		calendars: make(map[string]model.Calendar),
+		attendees: make(map[string]map[string]model.Attendee),
+
+		workingHours:         make(map[string]model.WorkingHours),
+		notificationSettings: make(map[string]model.NotificationSettings),
+		deliveries:           make(map[string]map[model.Channel]time.Time),
 	}
 }
 
 // CreateUser создает нового пользователя и добавляет его в map пользователей.
-func (s *Storage) CreateUser(_ context.Context, user model.User) error {
+// Возвращает сохраненного пользователя с присвоенным идентификатором.
+func (s *Storage) CreateUser(_ context.Context, user model.User) (model.User, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	user.ID = uuid.New().String()
 	s.users[user.ID] = user
 
-	return nil
+	return user, nil
 }
 
 // DeleteUser удаляет пользователя по его идентификатору.
@@ -49,18 +76,109 @@ func (s *Storage) DeleteUser(_ context.Context, userID string) error {
 	}
 
 	delete(s.users, userID)
+	delete(s.workingHours, userID)
+	delete(s.notificationSettings, userID)
+
+	// Как ON DELETE CASCADE в SQL: удаляются календари пользователя, его доступ к чужим календарям,
+	// его события и события его календарей, а также его участие в событиях.
+	for id, calendar := range s.calendars {
+		if calendar.OwnerID == userID {
+			delete(s.calendars, id)
+			continue
+		}
+		acl := make([]model.ACLEntry, 0, len(calendar.ACL))
+		for _, entry := range calendar.ACL {
+			if entry.UserID != userID {
+				acl = append(acl, entry)
+			}
+		}
+		calendar.ACL = acl
+		s.calendars[id] = calendar
+	}
+	for eventID, event := range s.events {
+		_, calendarExists := s.calendars[event.CalendarID]
+		if event.UserID == userID || event.CalendarID != "" && !calendarExists {
+			delete(s.events, eventID)
+			delete(s.attendees, eventID)
+			s.search.remove(event)
+		}
+	}
+	for _, attendees := range s.attendees {
+		delete(attendees, userID)
+	}
 	return nil
 }
 
 // CreateEvent cоздает новое событие и добавляет его в map событий.
-func (s *Storage) CreateEvent(_ context.Context, event model.Event) error {
+// Возвращает сохраненное событие с присвоенным идентификатором, model.ErrDateBusy,
+// если событие пересекается с другим событием пользователя, или model.ErrCalendarNotFound.
+func (s *Storage) CreateEvent(_ context.Context, event model.Event) (model.Event, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	event.ID = uuid.New().String()
+	event.Reminders = withReminderIDs(event)
+	if err := s.checkCalendar(event); err != nil {
+		return model.Event{}, err
+	}
+	if err := s.checkDateBusy(event); err != nil {
+		return model.Event{}, err
+	}
+
 	s.events[event.ID] = event
+	s.search.add(event)
 
-	return nil
+	return event, nil
+}
+
+// ImportEvents создает события загруженного календаря: либо все, либо ни одного, если хотя бы одно
+// создать не удалось. Событие, UID которого совпадает с UID или идентификатором события того же
+// пользователя, уже загружено и пропускается. Возвращает созданные события и число пропущенных.
+func (s *Storage) ImportEvents(_ context.Context, events []model.Event) ([]model.Event, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created := make([]model.Event, 0, len(events))
+	skipped := 0
+	for _, event := range events {
+		if s.imported(event) {
+			skipped++
+			continue
+		}
+
+		event.ID = uuid.New().String()
+		event.Reminders = withReminderIDs(event)
+		err := s.checkCalendar(event)
+		if err == nil {
+			err = s.checkDateBusy(event)
+		}
+		if err != nil {
+			for _, event := range created {
+				delete(s.events, event.ID)
+				s.search.remove(event)
+			}
+			return nil, 0, err
+		}
+
+		s.events[event.ID] = event
+		s.search.add(event)
+		created = append(created, event)
+	}
+
+	return created, skipped, nil
+}
+
+// imported проверяет, есть ли у пользователя события с UID загружаемого события.
+func (s *Storage) imported(event model.Event) bool {
+	if event.UID == "" {
+		return false
+	}
+	for _, existing := range s.events {
+		if existing.UserID == event.UserID && (existing.UID == event.UID || existing.ID == event.UID) {
+			return true
+		}
+	}
+	return false
 }
 
 // DeleteEvent удаляет событие по его идентификатору.
@@ -68,28 +186,46 @@ func (s *Storage) DeleteEvent(_ context.Context, eventID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.events[eventID]; !ok {
+	event, ok := s.events[eventID]
+	if !ok {
 		return ErrEventNotFound
 	}
 
 	delete(s.events, eventID)
+	delete(s.attendees, eventID)
+	s.search.remove(event)
 	return nil
 }
 
-// UpdateEvent обновляет существующее событие в map событий.
+// UpdateEvent обновляет существующее событие в map событий, напоминания заменяются новыми.
+// Отметка об отправленных уведомлениях заменяется на event.Notified, если изменились время
+// или повторение события. Возвращает model.ErrDateBusy, если событие пересекается с другим событием пользователя.
 func (s *Storage) UpdateEvent(_ context.Context, event model.Event) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.events[event.ID]; !ok {
+	current, ok := s.events[event.ID]
+	if !ok {
 		return ErrEventNotFound
 	}
 
+	if err := s.checkCalendar(event); err != nil {
+		return err
+	}
+	if err := s.checkDateBusy(event); err != nil {
+		return err
+	}
+
+	event.UID = current.UID
+	event.Reminders = withReminderIDs(event)
+	event.Notified = keepNotified(current, event)
+	s.search.remove(current)
 	s.events[event.ID] = event
+	s.search.add(event)
 	return nil
 }
 
-// SelectEvents возвращает все события.
+// SelectEvents возвращает все события в порядке начала.
 func (s *Storage) SelectEvents(_ context.Context) ([]model.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -98,11 +234,27 @@ func (s *Storage) SelectEvents(_ context.Context) ([]model.Event, error) {
 	for _, event := range s.events {
 		events = append(events, event)
 	}
+	sort.Slice(events, func(i, j int) bool {
+		return model.CompareEvents(events[i], events[j], model.OrderByBeginning) < 0
+	})
 
 	return events, nil
 }
 
-// SelectUsers возвращает всех пользователей.
+// SelectEvent возвращает событие по его идентификатору.
+func (s *Storage) SelectEvent(_ context.Context, eventID string) (model.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event, ok := s.events[eventID]
+	if !ok {
+		return model.Event{}, ErrEventNotFound
+	}
+
+	return event, nil
+}
+
+// SelectUsers возвращает всех пользователей в порядке фамилии.
 func (s *Storage) SelectUsers(_ context.Context) ([]model.User, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -111,34 +263,67 @@ func (s *Storage) SelectUsers(_ context.Context) ([]model.User, error) {
 	for _, user := range s.users {
 		users = append(users, user)
 	}
+	sort.Slice(users, func(i, j int) bool {
+		return model.CompareUsers(users[i], users[j], model.OrderByLastName) < 0
+	})
 
 	return users, nil
 }
 
-// SelectEventsForDay возвращает события на указанный день.
+// SelectEventsForDay возвращает события на день, в который попадает date в своем часовом поясе.
 func (s *Storage) SelectEventsForDay(_ context.Context, date time.Time) ([]model.Event, error) {
+	dayStart := model.DayStart(date, date.Location())
+	return s.selectEvents(dayStart, dayStart.AddDate(0, 0, 1))
+}
+
+// SelectEventsForWeek возвращает события на неделю, начиная с указанной даты.
+func (s *Storage) SelectEventsForWeek(_ context.Context, startDate time.Time) ([]model.Event, error) {
+	return s.selectEvents(startDate, startDate.AddDate(0, 0, 7))
+}
+
+// SelectEventsForMonth возвращает события на месяц, начиная с указанной даты.
+func (s *Storage) SelectEventsForMonth(_ context.Context, startDate time.Time) ([]model.Event, error) {
+	return s.selectEvents(startDate, startDate.AddDate(0, 1, 0))
+}
+
+// selectEvents возвращает события и повторения, начинающиеся в интервале [start, end).
+// Границы вычисляются вызывающим через AddDate в часовом поясе запроса,
+// поэтому день перехода на летнее время длится 23 или 25 часов.
+func (s *Storage) selectEvents(start, end time.Time) ([]model.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	events := make([]model.Event, 0)
 	for _, event := range s.events {
-		if event.Beginning.Year() == date.Year() && event.Beginning.YearDay() == date.YearDay() {
-			events = append(events, event)
+		occurrences, err := event.Occurrences(start, end)
+		if err != nil {
+			return nil, err
 		}
+		events = append(events, occurrences...)
 	}
 
 	return events, nil
 }
 
-// SelectEventsForWeek возвращает события на неделю, начиная с указанной даты.
-func (s *Storage) SelectEventsForWeek(_ context.Context, startDate time.Time) ([]model.Event, error) {
+// SelectEventsByTime возвращает список событий, которые должны быть уведомлены в указанное время.
+// Для повторяющихся событий возвращается каждое повторение, уведомление о котором приходится на t.
+func (s *Storage) SelectEventsByTime(_ context.Context, t time.Time) ([]model.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	events := make([]model.Event, 0)
-	endDate := startDate.AddDate(0, 0, 7)
+
 	for _, event := range s.events {
-		if event.Beginning.After(startDate) && event.Beginning.Before(endDate) {
+		if event.Recurrence != "" {
+			occurrences, err := event.NotifiedAt(t)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, occurrences...)
+			continue
+		}
+
+		if event.Notification.Equal(t) {
 			events = append(events, event)
 		}
 	}
@@ -146,31 +331,80 @@ func (s *Storage) SelectEventsForWeek(_ context.Context, startDate time.Time) ([
 	return events, nil
 }
 
-// SelectEventsForMonth возвращает события на месяц, начиная с указанной даты.
-func (s *Storage) SelectEventsForMonth(_ context.Context, startDate time.Time) ([]model.Event, error) {
+// SelectDueNotifications возвращает повторения событий, уведомления о которых наступили к моменту now,
+// но еще не отправлены, в порядке времени уведомления.
+func (s *Storage) SelectDueNotifications(_ context.Context, now, since time.Time) ([]model.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	events := make([]model.Event, 0)
-	endDate := startDate.AddDate(0, 1, 0)
 	for _, event := range s.events {
-		if event.Beginning.After(startDate) && event.Beginning.Before(endDate) {
-			events = append(events, event)
+		pending, err := event.PendingNotifications(now, since)
+		if err != nil {
+			return nil, err
 		}
+		events = append(events, pending...)
 	}
-
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Notification.Before(events[j].Notification)
+	})
 	return events, nil
 }
 
-// SelectEventsByTime возвращает список событий, которые должны быть уведомлены в указанное время.
-func (s *Storage) SelectEventsByTime(_ context.Context, t time.Time) ([]model.Event, error) {
-	events := make([]model.Event, 0)
+// MarkNotified отмечает отправленными уведомления события со временем не позже notification.
+// Сообщения outbox сохраняются вместе с отметкой, только если она сдвинулась.
+func (s *Storage) MarkNotified(
+	_ context.Context, eventID string, notification time.Time, outbox ...model.OutboxMessage,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	for _, event := range s.events {
-		if event.Notification.Equal(t) {
-			events = append(events, event)
+	event, ok := s.events[eventID]
+	if ok && notification.After(event.Notified) {
+		event.Notified = notification
+		s.events[eventID] = event
+		s.addOutbox(outbox)
+	}
+	return nil
+}
+
+// keepNotified возвращает отметку об отправленных уведомлениях для измененного события:
+// прежняя сохраняется, только если время и повторение события не изменились.
+func keepNotified(current, event model.Event) time.Time {
+	if current.SameSchedule(event) && current.Notification.Equal(event.Notification) {
+		return current.Notified
+	}
+	return event.Notified
+}
+
+// checkCalendar проверяет, что календарь события существует. Вызывается под блокировкой.
+func (s *Storage) checkCalendar(event model.Event) error {
+	if event.CalendarID == "" {
+		return nil
+	}
+	if _, ok := s.calendars[event.CalendarID]; !ok {
+		return model.ErrCalendarNotFound
+	}
+	return nil
+}
+
+// checkDateBusy проверяет, не пересекается ли событие с другими событиями того же пользователя.
+// Вызывается под блокировкой на запись.
+func (s *Storage) checkDateBusy(event model.Event) error {
+	for _, existing := range s.events {
+		if existing.ID == event.ID || existing.UserID != event.UserID {
+			continue
+		}
+
+		existing := existing
+		overlaps, err := event.Overlaps(&existing)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return model.ErrDateBusy
 		}
 	}
 
-	return events, nil
+	return nil
 }
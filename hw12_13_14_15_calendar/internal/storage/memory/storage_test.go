@@ -2,6 +2,10 @@ package memorystorage
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,7 +34,9 @@ func TestStorage(t *testing.T) {
 		}
 
 		for _, user := range users {
-			require.Nil(t, s.CreateUser(ctx, user))
+			created, err := s.CreateUser(ctx, user)
+			require.Nil(t, err)
+			require.NotEmpty(t, created.ID)
 		}
 
 		selectedUsers, err := s.SelectUsers(ctx)
@@ -57,12 +63,9 @@ func TestStorage(t *testing.T) {
 			Age:       22,
 		}
 
-		require.Nil(t, s.CreateUser(ctx, user))
-		selectedUsers, err := s.SelectUsers(ctx)
+		user, err := s.CreateUser(ctx, user)
 		require.Nil(t, err)
 
-		user = selectedUsers[0]
-
 		events := []model.Event{
 			{
 				Title:        "Просмотр фильма",
@@ -83,7 +86,9 @@ func TestStorage(t *testing.T) {
 		}
 
 		for _, event := range events {
-			require.Nil(t, s.CreateEvent(ctx, event))
+			created, err := s.CreateEvent(ctx, event)
+			require.Nil(t, err)
+			require.NotEmpty(t, created.ID)
 		}
 
 		selectedEvents, err := s.SelectEvents(ctx)
@@ -107,6 +112,151 @@ func TestStorage(t *testing.T) {
 
 		require.Nil(t, s.DeleteUser(ctx, user.ID))
 	})
+
+	t.Run("recurring event case", func(t *testing.T) {
+		s := New()
+		ctx := context.Background()
+
+		beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+		event := model.Event{
+			Title:        "Планерка",
+			Beginning:    beginning,
+			Finish:       beginning.Add(time.Hour),
+			Notification: beginning.Add(-15 * time.Minute),
+			Recurrence:   "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=6",
+			ExDates:      []time.Time{beginning.AddDate(0, 0, 7)},
+		}
+		_, err := s.CreateEvent(ctx, event)
+		require.Nil(t, err)
+
+		dayEvents, err := s.SelectEventsForDay(ctx, time.Date(2024, time.June, 13, 0, 0, 0, 0, time.UTC))
+		require.Nil(t, err)
+		require.Len(t, dayEvents, 1)
+		require.Equal(t, time.Date(2024, time.June, 13, 10, 0, 0, 0, time.UTC), dayEvents[0].Beginning)
+
+		dayEvents, err = s.SelectEventsForDay(ctx, time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC))
+		require.Nil(t, err)
+		require.Len(t, dayEvents, 0)
+
+		weekEvents, err := s.SelectEventsForWeek(ctx, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC))
+		require.Nil(t, err)
+		require.Len(t, weekEvents, 2)
+
+		monthEvents, err := s.SelectEventsForMonth(ctx, time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+		require.Nil(t, err)
+		require.Len(t, monthEvents, 5)
+
+		notified, err := s.SelectEventsByTime(ctx, time.Date(2024, time.June, 17, 9, 45, 0, 0, time.UTC))
+		require.Nil(t, err)
+		require.Len(t, notified, 1)
+		require.Equal(t, time.Date(2024, time.June, 17, 10, 0, 0, 0, time.UTC), notified[0].Beginning)
+	})
+}
+
+func TestStorageTimeZones(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	create := func(title string, beginning time.Time) {
+		_, err := s.CreateEvent(ctx, model.Event{Title: title, Beginning: beginning, Finish: beginning.Add(time.Hour)})
+		require.NoError(t, err)
+	}
+	titles := func(events []model.Event) []string {
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.Title)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	t.Run("day in requester time zone", func(t *testing.T) {
+		// 20:00 UTC 1 июня - это 05:00 2 июня в Токио.
+		create("Созвон", time.Date(2024, time.June, 1, 20, 0, 0, 0, time.UTC))
+
+		events, err := s.SelectEventsForDay(ctx, time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Созвон"}, titles(events))
+
+		events, err = s.SelectEventsForDay(ctx, time.Date(2024, time.June, 1, 0, 0, 0, 0, tokyo))
+		require.NoError(t, err)
+		require.Empty(t, events)
+
+		events, err = s.SelectEventsForDay(ctx, time.Date(2024, time.June, 2, 0, 0, 0, 0, tokyo))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Созвон"}, titles(events))
+	})
+
+	t.Run("daylight saving day lasts 23 hours", func(t *testing.T) {
+		create("Поздно вечером", time.Date(2024, time.March, 31, 23, 30, 0, 0, berlin))
+		create("Сразу после полуночи", time.Date(2024, time.April, 1, 0, 30, 0, 0, berlin))
+
+		events, err := s.SelectEventsForDay(ctx, time.Date(2024, time.March, 31, 12, 0, 0, 0, berlin))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Поздно вечером"}, titles(events))
+	})
+
+	t.Run("week starts at midnight inclusively", func(t *testing.T) {
+		create("В полночь", time.Date(2024, time.July, 1, 0, 0, 0, 0, tokyo))
+
+		events, err := s.SelectEventsForWeek(ctx, time.Date(2024, time.July, 1, 0, 0, 0, 0, tokyo))
+		require.NoError(t, err)
+		require.Equal(t, []string{"В полночь"}, titles(events))
+	})
+}
+
+func TestStorageDateBusy(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	meeting := model.Event{
+		Title:     "Планерка",
+		Beginning: beginning,
+		Finish:    beginning.Add(time.Hour),
+		UserID:    "user",
+	}
+	_, err := s.CreateEvent(ctx, meeting)
+	require.Nil(t, err)
+
+	overlapping := meeting
+	overlapping.Beginning = beginning.Add(30 * time.Minute)
+	overlapping.Finish = beginning.Add(90 * time.Minute)
+	_, err = s.CreateEvent(ctx, overlapping)
+	require.ErrorIs(t, err, model.ErrDateBusy)
+
+	otherUser := overlapping
+	otherUser.UserID = "other"
+	_, err = s.CreateEvent(ctx, otherUser)
+	require.Nil(t, err)
+
+	series := meeting
+	series.Beginning = beginning.AddDate(0, 0, -7).Add(30 * time.Minute)
+	series.Finish = series.Beginning.Add(time.Hour)
+	series.Recurrence = "FREQ=WEEKLY"
+	_, err = s.CreateEvent(ctx, series)
+	require.ErrorIs(t, err, model.ErrDateBusy)
+
+	series.ExDates = []time.Time{series.Beginning.AddDate(0, 0, 7)}
+	_, err = s.CreateEvent(ctx, series)
+	require.Nil(t, err)
+
+	events, err := s.SelectEventsForDay(ctx, beginning)
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+
+	for _, event := range events {
+		if event.UserID != "user" {
+			continue
+		}
+		// Обновление события не конфликтует с ним самим.
+		event.Finish = event.Finish.Add(-30 * time.Minute)
+		require.Nil(t, s.UpdateEvent(ctx, event))
+	}
 }
 
 func containsUser(users []model.User, u model.User) bool {
@@ -134,3 +284,719 @@ func containsEvent(events []model.Event, e model.Event) bool {
 	}
 	return false
 }
+
+func TestStorageAPIKeys(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	created, err := s.CreateAPIKey(ctx, model.APIKey{Name: "batch", Hash: "hash", Scopes: []string{"events:read"}})
+	require.Nil(t, err)
+	require.NotEmpty(t, created.ID)
+	require.False(t, created.CreatedAt.IsZero())
+
+	selected, err := s.SelectAPIKeyByHash(ctx, "hash")
+	require.Nil(t, err)
+	require.Equal(t, created, selected)
+
+	_, err = s.SelectAPIKeyByHash(ctx, "other")
+	require.ErrorIs(t, err, model.ErrAPIKeyNotFound)
+
+	require.Nil(t, s.RevokeAPIKey(ctx, created.ID))
+	revoked, err := s.SelectAPIKeyByHash(ctx, "hash")
+	require.Nil(t, err)
+	require.True(t, revoked.Revoked())
+
+	// Повторный отзыв не меняет время отзыва.
+	require.Nil(t, s.RevokeAPIKey(ctx, created.ID))
+	keys, err := s.SelectAPIKeys(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []model.APIKey{revoked}, keys)
+
+	require.ErrorIs(t, s.RevokeAPIKey(ctx, "unknown"), model.ErrAPIKeyNotFound)
+}
+
+func TestStorageListEvents(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	for _, event := range []model.Event{
+		{Title: "Планерка", UserID: "alice", Beginning: day.Add(10 * time.Hour), Finish: day.Add(11 * time.Hour)},
+		{Title: "Обед", UserID: "alice", Beginning: day.Add(13 * time.Hour), Finish: day.Add(14 * time.Hour)},
+		{Title: "Обед", UserID: "bob", Beginning: day.Add(13 * time.Hour), Finish: day.Add(14 * time.Hour)},
+		{
+			Title: "Ежедневная планерка", UserID: "bob", Recurrence: "FREQ=DAILY",
+			Beginning: day.AddDate(0, 0, -10).Add(9 * time.Hour), Finish: day.AddDate(0, 0, -10).Add(10 * time.Hour),
+		},
+	} {
+		_, err := s.CreateEvent(ctx, event)
+		require.Nil(t, err)
+	}
+
+	titles := func(events []model.Event) []string {
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.Title)
+		}
+		return result
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		events, next, err := s.ListEvents(ctx, model.EventQuery{
+			EventFilter: model.EventFilter{Title: "планерка"}, OrderBy: model.OrderByBeginning, PageSize: 10,
+		})
+		require.Nil(t, err)
+		require.Nil(t, next)
+		require.Equal(t, []string{"Ежедневная планерка", "Планерка"}, titles(events))
+
+		events, _, err = s.ListEvents(ctx, model.EventQuery{
+			EventFilter: model.EventFilter{UserID: "alice"}, OrderBy: model.OrderByTitleDesc, PageSize: 10,
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"Планерка", "Обед"}, titles(events))
+	})
+
+	t.Run("period expands recurrences", func(t *testing.T) {
+		events, _, err := s.ListEvents(ctx, model.EventQuery{
+			EventFilter: model.EventFilter{From: day, To: day.AddDate(0, 0, 2)},
+			OrderBy:     model.OrderByBeginning,
+			PageSize:    10,
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{
+			"Ежедневная планерка", "Планерка", "Обед", "Обед", "Ежедневная планерка",
+		}, titles(events))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		query := model.EventQuery{
+			EventFilter: model.EventFilter{From: day, To: day.AddDate(0, 0, 2)},
+			OrderBy:     model.OrderByBeginningDesc,
+			PageSize:    2,
+		}
+		var all []model.Event
+		for {
+			events, next, err := s.ListEvents(ctx, query)
+			require.Nil(t, err)
+			all = append(all, events...)
+			if next == nil {
+				break
+			}
+			query.After = next
+		}
+		require.Equal(t, []string{
+			"Ежедневная планерка", "Обед", "Обед", "Планерка", "Ежедневная планерка",
+		}, titles(all))
+	})
+}
+
+func TestStorageListUsers(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	for _, user := range []model.User{
+		{LastName: "Петров", Email: "b@example.com"},
+		{LastName: "Иванов", Email: "c@example.com"},
+		{LastName: "Сидоров", Email: "a@example.com"},
+	} {
+		_, err := s.CreateUser(ctx, user)
+		require.Nil(t, err)
+	}
+
+	users, next, err := s.ListUsers(ctx, model.UserQuery{OrderBy: model.OrderByEmail, PageSize: 2})
+	require.Nil(t, err)
+	require.Equal(t, []string{"a@example.com", "b@example.com"}, []string{users[0].Email, users[1].Email})
+	require.NotNil(t, next)
+
+	users, next, err = s.ListUsers(ctx, model.UserQuery{OrderBy: model.OrderByEmail, PageSize: 2, After: next})
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "c@example.com", users[0].Email)
+	require.Nil(t, next)
+
+	users, _, err = s.ListUsers(ctx, model.UserQuery{UserID: users[0].ID, OrderBy: model.OrderByLastName, PageSize: 10})
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "Иванов", users[0].LastName)
+}
+
+func TestStorageSearchEvents(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	create := func(title, description, userID string) model.Event {
+		created, err := s.CreateEvent(ctx, model.Event{
+			Title: title, Description: description, UserID: userID, Beginning: beginning, Finish: beginning,
+		})
+		require.Nil(t, err)
+		beginning = beginning.Add(time.Hour)
+		return created
+	}
+	review := create("Ревью: бюджет", "Обсуждаем бюджет на квартал", "alice")
+	planning := create("Планирование", "Бюджет отдела и найм", "alice")
+	create("Бюджет", "", "bob")
+	create("Обед", "Без бюджета", "alice")
+
+	search := func(text, userID string) []model.SearchResult {
+		results, err := s.SearchEvents(ctx, model.SearchQuery{Text: text, UserID: userID, Limit: 10})
+		require.Nil(t, err)
+		return results
+	}
+
+	t.Run("ranking and snippets", func(t *testing.T) {
+		results := search("БЮДЖЕТ", "alice")
+		require.Len(t, results, 2)
+		require.Equal(t, review.ID, results[0].Event.ID)
+		require.InDelta(t, 1.4, results[0].Rank, 1e-9)
+		require.Equal(t, planning.ID, results[1].Event.ID)
+		require.Equal(t,
+			"Планирование <mark>Бюджет</mark> отдела и найм", results[1].Snippet)
+	})
+
+	t.Run("snippet is escaped", func(t *testing.T) {
+		create("<script>alert(1)</script>", "Ретро & демо", "erin")
+
+		results := search("script", "erin")
+		require.Len(t, results, 1)
+		require.Equal(t,
+			"<mark>script</mark>&gt;alert(1)&lt;/<mark>script</mark>&gt; Ретро &amp; демо", results[0].Snippet)
+		require.NotContains(t, results[0].Snippet, "<script")
+	})
+
+	t.Run("all words must match", func(t *testing.T) {
+		results := search("бюджет найм", "")
+		require.Len(t, results, 1)
+		require.Equal(t, planning.ID, results[0].Event.ID)
+		require.Empty(t, search("бюджет отпуск", ""))
+		require.Empty(t, search(" ,. ", ""))
+	})
+
+	t.Run("index follows changes", func(t *testing.T) {
+		planning.Description = "Найм"
+		require.Nil(t, s.UpdateEvent(ctx, planning))
+		require.Len(t, search("бюджет", "alice"), 1)
+		require.Len(t, search("найм", ""), 1)
+
+		require.Nil(t, s.DeleteEvent(ctx, planning.ID))
+		require.Empty(t, search("найм", ""))
+	})
+
+	t.Run("long text is cut around first match", func(t *testing.T) {
+		words := make([]string, 0, 40)
+		for i := 0; i < 40; i++ {
+			words = append(words, fmt.Sprintf("слово%d", i))
+		}
+		words[10] = "Бюджет"
+		create("Длинное", strings.Join(words, " "), "carol")
+
+		results := search("бюджет", "carol")
+		require.Len(t, results, 1)
+		require.True(t, strings.HasPrefix(results[0].Snippet, "слово5 "), results[0].Snippet)
+		require.Contains(t, results[0].Snippet, "слово9 <mark>Бюджет</mark> слово11")
+		require.Len(t, strings.Fields(results[0].Snippet), 20)
+
+		words[10], words[30] = "слово10", "Бюджет"
+		created := create("Длинное", strings.Join(words, " "), "dave")
+
+		results = search("бюджет", "dave")
+		require.Len(t, results, 1)
+		require.Equal(t, created.ID, results[0].Event.ID)
+		require.True(t, strings.HasPrefix(results[0].Snippet, "слово20 "), results[0].Snippet)
+		require.Contains(t, results[0].Snippet, "слово29 <mark>Бюджет</mark> слово31")
+		require.Len(t, strings.Fields(results[0].Snippet), 20)
+	})
+}
+
+func TestStorageCalendars(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	alice, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	bob, err := s.CreateUser(ctx, model.User{FirstName: "Боб"})
+	require.Nil(t, err)
+
+	_, err = s.CreateCalendar(ctx, model.Calendar{Name: "Без владельца", OwnerID: "unknown", TimeZone: "UTC"})
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	team, err := s.CreateCalendar(ctx, model.Calendar{Name: "Команда", OwnerID: alice.ID, TimeZone: "Europe/Moscow"})
+	require.Nil(t, err)
+	require.NotEmpty(t, team.ID)
+	home, err := s.CreateCalendar(ctx, model.Calendar{Name: "Дом", OwnerID: bob.ID, TimeZone: "UTC"})
+	require.Nil(t, err)
+
+	calendars, err := s.SelectCalendars(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, []model.Calendar{home, team}, calendars)
+	calendars, err = s.SelectCalendars(ctx, bob.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.Calendar{home}, calendars)
+
+	require.ErrorIs(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: "unknown", UserID: bob.ID, Role: model.RoleRead}),
+		model.ErrCalendarNotFound)
+	require.ErrorIs(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: team.ID, UserID: "unknown", Role: model.RoleRead}),
+		ErrUserNotFound)
+	require.Nil(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: team.ID, UserID: bob.ID, Role: model.RoleRead}))
+	require.Nil(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: team.ID, UserID: bob.ID, Role: model.RoleWrite}))
+
+	selected, err := s.SelectCalendar(ctx, team.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.ACLEntry{{CalendarID: team.ID, UserID: bob.ID, Role: model.RoleWrite}}, selected.ACL)
+	calendars, err = s.SelectCalendars(ctx, bob.ID)
+	require.Nil(t, err)
+	require.Len(t, calendars, 2)
+
+	// Изменение копии не меняет хранилище.
+	selected.ACL[0].Role = model.RoleOwner
+	selected, err = s.SelectCalendar(ctx, team.ID)
+	require.Nil(t, err)
+	require.Equal(t, model.RoleWrite, selected.RoleOf(bob.ID))
+
+	team.Name, team.Color = "Команда разработки", "#ff0000"
+	require.Nil(t, s.UpdateCalendar(ctx, team))
+	selected, err = s.SelectCalendar(ctx, team.ID)
+	require.Nil(t, err)
+	require.Equal(t, "Команда разработки", selected.Name)
+	require.Len(t, selected.ACL, 1)
+
+	require.Nil(t, s.RevokeAccess(ctx, team.ID, bob.ID))
+	require.ErrorIs(t, s.RevokeAccess(ctx, team.ID, bob.ID), model.ErrAccessNotFound)
+
+	_, err = s.CreateEvent(ctx, model.Event{
+		Title: "Чужой", UserID: alice.ID, CalendarID: "unknown", Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.ErrorIs(t, err, model.ErrCalendarNotFound)
+	event, err := s.CreateEvent(ctx, model.Event{
+		Title: "Планерка", UserID: alice.ID, CalendarID: team.ID, Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.Nil(t, err)
+
+	// Удаление календаря удаляет его события.
+	require.Nil(t, s.DeleteCalendar(ctx, team.ID))
+	_, err = s.SelectEvent(ctx, event.ID)
+	require.ErrorIs(t, err, ErrEventNotFound)
+	results, err := s.SearchEvents(ctx, model.SearchQuery{Text: "планерка", Limit: 10})
+	require.Nil(t, err)
+	require.Empty(t, results)
+	_, err = s.SelectCalendar(ctx, team.ID)
+	require.ErrorIs(t, err, model.ErrCalendarNotFound)
+	require.ErrorIs(t, s.DeleteCalendar(ctx, team.ID), model.ErrCalendarNotFound)
+}
+
+func TestStorageAttendees(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	organizer, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	guest, err := s.CreateUser(ctx, model.User{FirstName: "Боб"})
+	require.Nil(t, err)
+	event, err := s.CreateEvent(ctx, model.Event{
+		Title: "Планерка", UserID: organizer.ID, Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.Nil(t, err)
+
+	require.ErrorIs(t, s.SetAttendee(ctx, model.Attendee{EventID: "unknown", UserID: guest.ID}), ErrEventNotFound)
+	require.ErrorIs(t, s.SetAttendee(ctx, model.Attendee{EventID: event.ID, UserID: "unknown"}), ErrUserNotFound)
+
+	attendee := model.Attendee{EventID: event.ID, UserID: guest.ID, Status: model.StatusNeedsAction}
+	require.Nil(t, s.SetAttendee(ctx, attendee))
+	attendee.Status = model.StatusAccepted
+	require.Nil(t, s.SetAttendee(ctx, attendee))
+
+	attendees, err := s.SelectAttendees(ctx, event.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.Attendee{attendee}, attendees)
+	attendances, err := s.SelectAttendances(ctx, guest.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.Attendee{attendee}, attendances)
+	_, err = s.SelectAttendees(ctx, "unknown")
+	require.ErrorIs(t, err, ErrEventNotFound)
+
+	require.Nil(t, s.DeleteAttendee(ctx, event.ID, guest.ID))
+	require.ErrorIs(t, s.DeleteAttendee(ctx, event.ID, guest.ID), model.ErrAttendeeNotFound)
+
+	// Удаление события удаляет его участников.
+	require.Nil(t, s.SetAttendee(ctx, attendee))
+	require.Nil(t, s.DeleteEvent(ctx, event.ID))
+	attendances, err = s.SelectAttendances(ctx, guest.ID)
+	require.Nil(t, err)
+	require.Empty(t, attendances)
+}
+
+func TestStorageDeleteUser(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	alice, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	bob, err := s.CreateUser(ctx, model.User{FirstName: "Боб"})
+	require.Nil(t, err)
+	carol, err := s.CreateUser(ctx, model.User{FirstName: "Кэрол"})
+	require.Nil(t, err)
+
+	team, err := s.CreateCalendar(ctx, model.Calendar{Name: "Команда", OwnerID: alice.ID, TimeZone: "UTC"})
+	require.Nil(t, err)
+	home, err := s.CreateCalendar(ctx, model.Calendar{Name: "Дом", OwnerID: bob.ID, TimeZone: "UTC"})
+	require.Nil(t, err)
+	require.Nil(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: team.ID, UserID: bob.ID, Role: model.RoleWrite}))
+	require.Nil(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: home.ID, UserID: alice.ID, Role: model.RoleRead}))
+	require.Nil(t, s.SetAccess(ctx, model.ACLEntry{CalendarID: home.ID, UserID: carol.ID, Role: model.RoleRead}))
+
+	create := func(title, userID, calendarID string, hour int) model.Event {
+		t.Helper()
+		start := beginning.Add(time.Duration(hour) * time.Hour)
+		event, err := s.CreateEvent(ctx, model.Event{
+			Title: title, UserID: userID, CalendarID: calendarID, Beginning: start, Finish: start.Add(time.Hour),
+		})
+		require.Nil(t, err)
+		return event
+	}
+	personal := create("Личное", alice.ID, "", 0)
+	inTeam := create("Ретро", bob.ID, team.ID, 1)
+	inHome := create("Ужин", alice.ID, home.ID, 2)
+	kept := create("Планерка", bob.ID, "", 3)
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: kept.ID, UserID: alice.ID, Status: model.StatusAccepted}))
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: kept.ID, UserID: carol.ID, Status: model.StatusAccepted}))
+
+	// Удаление пользователя удаляет все, что в SQL удаляется каскадно.
+	require.Nil(t, s.DeleteUser(ctx, alice.ID))
+	require.ErrorIs(t, s.DeleteUser(ctx, alice.ID), ErrUserNotFound)
+
+	_, err = s.SelectCalendar(ctx, team.ID)
+	require.ErrorIs(t, err, model.ErrCalendarNotFound)
+	selected, err := s.SelectCalendar(ctx, home.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.ACLEntry{{CalendarID: home.ID, UserID: carol.ID, Role: model.RoleRead}}, selected.ACL)
+
+	for _, event := range []model.Event{personal, inTeam, inHome} {
+		_, err = s.SelectEvent(ctx, event.ID)
+		require.ErrorIs(t, err, ErrEventNotFound, event.Title)
+	}
+	results, err := s.SearchEvents(ctx, model.SearchQuery{Text: "ретро", Limit: 10})
+	require.Nil(t, err)
+	require.Empty(t, results)
+
+	_, err = s.SelectEvent(ctx, kept.ID)
+	require.Nil(t, err)
+	attendees, err := s.SelectAttendees(ctx, kept.ID)
+	require.Nil(t, err)
+	require.Equal(t, []model.Attendee{{EventID: kept.ID, UserID: carol.ID, Status: model.StatusAccepted}}, attendees)
+}
+
+func TestStorageFreeBusy(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	alice, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	bob, err := s.CreateUser(ctx, model.User{FirstName: "Боб"})
+	require.Nil(t, err)
+
+	standup, err := s.CreateEvent(ctx, model.Event{
+		Title: "Стендап", UserID: alice.ID, Beginning: beginning, Finish: beginning.Add(30 * time.Minute),
+		Recurrence: "FREQ=DAILY;COUNT=5",
+	})
+	require.Nil(t, err)
+	review, err := s.CreateEvent(ctx, model.Event{
+		Title: "Ревью", UserID: alice.ID, Beginning: beginning.Add(4 * time.Hour), Finish: beginning.Add(5 * time.Hour),
+	})
+	require.Nil(t, err)
+	_, err = s.CreateEvent(ctx, model.Event{
+		Title: "Отпуск", UserID: alice.ID, Beginning: beginning.AddDate(0, 1, 0), Finish: beginning.AddDate(0, 1, 1),
+	})
+	require.Nil(t, err)
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: standup.ID, UserID: bob.ID, Status: model.StatusAccepted}))
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: review.ID, UserID: bob.ID, Status: model.StatusDeclined}))
+
+	from, to := beginning.Add(-time.Hour), beginning.Add(23*time.Hour)
+	busy, err := s.SelectBusy(ctx, []string{alice.ID, bob.ID}, from, to)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []model.BusyInterval{
+		{UserID: alice.ID, Interval: model.Interval{Start: beginning, End: beginning.Add(30 * time.Minute)}},
+		{UserID: alice.ID, Interval: model.Interval{Start: beginning.Add(4 * time.Hour), End: beginning.Add(5 * time.Hour)}},
+		{UserID: bob.ID, Interval: model.Interval{Start: beginning, End: beginning.Add(30 * time.Minute)}},
+	}, busy)
+
+	_, err = s.SelectBusy(ctx, []string{alice.ID, "unknown"}, from, to)
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	hours := model.WorkingHours{UserID: bob.ID, TimeZone: "UTC", Start: "09:00", End: "18:00", Days: []int{1, 2, 3, 4, 5}}
+	require.ErrorIs(t, s.SetWorkingHours(ctx, model.WorkingHours{UserID: "unknown"}), ErrUserNotFound)
+	require.Nil(t, s.SetWorkingHours(ctx, hours))
+	selected, err := s.SelectWorkingHours(ctx, []string{alice.ID, bob.ID})
+	require.Nil(t, err)
+	require.Equal(t, []model.WorkingHours{hours}, selected)
+
+	require.Nil(t, s.DeleteWorkingHours(ctx, bob.ID))
+	require.ErrorIs(t, s.DeleteWorkingHours(ctx, bob.ID), model.ErrWorkingHoursNotFound)
+}
+
+func TestStorageNotificationSettings(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	alice, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+
+	_, err = s.SelectNotificationSettings(ctx, alice.ID)
+	require.ErrorIs(t, err, model.ErrNotificationSettingsNotFound)
+	require.ErrorIs(t, s.SetNotificationSettings(ctx, model.NotificationSettings{UserID: "unknown"}), ErrUserNotFound)
+
+	settings := model.NotificationSettings{
+		UserID: alice.ID, Channels: []model.Channel{model.ChannelEmail, model.ChannelWebhook},
+		WebhookURL: "https://example.com/hook",
+	}
+	require.Nil(t, s.SetNotificationSettings(ctx, settings))
+	selected, err := s.SelectNotificationSettings(ctx, alice.ID)
+	require.Nil(t, err)
+	require.Equal(t, settings, selected)
+
+	require.Nil(t, s.DeleteNotificationSettings(ctx, alice.ID))
+	require.ErrorIs(t, s.DeleteNotificationSettings(ctx, alice.ID), model.ErrNotificationSettingsNotFound)
+
+	require.Nil(t, s.SetNotificationSettings(ctx, settings))
+	require.Nil(t, s.DeleteUser(ctx, alice.ID))
+	_, err = s.SelectNotificationSettings(ctx, alice.ID)
+	require.ErrorIs(t, err, model.ErrNotificationSettingsNotFound)
+}
+
+func TestStorageReminders(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	user, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	event, err := s.CreateEvent(ctx, model.Event{
+		Title: "Планерка", UserID: user.ID, Beginning: beginning, Finish: beginning.Add(time.Hour),
+		Reminders: []model.Reminder{
+			{MinutesBefore: 30, FireAt: beginning.Add(-30 * time.Minute), Occurrence: beginning},
+			{MinutesBefore: 10, FireAt: beginning.Add(-10 * time.Minute), Occurrence: beginning},
+			{MinutesBefore: 60},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, event.Reminders, 3)
+	for _, reminder := range event.Reminders {
+		require.NotEmpty(t, reminder.ID)
+		require.Equal(t, event.ID, reminder.EventID)
+	}
+
+	due, err := s.SelectDueReminders(ctx, beginning.Add(-20*time.Minute))
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, event.Reminders[0], due[0].Reminder)
+	require.Equal(t, event.ID, due[0].Event.ID)
+	due, err = s.SelectDueReminders(ctx, beginning)
+	require.Nil(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, event.Reminders[1].ID, due[1].Reminder.ID)
+
+	// Напоминание забирается только один раз.
+	sent := event.Reminders[0]
+	sent.FireAt, sent.Occurrence, sent.SentAt = time.Time{}, time.Time{}, beginning
+	claimed, err := s.ClaimReminder(ctx, event.Reminders[0], sent)
+	require.Nil(t, err)
+	require.True(t, claimed)
+	claimed, err = s.ClaimReminder(ctx, event.Reminders[0], sent)
+	require.Nil(t, err)
+	require.False(t, claimed)
+
+	stored, err := s.SelectEvent(ctx, event.ID)
+	require.Nil(t, err)
+	require.Equal(t, sent, stored.Reminders[0])
+	require.Equal(t, event.Reminders[0].FireAt, due[0].Event.Reminders[0].FireAt)
+	due, err = s.SelectDueReminders(ctx, beginning)
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+
+	// Напоминания, сохраненные с идентификаторами, переживают изменение события без повторной отправки.
+	stored.Title = "Еженедельная планерка"
+	require.Nil(t, s.UpdateEvent(ctx, stored))
+	updated, err := s.SelectEvent(ctx, event.ID)
+	require.Nil(t, err)
+	require.Equal(t, stored.Reminders, updated.Reminders)
+	due, err = s.SelectDueReminders(ctx, beginning)
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, event.Reminders[1].ID, due[0].Reminder.ID)
+
+	// Удаление события удаляет его напоминания.
+	require.Nil(t, s.DeleteEvent(ctx, event.ID))
+	claimed, err = s.ClaimReminder(ctx, event.Reminders[1], sent)
+	require.Nil(t, err)
+	require.False(t, claimed)
+	due, err = s.SelectDueReminders(ctx, beginning)
+	require.Nil(t, err)
+	require.Empty(t, due)
+}
+
+func TestStorageDueNotifications(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	event, err := s.CreateEvent(ctx, model.Event{
+		Title: "Планерка", Beginning: beginning, Finish: beginning.Add(time.Hour),
+		Notification: beginning.Add(-15 * time.Minute),
+	})
+	require.Nil(t, err)
+	_, err = s.CreateEvent(ctx, model.Event{
+		Title: "Обед", Beginning: beginning.Add(3 * time.Hour), Finish: beginning.Add(4 * time.Hour),
+		Notification: beginning.Add(-20 * time.Minute),
+	})
+	require.Nil(t, err)
+
+	now := beginning.Add(-10 * time.Minute)
+	due, err := s.SelectDueNotifications(ctx, now, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, "Обед", due[0].Title)
+	require.Equal(t, "Планерка", due[1].Title)
+
+	// Отметка не сдвигается назад.
+	require.Nil(t, s.MarkNotified(ctx, event.ID, event.Notification))
+	require.Nil(t, s.MarkNotified(ctx, event.ID, event.Notification.Add(-time.Hour)))
+	require.Nil(t, s.MarkNotified(ctx, "unknown", event.Notification))
+	due, err = s.SelectDueNotifications(ctx, now, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, "Обед", due[0].Title)
+
+	// Отметка сохраняется при изменении описания и сбрасывается при переносе события.
+	event.Description = "Ежедневная"
+	require.Nil(t, s.UpdateEvent(ctx, event))
+	due, err = s.SelectDueNotifications(ctx, now, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+
+	event.Beginning = beginning.Add(time.Minute)
+	event.Notification = beginning.Add(-14 * time.Minute)
+	require.Nil(t, s.UpdateEvent(ctx, event))
+	due, err = s.SelectDueNotifications(ctx, now, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, due, 2)
+
+	// Уведомление о начавшемся событии не теряется, например если планировщик был остановлен,
+	// пока оно не устарело к since.
+	late := beginning.Add(5 * time.Hour)
+	due, err = s.SelectDueNotifications(ctx, late, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, due, 2)
+	due, err = s.SelectDueNotifications(ctx, late, beginning.Add(-15*time.Minute))
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, "Планерка", due[0].Title)
+}
+
+func TestStorageOutbox(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+
+	user, err := s.CreateUser(ctx, model.User{FirstName: "Алиса"})
+	require.Nil(t, err)
+	event, err := s.CreateEvent(ctx, model.Event{
+		Title: "Планерка", UserID: user.ID, Beginning: beginning, Finish: beginning.Add(time.Hour),
+	})
+	require.Nil(t, err)
+
+	var relayed []string
+	relay := func(limit int) int {
+		n, err := s.RelayOutbox(ctx, limit, func(message model.OutboxMessage) error {
+			relayed = append(relayed, message.Key)
+			return nil
+		})
+		require.Nil(t, err)
+		return n
+	}
+
+	// Сообщение сохраняется вместе с отметкой, только если она сдвинулась.
+	notification := beginning.Add(-15 * time.Minute)
+	message := func(key string) model.OutboxMessage { return model.OutboxMessage{Key: key, Body: []byte("{}")} }
+	require.Nil(t, s.MarkNotified(ctx, event.ID, notification, message("first")))
+	require.Nil(t, s.MarkNotified(ctx, event.ID, notification, message("again")))
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: event.ID, UserID: user.ID}, message("second")))
+	// Ключ, который уже ждет публикации, не дублируется.
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: event.ID, UserID: user.ID}, message("second")))
+
+	require.Equal(t, 1, relay(1))
+	require.Equal(t, 1, relay(10))
+	require.Equal(t, 0, relay(10))
+	require.Equal(t, []string{"first", "second"}, relayed)
+
+	// Неопубликованные сообщения остаются в outbox.
+	require.Nil(t, s.SetAttendee(ctx, model.Attendee{EventID: event.ID, UserID: user.ID}, message("third")))
+	n, err := s.RelayOutbox(ctx, 10, func(model.OutboxMessage) error { return errors.New("unavailable") })
+	require.Error(t, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, 1, relay(10))
+
+	t.Run("deliveries", func(t *testing.T) {
+		delivered := time.Now()
+		channels, err := s.SelectDeliveredChannels(ctx, "first")
+		require.Nil(t, err)
+		require.Empty(t, channels)
+
+		require.Nil(t, s.MarkDelivered(ctx, "first", model.ChannelEmail, delivered))
+		require.Nil(t, s.MarkDelivered(ctx, "first", model.ChannelEmail, delivered.Add(time.Hour)))
+		require.Nil(t, s.MarkDelivered(ctx, "second", model.ChannelLog, delivered.Add(time.Hour)))
+		channels, err = s.SelectDeliveredChannels(ctx, "first")
+		require.Nil(t, err)
+		require.Equal(t, []model.Channel{model.ChannelEmail}, channels)
+
+		require.Nil(t, s.DeleteDeliveries(ctx, delivered.Add(time.Minute)))
+		channels, err = s.SelectDeliveredChannels(ctx, "first")
+		require.Nil(t, err)
+		require.Empty(t, channels)
+		channels, err = s.SelectDeliveredChannels(ctx, "second")
+		require.Nil(t, err)
+		require.Equal(t, []model.Channel{model.ChannelLog}, channels)
+	})
+}
+
+func TestStorageImportEvents(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	beginning := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	event := func(uid, title string, offset time.Duration) model.Event {
+		return model.Event{
+			UID: uid, Title: title, UserID: "alice",
+			Beginning: beginning.Add(offset), Finish: beginning.Add(offset + time.Hour),
+		}
+	}
+
+	// Пересечение второго события с первым отменяет загрузку целиком.
+	_, _, err := s.ImportEvents(ctx, []model.Event{event("a", "Планерка", 0), event("b", "Ревью", 30*time.Minute)})
+	require.ErrorIs(t, err, model.ErrDateBusy)
+	events, err := s.SelectEvents(ctx)
+	require.Nil(t, err)
+	require.Empty(t, events)
+	results, err := s.SearchEvents(ctx, model.SearchQuery{Text: "планерка", Limit: 10})
+	require.Nil(t, err)
+	require.Empty(t, results)
+
+	created, skipped, err := s.ImportEvents(ctx, []model.Event{
+		event("a", "Планерка", 0), event("b", "Ревью", 2*time.Hour),
+	})
+	require.Nil(t, err)
+	require.Len(t, created, 2)
+	require.Zero(t, skipped)
+	require.Equal(t, "a", created[0].UID)
+
+	// Повторная загрузка пропускает события с тем же UID или идентификатором.
+	created, skipped, err = s.ImportEvents(ctx, []model.Event{
+		event("a", "Планерка", 0), event(created[1].ID, "Ревью", 2*time.Hour), event("c", "Ретро", 4*time.Hour),
+	})
+	require.Nil(t, err)
+	require.Len(t, created, 1)
+	require.Equal(t, 2, skipped)
+	events, err = s.SelectEvents(ctx)
+	require.Nil(t, err)
+	require.Len(t, events, 3)
+}
@@ -0,0 +1,81 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// CreateAPIKey вставляет ключ API в базу данных.
+// Возвращает ключ с идентификатором и временем создания, назначенными базой.
+func (s *Storage) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	sql := `INSERT INTO calendar.api_keys (name, keyhash, scopes) VALUES ($1, $2, $3) RETURNING id, createdat;`
+
+	err := s.Pool.QueryRow(ctx, sql, key.Name, key.Hash, key.Scopes).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	key.RevokedAt = time.Time{}
+	return key, nil
+}
+
+// SelectAPIKeys возвращает все ключи API в порядке создания.
+func (s *Storage) SelectAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	keys := make([]model.APIKey, 0)
+	sql := `SELECT id, name, keyhash, scopes, createdat, revokedat FROM calendar.api_keys ORDER BY createdat;`
+
+	rows, err := s.Pool.Query(ctx, sql)
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// SelectAPIKeyByHash возвращает ключ API по хешу или model.ErrAPIKeyNotFound.
+func (s *Storage) SelectAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error) {
+	sql := `SELECT id, name, keyhash, scopes, createdat, revokedat FROM calendar.api_keys WHERE keyhash = $1;`
+
+	key, err := scanAPIKey(s.Pool.QueryRow(ctx, sql, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.APIKey{}, model.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// RevokeAPIKey отзывает ключ API. Повторный отзыв не меняет время отзыва.
+// Возвращает model.ErrAPIKeyNotFound, если ключа нет.
+func (s *Storage) RevokeAPIKey(ctx context.Context, id string) error {
+	sql := `UPDATE calendar.api_keys SET revokedat = COALESCE(revokedat, now()) WHERE id = $1;`
+
+	tag, err := s.Pool.Exec(ctx, sql, id)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrAPIKeyNotFound
+	}
+	return err
+}
+
+// scanAPIKey читает ключ API из строки результата запроса.
+func scanAPIKey(row pgx.Row) (model.APIKey, error) {
+	var key model.APIKey
+	var revokedAt *time.Time
+	if err := row.Scan(&key.ID, &key.Name, &key.Hash, &key.Scopes, &key.CreatedAt, &revokedAt); err != nil {
+		return model.APIKey{}, err
+	}
+	if revokedAt != nil {
+		key.RevokedAt = *revokedAt
+	}
+	return key, nil
+}
@@ -0,0 +1,80 @@
+package sqlstorage
+
+import (
+	"context"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectAttendees возвращает участников события в порядке идентификаторов пользователей
+// или model.ErrEventNotFound, если события нет.
+func (s *Storage) SelectAttendees(ctx context.Context, eventID string) ([]model.Attendee, error) {
+	if _, err := s.SelectEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	sql := `SELECT eventid, userid, status FROM calendar.event_attendees WHERE eventid = $1 ORDER BY userid;`
+	return s.queryAttendees(ctx, sql, eventID)
+}
+
+// SelectAttendances возвращает участие пользователя userID в событиях.
+func (s *Storage) SelectAttendances(ctx context.Context, userID string) ([]model.Attendee, error) {
+	sql := `SELECT eventid, userid, status FROM calendar.event_attendees WHERE userid = $1 ORDER BY eventid;`
+	return s.queryAttendees(ctx, sql, userID)
+}
+
+// SetAttendee добавляет участника события или меняет его статус.
+// Сообщения outbox сохраняются в той же транзакции.
+// Возвращает model.ErrEventNotFound или model.ErrUserNotFound, если события или пользователя нет.
+func (s *Storage) SetAttendee(ctx context.Context, attendee model.Attendee, outbox ...model.OutboxMessage) (err error) {
+	sql := `INSERT INTO calendar.event_attendees (eventid, userid, status) VALUES ($1, $2, $3)
+			ON CONFLICT (eventid, userid) DO UPDATE SET status = EXCLUDED.status;`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, sql, attendee.EventID, attendee.UserID, attendee.Status); err != nil {
+		return mapError(err)
+	}
+	return insertOutbox(ctx, tx, outbox)
+}
+
+// DeleteAttendee удаляет участника события.
+// Возвращает model.ErrAttendeeNotFound, если пользователь не участвует в событии.
+func (s *Storage) DeleteAttendee(ctx context.Context, eventID, userID string) error {
+	sql := `DELETE FROM calendar.event_attendees WHERE eventid = $1 AND userid = $2;`
+
+	tag, err := s.Pool.Exec(ctx, sql, eventID, userID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrAttendeeNotFound
+	}
+	return err
+}
+
+// queryAttendees выполняет запрос участников.
+func (s *Storage) queryAttendees(ctx context.Context, sql string, args ...interface{}) ([]model.Attendee, error) {
+	attendees := make([]model.Attendee, 0)
+	rows, err := s.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return attendees, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attendee model.Attendee
+		if err := rows.Scan(&attendee.EventID, &attendee.UserID, &attendee.Status); err != nil {
+			return attendees, err
+		}
+		attendees = append(attendees, attendee)
+	}
+	return attendees, rows.Err()
+}
@@ -0,0 +1,135 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// calendarColumns столбцы календаря с ACL, собранной в массивы пользователей и ролей.
+const calendarColumns = `c.id, c.name, c.ownerid, c.timezone, c.color,
+	array_remove(array_agg(a.userid::text ORDER BY a.userid), NULL),
+	array_remove(array_agg(a.role ORDER BY a.userid), NULL)`
+
+// CreateCalendar вставляет календарь в базу данных без ACL.
+// Возвращает календарь с идентификатором, сгенерированным базой, или model.ErrUserNotFound, если владельца нет.
+func (s *Storage) CreateCalendar(ctx context.Context, calendar model.Calendar) (model.Calendar, error) {
+	sql := `INSERT INTO calendar.calendars (name, ownerid, timezone, color) VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	err := s.Pool.QueryRow(ctx, sql, calendar.Name, calendar.OwnerID, calendar.TimeZone, calendar.Color).
+		Scan(&calendar.ID)
+	if err = mapError(err); err != nil {
+		return model.Calendar{}, err
+	}
+	calendar.ACL = nil
+	return calendar, nil
+}
+
+// SelectCalendar возвращает календарь вместе с ACL или model.ErrCalendarNotFound.
+func (s *Storage) SelectCalendar(ctx context.Context, id string) (model.Calendar, error) {
+	sql := `SELECT ` + calendarColumns + `
+			FROM calendar.calendars c LEFT JOIN calendar.calendar_acl a ON a.calendarid = c.id
+			WHERE c.id = $1
+			GROUP BY c.id;`
+
+	calendar, err := scanCalendar(s.Pool.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Calendar{}, model.ErrCalendarNotFound
+	}
+	return calendar, err
+}
+
+// SelectCalendars возвращает календари вместе с ACL, которыми владеет пользователь userID
+// или к которым ему выдан доступ, в порядке названия. Пустой userID - все календари.
+func (s *Storage) SelectCalendars(ctx context.Context, userID string) ([]model.Calendar, error) {
+	calendars := make([]model.Calendar, 0)
+	sql := `SELECT ` + calendarColumns + `
+			FROM calendar.calendars c LEFT JOIN calendar.calendar_acl a ON a.calendarid = c.id
+			WHERE $1 = '' OR c.ownerid::text = $1 OR EXISTS (
+				SELECT 1 FROM calendar.calendar_acl u WHERE u.calendarid = c.id AND u.userid::text = $1
+			)
+			GROUP BY c.id
+			ORDER BY c.name COLLATE "C", c.id;`
+
+	rows, err := s.Pool.Query(ctx, sql, userID)
+	if err != nil {
+		return calendars, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		calendar, err := scanCalendar(rows)
+		if err != nil {
+			return calendars, err
+		}
+		calendars = append(calendars, calendar)
+	}
+
+	return calendars, rows.Err()
+}
+
+// UpdateCalendar изменяет название, часовой пояс и цвет календаря.
+// Возвращает model.ErrCalendarNotFound, если календаря нет.
+func (s *Storage) UpdateCalendar(ctx context.Context, calendar model.Calendar) error {
+	sql := `UPDATE calendar.calendars SET name = $2, timezone = $3, color = $4 WHERE id = $1;`
+
+	tag, err := s.Pool.Exec(ctx, sql, calendar.ID, calendar.Name, calendar.TimeZone, calendar.Color)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrCalendarNotFound
+	}
+	return err
+}
+
+// DeleteCalendar удаляет календарь. События и ACL календаря удаляются каскадом.
+// Возвращает model.ErrCalendarNotFound, если календаря нет.
+func (s *Storage) DeleteCalendar(ctx context.Context, id string) error {
+	sql := `DELETE FROM calendar.calendars WHERE id = $1;`
+
+	tag, err := s.Pool.Exec(ctx, sql, id)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrCalendarNotFound
+	}
+	return err
+}
+
+// SetAccess выдает пользователю доступ к календарю или меняет его роль.
+// Возвращает model.ErrCalendarNotFound или model.ErrUserNotFound, если календаря или пользователя нет.
+func (s *Storage) SetAccess(ctx context.Context, entry model.ACLEntry) error {
+	sql := `INSERT INTO calendar.calendar_acl (calendarid, userid, role) VALUES ($1, $2, $3)
+			ON CONFLICT (calendarid, userid) DO UPDATE SET role = EXCLUDED.role;`
+
+	_, err := s.Pool.Exec(ctx, sql, entry.CalendarID, entry.UserID, string(entry.Role))
+	return mapError(err)
+}
+
+// RevokeAccess отзывает доступ пользователя к календарю.
+// Возвращает model.ErrAccessNotFound, если доступа не было.
+func (s *Storage) RevokeAccess(ctx context.Context, calendarID, userID string) error {
+	sql := `DELETE FROM calendar.calendar_acl WHERE calendarid = $1 AND userid = $2;`
+
+	tag, err := s.Pool.Exec(ctx, sql, calendarID, userID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrAccessNotFound
+	}
+	return err
+}
+
+// scanCalendar читает календарь со столбцами calendarColumns из строки результата.
+func scanCalendar(row pgx.Row) (model.Calendar, error) {
+	var calendar model.Calendar
+	var users, roles []string
+	err := row.Scan(&calendar.ID, &calendar.Name, &calendar.OwnerID, &calendar.TimeZone, &calendar.Color,
+		&users, &roles)
+	if err != nil {
+		return model.Calendar{}, err
+	}
+
+	for i, userID := range users {
+		calendar.ACL = append(calendar.ACL, model.ACLEntry{
+			CalendarID: calendar.ID, UserID: userID, Role: model.Role(roles[i]),
+		})
+	}
+	return calendar, nil
+}
@@ -0,0 +1,120 @@
+package sqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectBusy возвращает интервалы занятости пользователей userIDs в [from, to): их собственные события
+// и события, приглашение на которые они не отклонили. Повторяющиеся серии, начавшиеся до to,
+// разворачиваются в повторения. Собственные события выбираются по индексу events_userid_beginning_id_idx,
+// участие - по event_attendees_user_idx. Возвращает model.ErrUserNotFound, если одного из пользователей нет.
+func (s *Storage) SelectBusy(ctx context.Context, userIDs []string, from, to time.Time) (
+	[]model.BusyInterval, error,
+) {
+	unique := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		// Идентификатор не в формате UUID не может принадлежать пользователю.
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, model.ErrUserNotFound
+		}
+		unique[userID] = true
+	}
+
+	var found int
+	err := s.Pool.QueryRow(ctx, `SELECT count(*) FROM calendar.users WHERE id = ANY($1::uuid[]);`, userIDs).
+		Scan(&found)
+	if err != nil {
+		return nil, err
+	}
+	if found != len(unique) {
+		return nil, model.ErrUserNotFound
+	}
+
+	sql := `SELECT userid::text, beginning, finish, recurrence, exdates, timezone
+			FROM calendar.events
+			WHERE userid = ANY($1::uuid[]) AND beginning < $3 AND (recurrence <> '' OR finish > $2)
+			UNION ALL
+			SELECT a.userid::text, e.beginning, e.finish, e.recurrence, e.exdates, e.timezone
+			FROM calendar.event_attendees a JOIN calendar.events e ON e.id = a.eventid
+			WHERE a.userid = ANY($1::uuid[]) AND a.status <> 'declined'
+				AND e.beginning < $3 AND (e.recurrence <> '' OR e.finish > $2);`
+
+	rows, err := s.Pool.Query(ctx, sql, userIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	busy := make([]model.BusyInterval, 0)
+	for rows.Next() {
+		var userID string
+		var event model.Event
+		err := rows.Scan(&userID, &event.Beginning, &event.Finish, &event.Recurrence, &event.ExDates, &event.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+
+		event = inUTC(event)
+		intervals, err := event.BusyIntervals(from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, interval := range intervals {
+			busy = append(busy, model.BusyInterval{UserID: userID, Interval: interval})
+		}
+	}
+	return busy, rows.Err()
+}
+
+// SelectWorkingHours возвращает рабочее время тех пользователей из userIDs, у которых оно задано,
+// в порядке идентификаторов пользователей.
+func (s *Storage) SelectWorkingHours(ctx context.Context, userIDs []string) ([]model.WorkingHours, error) {
+	sql := `SELECT userid::text, timezone, to_char(starttime, 'HH24:MI'), to_char(endtime, 'HH24:MI'), days
+			FROM calendar.working_hours
+			WHERE userid::text = ANY($1)
+			ORDER BY userid;`
+
+	hours := make([]model.WorkingHours, 0, len(userIDs))
+	rows, err := s.Pool.Query(ctx, sql, userIDs)
+	if err != nil {
+		return hours, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h model.WorkingHours
+		if err := rows.Scan(&h.UserID, &h.TimeZone, &h.Start, &h.End, &h.Days); err != nil {
+			return hours, err
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
+
+// SetWorkingHours задает или заменяет рабочее время пользователя.
+// Возвращает model.ErrUserNotFound, если пользователя нет.
+func (s *Storage) SetWorkingHours(ctx context.Context, hours model.WorkingHours) error {
+	sql := `INSERT INTO calendar.working_hours (userid, timezone, starttime, endtime, days)
+			VALUES ($1, $2, $3::time, $4::time, $5::smallint[])
+			ON CONFLICT (userid) DO UPDATE SET timezone = EXCLUDED.timezone, starttime = EXCLUDED.starttime,
+				endtime = EXCLUDED.endtime, days = EXCLUDED.days;`
+
+	_, err := s.Pool.Exec(ctx, sql, hours.UserID, hours.TimeZone, hours.Start, hours.End, hours.Days)
+	return mapError(err)
+}
+
+// DeleteWorkingHours удаляет рабочее время пользователя.
+// Возвращает model.ErrWorkingHoursNotFound, если оно не было задано.
+func (s *Storage) DeleteWorkingHours(ctx context.Context, userID string) error {
+	sql := `DELETE FROM calendar.working_hours WHERE userid::text = $1;`
+
+	tag, err := s.Pool.Exec(ctx, sql, userID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrWorkingHoursNotFound
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectNotificationSettings возвращает настройки уведомлений пользователя.
+// Возвращает model.ErrNotificationSettingsNotFound, если они не заданы.
+func (s *Storage) SelectNotificationSettings(ctx context.Context, userID string) (model.NotificationSettings, error) {
+	sql := `SELECT userid::text, channels, webhookurl
+			FROM calendar.notification_settings
+			WHERE userid::text = $1;`
+
+	var (
+		settings model.NotificationSettings
+		channels []string
+	)
+	err := s.Pool.QueryRow(ctx, sql, userID).Scan(&settings.UserID, &channels, &settings.WebhookURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.NotificationSettings{}, model.ErrNotificationSettingsNotFound
+	}
+	if err != nil {
+		return model.NotificationSettings{}, err
+	}
+	settings.Channels = make([]model.Channel, len(channels))
+	for i, channel := range channels {
+		settings.Channels[i] = model.Channel(channel)
+	}
+	return settings, nil
+}
+
+// SetNotificationSettings задает или заменяет настройки уведомлений пользователя.
+// Возвращает model.ErrUserNotFound, если пользователя нет.
+func (s *Storage) SetNotificationSettings(ctx context.Context, settings model.NotificationSettings) error {
+	sql := `INSERT INTO calendar.notification_settings (userid, channels, webhookurl)
+			VALUES ($1, $2::text[], $3)
+			ON CONFLICT (userid) DO UPDATE SET channels = EXCLUDED.channels, webhookurl = EXCLUDED.webhookurl;`
+
+	channels := make([]string, len(settings.Channels))
+	for i, channel := range settings.Channels {
+		channels[i] = string(channel)
+	}
+	_, err := s.Pool.Exec(ctx, sql, settings.UserID, channels, settings.WebhookURL)
+	return mapError(err)
+}
+
+// DeleteNotificationSettings удаляет настройки уведомлений пользователя.
+// Возвращает model.ErrNotificationSettingsNotFound, если они не были заданы.
+func (s *Storage) DeleteNotificationSettings(ctx context.Context, userID string) error {
+	sql := `DELETE FROM calendar.notification_settings WHERE userid::text = $1;`
+
+	tag, err := s.Pool.Exec(ctx, sql, userID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrNotificationSettingsNotFound
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package sqlstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// eventsChannel канал, в который триггер events_notify_change публикует изменения событий.
+const eventsChannel = "calendar_events"
+
+// eventNotification уведомление триггера events_notify_change.
+type eventNotification struct {
+	Type       model.ChangeType `json:"type"`
+	ID         string           `json:"id"`
+	UserID     string           `json:"userId"`
+	CalendarID *string          `json:"calendarId"`
+	Beginning  notifyTime       `json:"beginning"`
+	Finish     notifyTime       `json:"finish"`
+	Recurrence string           `json:"recurrence"`
+	ExDates    []notifyTime     `json:"exDates"`
+	TimeZone   string           `json:"timeZone"`
+}
+
+// notifyTime время в JSON PostgreSQL: timestamptz сериализуется со смещением,
+// timestamp до миграции на timestamptz - без часового пояса.
+type notifyTime struct {
+	time.Time
+}
+
+func (t *notifyTime) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		return err
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, *value)
+	if err != nil {
+		parsed, err = time.Parse("2006-01-02T15:04:05.999999999", *value)
+	}
+	t.Time = parsed
+	return err
+}
+
+// ListenEventChanges подписывается на изменения событий через LISTEN и передает их в handle.
+// Уведомления приходят обо всех изменениях в базе, включая сделанные другими экземплярами сервиса.
+// Возвращает nil после отмены ctx и ошибку при потере соединения.
+func (s *Storage) ListenEventChanges(ctx context.Context, handle func(model.EventChange)) error {
+	poolConn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// Соединение в режиме LISTEN не возвращается в пул.
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		change, err := s.eventChange(ctx, notification.Payload)
+		if err != nil {
+			return err
+		}
+		handle(change)
+	}
+}
+
+// eventChange разбирает уведомление и дочитывает созданное или обновленное событие.
+// Если событие успели удалить, возвращается состояние из уведомления.
+func (s *Storage) eventChange(ctx context.Context, payload string) (model.EventChange, error) {
+	var n eventNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return model.EventChange{}, err
+	}
+
+	event := model.Event{
+		ID:         n.ID,
+		UserID:     n.UserID,
+		Beginning:  n.Beginning.Time,
+		Finish:     n.Finish.Time,
+		Recurrence: n.Recurrence,
+		TimeZone:   n.TimeZone,
+	}
+	if n.CalendarID != nil {
+		event.CalendarID = *n.CalendarID
+	}
+	for _, exDate := range n.ExDates {
+		event.ExDates = append(event.ExDates, exDate.Time)
+	}
+	event = inUTC(event)
+
+	if n.Type != model.ChangeDeleted {
+		stored, err := s.SelectEvent(ctx, n.ID)
+		switch {
+		case err == nil:
+			event = stored
+		case !errors.Is(err, model.ErrEventNotFound):
+			return model.EventChange{}, err
+		}
+	}
+
+	return model.EventChange{Type: n.Type, Event: event}, nil
+}
@@ -0,0 +1,121 @@
+package sqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// insertOutbox добавляет сообщения в outbox в транзакции tx, пропуская ключи, которые уже ждут публикации.
+func insertOutbox(ctx context.Context, tx pgx.Tx, outbox []model.OutboxMessage) error {
+	sql := `INSERT INTO calendar.outbox (key, body) VALUES ($1, $2) ON CONFLICT (key) DO NOTHING;`
+
+	for _, message := range outbox {
+		if _, err := tx.Exec(ctx, sql, message.Key, message.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RelayOutbox передает publish до limit первых сообщений outbox и удаляет опубликованные.
+// Выбранные строки блокируются до конца транзакции и пропускаются другими ретрансляторами.
+// Публикация останавливается на первой ошибке, которая возвращается вместе с числом опубликованных.
+// Сообщение считается опубликованным, когда publish вернул nil, то есть брокер подтвердил прием.
+func (s *Storage) RelayOutbox(
+	ctx context.Context, limit int, publish func(model.OutboxMessage) error,
+) (relayed int, err error) {
+	sql := `SELECT id, key, body, createdat FROM calendar.outbox
+			ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED;`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil && relayed == 0 {
+			tx.Rollback(ctx)
+		} else if commitErr := tx.Commit(ctx); commitErr != nil {
+			relayed, err = 0, commitErr
+		}
+	}()
+
+	batch, err := queryOutbox(ctx, tx, sql, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int64, 0, len(batch))
+	var publishErr error
+	for _, message := range batch {
+		if publishErr = publish(message); publishErr != nil {
+			break
+		}
+		ids = append(ids, message.ID)
+	}
+	if len(ids) > 0 {
+		if _, err = tx.Exec(ctx, `DELETE FROM calendar.outbox WHERE id = ANY($1);`, ids); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), publishErr
+}
+
+// queryOutbox выполняет запрос сообщений outbox.
+func queryOutbox(ctx context.Context, tx pgx.Tx, sql string, args ...interface{}) ([]model.OutboxMessage, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var outbox []model.OutboxMessage
+	for rows.Next() {
+		var message model.OutboxMessage
+		if err := rows.Scan(&message.ID, &message.Key, &message.Body, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		outbox = append(outbox, message)
+	}
+	return outbox, rows.Err()
+}
+
+// SelectDeliveredChannels возвращает каналы, по которым уже доставлено сообщение с ключом key.
+func (s *Storage) SelectDeliveredChannels(ctx context.Context, key string) ([]model.Channel, error) {
+	sql := `SELECT channel FROM calendar.deliveries WHERE key = $1;`
+
+	rows, err := s.Pool.Query(ctx, sql, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels := make([]model.Channel, 0)
+	for rows.Next() {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, err
+		}
+		channels = append(channels, model.Channel(channel))
+	}
+	return channels, rows.Err()
+}
+
+// MarkDelivered отмечает доставку сообщения с ключом key по каналу channel.
+func (s *Storage) MarkDelivered(ctx context.Context, key string, channel model.Channel, at time.Time) error {
+	sql := `INSERT INTO calendar.deliveries (key, channel, deliveredat) VALUES ($1, $2, $3)
+			ON CONFLICT (key, channel) DO NOTHING;`
+
+	_, err := s.Pool.Exec(ctx, sql, key, channel, at)
+	return err
+}
+
+// DeleteDeliveries удаляет отметки о доставках, сделанных раньше before.
+func (s *Storage) DeleteDeliveries(ctx context.Context, before time.Time) error {
+	sql := `DELETE FROM calendar.deliveries WHERE deliveredat < $1;`
+
+	_, err := s.Pool.Exec(ctx, sql, before)
+	return err
+}
@@ -0,0 +1,227 @@
+package sqlstorage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// likeEscaper экранирует спецсимволы шаблона LIKE.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// conditions накапливает условия WHERE и их параметры.
+type conditions struct {
+	where []string
+	args  []interface{}
+}
+
+// arg добавляет параметр запроса и возвращает его плейсхолдер.
+func (c *conditions) arg(value interface{}) string {
+	c.args = append(c.args, value)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+// add добавляет условие.
+func (c *conditions) add(condition string) {
+	c.where = append(c.where, condition)
+}
+
+// sql возвращает условие WHERE или пустую строку, если условий нет.
+func (c *conditions) sql() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.where, " AND ")
+}
+
+// keyset добавляет условие "строка после курсора" для сортировки по столбцам columns
+// и возвращает выражение ORDER BY. Строки сравниваются в порядке байтов, как model.CompareEvents.
+func (c *conditions) keyset(columns []string, cursor []interface{}, desc bool) string {
+	op, direction := ">", ""
+	if desc {
+		op, direction = "<", " DESC"
+	}
+
+	if cursor != nil {
+		placeholders := make([]string, len(cursor))
+		for i, value := range cursor {
+			placeholders[i] = c.arg(value)
+		}
+		c.add("(" + strings.Join(columns, ", ") + ") " + op + " (" + strings.Join(placeholders, ", ") + ")")
+	}
+
+	order := make([]string, len(columns))
+	for i, column := range columns {
+		order[i] = column + direction
+	}
+	return " ORDER BY " + strings.Join(order, ", ")
+}
+
+// visibility добавляет условие видимости событий для пользователя visibility.ReaderID.
+func (c *conditions) visibility(visibility model.Visibility) {
+	if visibility.ReaderID == "" {
+		return
+	}
+	c.add("(userid = " + c.arg(visibility.ReaderID) + " OR calendarid::text = ANY(" +
+		c.arg(visibility.CalendarIDs) + ") OR id::text = ANY(" + c.arg(visibility.EventIDs) + "))")
+}
+
+// eventConditions возвращает условия фильтра событий без интервала времени.
+func eventConditions(filter model.EventFilter) *conditions {
+	c := &conditions{}
+	if filter.UserID != "" {
+		c.add("userid = " + c.arg(filter.UserID))
+	}
+	if filter.CalendarID != "" {
+		c.add("calendarid = " + c.arg(filter.CalendarID))
+	}
+	c.visibility(filter.Visibility)
+	if filter.Title != "" {
+		c.add("title ILIKE " + c.arg("%"+likeEscaper.Replace(filter.Title)+"%"))
+	}
+	return c
+}
+
+// ListEvents возвращает страницу событий, подходящих под фильтр, в порядке query.OrderBy.
+// Страница однократных событий выбирается по курсору в базе данных. С интервалом времени
+// повторяющиеся серии, начавшиеся до его конца, разворачиваются в повторения и объединяются со страницей.
+func (s *Storage) ListEvents(ctx context.Context, query model.EventQuery) (
+	page []model.Event, next *model.Event, err error,
+) {
+	sql := `SELECT ` + eventColumns + `
+			FROM calendar.events`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	c := eventConditions(query.EventFilter)
+	if query.Period() {
+		c.add("recurrence = ''")
+		c.add("beginning >= " + c.arg(query.From))
+		c.add("beginning < " + c.arg(query.To))
+	}
+
+	columns := []string{"beginning", "id"}
+	var cursor []interface{}
+	if query.After != nil {
+		cursor = []interface{}{query.After.Beginning, query.After.ID}
+	}
+	if strings.TrimPrefix(query.OrderBy, "-") == model.OrderByTitle {
+		columns = append([]string{`title COLLATE "C"`}, columns...)
+		if query.After != nil {
+			cursor = append([]interface{}{query.After.Title}, cursor...)
+		}
+	}
+	order := c.keyset(columns, cursor, strings.HasPrefix(query.OrderBy, "-"))
+	limit := " LIMIT " + c.arg(query.PageSize+1)
+
+	events, err := queryEvents(ctx, tx, sql+c.sql()+order+limit, c.args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if query.Period() {
+		series := eventConditions(query.EventFilter)
+		series.add("recurrence <> ''")
+		series.add("beginning < " + series.arg(query.To))
+
+		var recurring []model.Event
+		recurring, err = queryEvents(ctx, tx, sql+series.sql(), series.args...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, event := range recurring {
+			var occurrences []model.Event
+			occurrences, err = event.Occurrences(query.From, query.To)
+			if err != nil {
+				return nil, nil, err
+			}
+			events = append(events, occurrences...)
+		}
+	}
+
+	page, next = model.PageEvents(events, query)
+	return page, next, nil
+}
+
+// ListUsers возвращает страницу пользователей в порядке query.OrderBy.
+func (s *Storage) ListUsers(ctx context.Context, query model.UserQuery) (
+	users []model.User, next *model.User, err error,
+) {
+	sql := `SELECT id, firstname, lastname, email, age, timezone FROM calendar.users`
+
+	c := &conditions{}
+	if query.UserID != "" {
+		c.add("id = " + c.arg(query.UserID))
+	}
+
+	column := `lastname COLLATE "C"`
+	if strings.TrimPrefix(query.OrderBy, "-") == model.OrderByEmail {
+		column = `email COLLATE "C"`
+	}
+	var cursor []interface{}
+	if query.After != nil {
+		value := query.After.LastName
+		if column == `email COLLATE "C"` {
+			value = query.After.Email
+		}
+		cursor = []interface{}{value, query.After.ID}
+	}
+	order := c.keyset([]string{column, "id"}, cursor, strings.HasPrefix(query.OrderBy, "-"))
+	limit := " LIMIT " + c.arg(query.PageSize+1)
+
+	rows, err := s.Pool.Query(ctx, sql+c.sql()+order+limit, c.args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	users = make([]model.User, 0)
+	for rows.Next() {
+		var user model.User
+		err = rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Age, &user.TimeZone)
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	users, next = model.PageUsers(users, query)
+	return users, next, nil
+}
+
+// queryEvents выполняет запрос событий в транзакции.
+func queryEvents(ctx context.Context, tx pgx.Tx, sql string, args ...interface{}) ([]model.Event, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]model.Event, 0)
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
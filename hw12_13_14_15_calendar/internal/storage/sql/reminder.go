@@ -0,0 +1,110 @@
+package sqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// SelectDueReminders возвращает напоминания со временем FireAt не позже t вместе с их событиями
+// в порядке времени напоминания.
+func (s *Storage) SelectDueReminders(ctx context.Context, t time.Time) ([]model.DueReminder, error) {
+	sql := `SELECT ` + eventColumns + `, due.id, due.minutesbefore, due.channel, due.fireat, due.occurrence, due.sentat
+			FROM calendar.events JOIN calendar.event_reminders due ON due.eventid = events.id
+			WHERE due.fireat <= $1
+			ORDER BY due.fireat, due.id;`
+
+	reminders := make([]model.DueReminder, 0)
+	rows, err := s.Pool.Query(ctx, sql, t)
+	if err != nil {
+		return reminders, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			due                model.DueReminder
+			occurrence, sentAt *time.Time
+		)
+		due.Event, err = scanEvent(rows, &due.Reminder.ID, &due.Reminder.MinutesBefore, &due.Reminder.Channel,
+			&due.Reminder.FireAt, &occurrence, &sentAt)
+		if err != nil {
+			return reminders, err
+		}
+		due.Reminder.EventID = due.Event.ID
+		due.Reminder.FireAt = due.Reminder.FireAt.UTC()
+		if occurrence != nil {
+			due.Reminder.Occurrence = occurrence.UTC()
+		}
+		if sentAt != nil {
+			due.Reminder.SentAt = sentAt.UTC()
+		}
+		reminders = append(reminders, due)
+	}
+	return reminders, rows.Err()
+}
+
+// ClaimReminder заменяет напоминание current на next, если его FireAt не изменился.
+// Сообщения outbox сохраняются в той же транзакции, только если напоминание удалось занять.
+func (s *Storage) ClaimReminder(
+	ctx context.Context, current, next model.Reminder, outbox ...model.OutboxMessage,
+) (claimed bool, err error) {
+	if _, err := uuid.Parse(current.ID); err != nil {
+		return false, nil
+	}
+	sql := `UPDATE calendar.event_reminders SET fireat = $3, occurrence = $4, sentat = $5
+			WHERE id = $1 AND fireat = $2;`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, sql, current.ID, current.FireAt,
+		nullTime(next.FireAt), nullTime(next.Occurrence), nullTime(next.SentAt))
+	if err != nil || tag.RowsAffected() == 0 {
+		return false, err
+	}
+	return true, insertOutbox(ctx, tx, outbox)
+}
+
+// insertReminders вставляет напоминания события и возвращает их с идентификаторами. Новым напоминаниям
+// идентификаторы генерирует база, напоминания, сохраненные приложением без изменений, сохраняют свои.
+func insertReminders(ctx context.Context, tx pgx.Tx, event model.Event) ([]model.Reminder, error) {
+	sql := `INSERT INTO calendar.event_reminders (id, eventid, minutesbefore, channel, fireat, occurrence, sentat)
+			VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5, $6, $7) RETURNING id;`
+
+	if len(event.Reminders) == 0 {
+		return nil, nil
+	}
+	reminders := make([]model.Reminder, 0, len(event.Reminders))
+	for _, reminder := range event.Reminders {
+		reminder.EventID = event.ID
+		err := tx.QueryRow(ctx, sql, reminder.ID, event.ID, reminder.MinutesBefore, reminder.Channel,
+			nullTime(reminder.FireAt), nullTime(reminder.Occurrence), nullTime(reminder.SentAt),
+		).Scan(&reminder.ID)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+// nullTime возвращает nil для нулевого времени, чтобы оно сохранялось как NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
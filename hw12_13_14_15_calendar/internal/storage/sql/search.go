@@ -0,0 +1,55 @@
+package sqlstorage
+
+import (
+	"context"
+
+	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
+)
+
+// headlineOptions параметры ts_headline, согласованные с фрагментами хранилища в памяти.
+const headlineOptions = "StartSel=" + model.HighlightStart + ", StopSel=" + model.HighlightStop +
+	", MaxWords=20, MinWords=10, HighlightAll=false"
+
+// headlineText текст фрагмента, экранированный для HTML так же, как html.EscapeString, до вставки тегов
+// ts_headline. Сущности HTML не попадают в словарь simple, поэтому не подсвечиваются.
+const headlineText = `replace(replace(replace(replace(replace(
+				coalesce(title, '') || ' ' || coalesce(description, ''),
+				'&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;')`
+
+// SearchEvents возвращает события, название или описание которых содержит все слова запроса,
+// в порядке убывания релевантности. Используется индекс events_search_idx по столбцу search.
+func (s *Storage) SearchEvents(ctx context.Context, query model.SearchQuery) ([]model.SearchResult, error) {
+	c := &conditions{}
+	q := c.arg(query.Text)
+	headline := c.arg(headlineOptions)
+	c.add("search @@ q")
+	if query.UserID != "" {
+		c.add("userid = " + c.arg(query.UserID))
+	}
+	c.visibility(query.Visibility)
+
+	sql := `SELECT ` + eventColumns + `,
+				ts_rank(search, q)::float8 AS rank,
+				ts_headline('simple', ` + headlineText + `, q, ` + headline + `)
+			FROM calendar.events, plainto_tsquery('simple', ` + q + `) q` + c.sql() + `
+			ORDER BY rank DESC, beginning, id
+			LIMIT ` + c.arg(query.Limit)
+
+	rows, err := s.Pool.Query(ctx, sql, c.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]model.SearchResult, 0)
+	for rows.Next() {
+		var result model.SearchResult
+		result.Event, err = scanEvent(rows, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
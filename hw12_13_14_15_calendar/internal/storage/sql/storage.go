@@ -2,12 +2,37 @@ package sqlstorage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/juliazadorozhnaya/otus_homework/hw12_13_14_15_calendar/internal/model"
 )
 
+const (
+	// exclusionViolation код ошибки PostgreSQL при нарушении ограничения EXCLUDE.
+	exclusionViolation = "23P01"
+	// foreignKeyViolation код ошибки PostgreSQL при нарушении внешнего ключа.
+	foreignKeyViolation = "23503"
+)
+
+// eventColumns столбцы событий в порядке, в котором их читает scanEvent.
+// Напоминания события читаются одним столбцом в виде массива JSON.
+const eventColumns = `id, title, description, beginning, finish, notification, userid, recurrence, exdates,
+	coalesce(calendarid::text, ''), timezone, uid,
+	(SELECT json_agg(json_build_object('id', r.id, 'minutesBefore', r.minutesbefore, 'channel', r.channel,
+		'fireAt', r.fireat, 'sentAt', r.sentat) ORDER BY r.minutesbefore DESC, r.channel)
+	FROM calendar.event_reminders r WHERE r.eventid = events.id)`
+
+// sameSchedule условие UpdateEvent, при котором время и повторение события не меняются.
+const sameSchedule = `beginning = $4 AND notification = $6 AND recurrence = $8
+	AND exdates IS NOT DISTINCT FROM $9 AND timezone = $11`
+
 type Storage struct {
 	Pool *pgxpool.Pool
 }
@@ -23,10 +48,10 @@ func New(connString string) (*Storage, error) {
 	}, nil
 }
 
-// SelectUsers возвращает всех пользователей из базы данных.
+// SelectUsers возвращает всех пользователей из базы данных в порядке фамилии.
 func (s *Storage) SelectUsers(ctx context.Context) (users []model.User, err error) {
 	users = make([]model.User, 0)
-	sql := `SELECT id, firstname, lastname, email, age FROM calendar.users;`
+	sql := `SELECT id, firstname, lastname, email, age, timezone FROM calendar.users ORDER BY lastname COLLATE "C", id;`
 
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
@@ -48,7 +73,7 @@ func (s *Storage) SelectUsers(ctx context.Context) (users []model.User, err erro
 
 	for rows.Next() {
 		var user model.User
-		err = rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Age)
+		err = rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Age, &user.TimeZone)
 		if err != nil {
 			return users, err
 		}
@@ -59,12 +84,14 @@ func (s *Storage) SelectUsers(ctx context.Context) (users []model.User, err erro
 }
 
 // CreateUser вставляет нового пользователя в базу данных.
-func (s *Storage) CreateUser(ctx context.Context, user model.User) error {
-	sql := `INSERT INTO calendar.users (firstname, lastname, email, age) VALUES ($1, $2, $3, $4);`
+// Возвращает сохраненного пользователя с идентификатором, сгенерированным базой.
+func (s *Storage) CreateUser(ctx context.Context, user model.User) (model.User, error) {
+	sql := `INSERT INTO calendar.users (firstname, lastname, email, age, timezone)
+			VALUES ($1, $2, $3, $4, $5) RETURNING id;`
 
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
-		return err
+		return model.User{}, err
 	}
 	defer func() {
 		if err != nil {
@@ -74,11 +101,15 @@ func (s *Storage) CreateUser(ctx context.Context, user model.User) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, sql, user.FirstName, user.LastName, user.Email, user.Age)
-	return err
+	err = tx.QueryRow(ctx, sql, user.FirstName, user.LastName, user.Email, user.Age, user.TimeZone).Scan(&user.ID)
+	if err != nil {
+		return model.User{}, err
+	}
+	return user, nil
 }
 
 // DeleteUser удаляет пользователя по его идентификатору.
+// Возвращает model.ErrUserNotFound, если пользователя нет.
 func (s *Storage) DeleteUser(ctx context.Context, userID string) error {
 	sql := `DELETE FROM calendar.users WHERE id = $1;`
 
@@ -94,18 +125,20 @@ func (s *Storage) DeleteUser(ctx context.Context, userID string) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, sql, userID)
+	tag, err := tx.Exec(ctx, sql, userID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrUserNotFound
+	}
 	return err
 }
 
-// CreateEvent вставляет новое событие в базу данных.
-func (s *Storage) CreateEvent(ctx context.Context, event model.Event) error {
-	sql := `INSERT INTO calendar.events (title, description, beginning, finish, notification, userid) 
-			VALUES ($1, $2, $3, $4, $5, $6);`
-
+// CreateEvent вставляет новое событие и его напоминания в базу данных.
+// Возвращает сохраненное событие с идентификатором, сгенерированным базой,
+// или model.ErrDateBusy, если событие пересекается с другим событием пользователя.
+func (s *Storage) CreateEvent(ctx context.Context, event model.Event) (model.Event, error) {
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
-		return err
+		return model.Event{}, err
 	}
 	defer func() {
 		if err != nil {
@@ -115,12 +148,81 @@ func (s *Storage) CreateEvent(ctx context.Context, event model.Event) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, sql, event.Title, event.Description, event.Beginning, event.Finish,
-		event.Notification, event.UserID)
-	return err
+	event, err = insertEvent(ctx, tx, event)
+	return event, err
+}
+
+// ImportEvents создает события загруженного календаря в одной транзакции: при ошибке
+// не создается ни одно. Событие, UID которого совпадает с UID или идентификатором события
+// того же пользователя, пропускается. Возвращает созданные события и число пропущенных.
+func (s *Storage) ImportEvents(
+	ctx context.Context, events []model.Event,
+) (created []model.Event, skipped int, err error) {
+	sql := `SELECT EXISTS (SELECT 1 FROM calendar.events WHERE userid = $1 AND (uid = $2 OR id::text = $2));`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	created = make([]model.Event, 0, len(events))
+	for _, event := range events {
+		if event.UID != "" {
+			var imported bool
+			if err = tx.QueryRow(ctx, sql, event.UserID, event.UID).Scan(&imported); err != nil {
+				return nil, 0, err
+			}
+			if imported {
+				skipped++
+				continue
+			}
+		}
+
+		if event, err = insertEvent(ctx, tx, event); err != nil {
+			return nil, 0, err
+		}
+		created = append(created, event)
+	}
+	return created, skipped, nil
+}
+
+// insertEvent вставляет событие и его напоминания в транзакции tx.
+func insertEvent(ctx context.Context, tx pgx.Tx, event model.Event) (model.Event, error) {
+	sql := `INSERT INTO calendar.events
+				(title, description, beginning, finish, notification, userid, recurrence, exdates, calendarid, timezone,
+				notifiedat, nextnotification, uid)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, $10, $11, $12, $13) RETURNING id;`
+
+	notified, next, err := notificationMarks(event)
+	if err != nil {
+		return model.Event{}, err
+	}
+	if err = checkDateBusy(ctx, tx, event); err != nil {
+		return model.Event{}, err
+	}
+
+	err = tx.QueryRow(ctx, sql, event.Title, event.Description, event.Beginning, event.Finish,
+		event.Notification, event.UserID, event.Recurrence, event.ExDates, event.CalendarID, event.TimeZone,
+		notified, next, event.UID,
+	).Scan(&event.ID)
+	if err = mapError(err); err != nil {
+		return model.Event{}, err
+	}
+	if event.Reminders, err = insertReminders(ctx, tx, event); err != nil {
+		return model.Event{}, err
+	}
+	return event, nil
 }
 
 // DeleteEvent удаляет событие по его идентификатору.
+// Возвращает model.ErrEventNotFound, если события нет.
 func (s *Storage) DeleteEvent(ctx context.Context, eventID string) error {
 	sql := `DELETE FROM calendar.events WHERE id = $1;`
 
@@ -136,16 +238,31 @@ func (s *Storage) DeleteEvent(ctx context.Context, eventID string) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, sql, eventID)
+	tag, err := tx.Exec(ctx, sql, eventID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrEventNotFound
+	}
 	return err
 }
 
-// UpdateEvent обновляет существующее событие в базе данных.
+// UpdateEvent обновляет существующее событие в базе данных вместе с его напоминаниями.
+// Отметка об отправленных уведомлениях заменяется на event.Notified, если изменились время или
+// повторение события.
+// Возвращает model.ErrEventNotFound, если события нет,
+// и model.ErrDateBusy, если событие пересекается с другим событием пользователя.
 func (s *Storage) UpdateEvent(ctx context.Context, event model.Event) error {
 	sql := `UPDATE calendar.events
-			SET title = $2, description = $3, beginning = $4, finish = $5, notification = $6, userid = $7
+			SET title = $2, description = $3, beginning = $4, finish = $5, notification = $6, userid = $7,
+				recurrence = $8, exdates = $9, calendarid = NULLIF($10, '')::uuid, timezone = $11,
+				notifiedat = CASE WHEN ` + sameSchedule + ` THEN notifiedat ELSE $12 END,
+				nextnotification = CASE WHEN ` + sameSchedule + ` THEN nextnotification ELSE $13 END
 			WHERE id = $1;`
 
+	notified, next, err := notificationMarks(event)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
 		return err
@@ -158,15 +275,34 @@ func (s *Storage) UpdateEvent(ctx context.Context, event model.Event) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, sql, event.ID, event.Title, event.Description, event.Beginning, event.Finish,
-		event.Notification, event.UserID)
+	if err = checkDateBusy(ctx, tx, event); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, sql, event.ID, event.Title, event.Description, event.Beginning, event.Finish,
+		event.Notification, event.UserID, event.Recurrence, event.ExDates, event.CalendarID, event.TimeZone,
+		notified, next)
+	err = mapError(err)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = model.ErrEventNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM calendar.event_reminders WHERE eventid = $1;`, event.ID); err != nil {
+		return err
+	}
+	_, err = insertReminders(ctx, tx, event)
 	return err
 }
 
-// SelectEvents возвращает все события из базы данных.
+// SelectEvents возвращает все события из базы данных в порядке начала.
 func (s *Storage) SelectEvents(ctx context.Context) (events []model.Event, err error) {
 	events = make([]model.Event, 0)
-	sql := `SELECT id, title, description, beginning, finish, notification, userid FROM calendar.events;`
+	sql := `SELECT ` + eventColumns + `
+			FROM calendar.events
+			ORDER BY beginning, id;`
 
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
@@ -188,9 +324,7 @@ func (s *Storage) SelectEvents(ctx context.Context) (events []model.Event, err e
 
 	for rows.Next() {
 		var event model.Event
-		err = rows.Scan(&event.ID, &event.Title, &event.Description, &event.Beginning, &event.Finish,
-			&event.Notification, &event.UserID)
-		if err != nil {
+		if event, err = scanEvent(rows); err != nil {
 			return events, err
 		}
 		events = append(events, event)
@@ -199,12 +333,27 @@ func (s *Storage) SelectEvents(ctx context.Context) (events []model.Event, err e
 	return events, rows.Err()
 }
 
-// selectEvents возвращает события из базы данных, которые начинаются в указанный период.
+// SelectEvent возвращает событие по его идентификатору.
+// Возвращает model.ErrEventNotFound, если события нет.
+func (s *Storage) SelectEvent(ctx context.Context, eventID string) (event model.Event, err error) {
+	sql := `SELECT ` + eventColumns + `
+			FROM calendar.events
+			WHERE id = $1;`
+
+	event, err = scanEvent(s.Pool.QueryRow(ctx, sql, eventID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Event{}, model.ErrEventNotFound
+	}
+	return event, err
+}
+
+// selectEvents возвращает события из базы данных, которые начинаются в интервале [startDate, endDate).
+// Повторяющиеся события разворачиваются в повторения, попадающие в интервал.
 func (s *Storage) selectEvents(ctx context.Context, startDate, endDate time.Time) (events []model.Event, err error) {
 	events = make([]model.Event, 0)
-	sql := `SELECT id, title, description, beginning, finish, notification, userid 
-			FROM calendar.events 
-			WHERE beginning BETWEEN $1 AND $2;`
+	sql := `SELECT ` + eventColumns + `
+			FROM calendar.events
+			WHERE beginning >= $1 AND beginning < $2 OR (recurrence <> '' AND beginning < $2);`
 
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
@@ -226,20 +375,31 @@ func (s *Storage) selectEvents(ctx context.Context, startDate, endDate time.Time
 
 	for rows.Next() {
 		var event model.Event
-		err = rows.Scan(&event.ID, &event.Title, &event.Description, &event.Beginning, &event.Finish,
-			&event.Notification, &event.UserID)
+		if event, err = scanEvent(rows); err != nil {
+			return events, err
+		}
+
+		if event.Recurrence == "" {
+			events = append(events, event)
+			continue
+		}
+
+		var occurrences []model.Event
+		occurrences, err = event.Occurrences(startDate, endDate)
 		if err != nil {
 			return events, err
 		}
-		events = append(events, event)
+		events = append(events, occurrences...)
 	}
 
 	return events, rows.Err()
 }
 
-// SelectEventsForDay возвращает события за указанный день.
+// SelectEventsForDay возвращает события за день, в который попадает date в своем часовом поясе.
+// Граница следующего дня вычисляется через AddDate, поэтому день перехода на летнее время
+// длится 23 или 25 часов.
 func (s *Storage) SelectEventsForDay(ctx context.Context, date time.Time) (events []model.Event, err error) {
-	startDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	startDate := model.DayStart(date, date.Location())
 	endDate := startDate.AddDate(0, 0, 1)
 	return s.selectEvents(ctx, startDate, endDate)
 }
@@ -257,11 +417,12 @@ func (s *Storage) SelectEventsForMonth(ctx context.Context, startDate time.Time)
 }
 
 // SelectEventsByTime возвращает события, которые нужно уведомить в указанное время.
+// Для повторяющихся событий возвращается каждое повторение, уведомление о котором приходится на t.
 func (s *Storage) SelectEventsByTime(ctx context.Context, t time.Time) (events []model.Event, err error) {
 	events = make([]model.Event, 0)
-	sql := `SELECT id, title, description, beginning, finish, notification, userid 
-			FROM calendar.events 
-			WHERE notification = $1;`
+	sql := `SELECT ` + eventColumns + `
+			FROM calendar.events
+			WHERE notification = $1 OR (recurrence <> '' AND notification <= $1);`
 
 	tx, err := s.Pool.Begin(ctx)
 	if err != nil {
@@ -283,13 +444,265 @@ func (s *Storage) SelectEventsByTime(ctx context.Context, t time.Time) (events [
 
 	for rows.Next() {
 		var event model.Event
-		err = rows.Scan(&event.ID, &event.Title, &event.Description, &event.Beginning,
-			&event.Finish, &event.Notification, &event.UserID)
+		if event, err = scanEvent(rows); err != nil {
+			return events, err
+		}
+
+		if event.Recurrence == "" {
+			events = append(events, event)
+			continue
+		}
+
+		var occurrences []model.Event
+		occurrences, err = event.NotifiedAt(t)
 		if err != nil {
 			return events, err
 		}
-		events = append(events, event)
+		events = append(events, occurrences...)
 	}
 
 	return events, rows.Err()
 }
+
+// SelectDueNotifications возвращает повторения событий, уведомления о которых наступили к моменту now,
+// но еще не отправлены и не устарели к since, в порядке времени уведомления.
+// Выбираются только события с наступившим nextnotification. Если все их наступившие уведомления
+// устарели, nextnotification переносится на следующее уведомление, чтобы событие не выбиралось снова.
+func (s *Storage) SelectDueNotifications(ctx context.Context, now, since time.Time) ([]model.Event, error) {
+	sql := `SELECT ` + eventColumns + `, notifiedat, nextnotification
+			FROM calendar.events
+			WHERE nextnotification <= $1
+			ORDER BY nextnotification, id;`
+
+	events := make([]model.Event, 0)
+	rows, err := s.Pool.Query(ctx, sql, now)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+
+	stale := make([]staleNotification, 0)
+	for rows.Next() {
+		var notified, next *time.Time
+		event, err := scanEvent(rows, &notified, &next)
+		if err != nil {
+			return events, err
+		}
+		if notified != nil {
+			event.Notified = notified.UTC()
+		}
+
+		pending, err := event.PendingNotifications(now, since)
+		if err != nil {
+			return events, err
+		}
+		if len(pending) == 0 {
+			following, err := event.NextNotification(now)
+			if err != nil {
+				return events, err
+			}
+			stale = append(stale, staleNotification{eventID: event.ID, selected: *next, next: following})
+			continue
+		}
+		events = append(events, pending...)
+	}
+	if err := rows.Err(); err != nil {
+		return events, err
+	}
+	rows.Close()
+
+	if err := s.skipStaleNotifications(ctx, stale); err != nil {
+		return events, err
+	}
+
+	// Повторения разных серий упорядочиваются заново: время их уведомлений не совпадает с временем серии.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Notification.Before(events[j].Notification)
+	})
+	return events, nil
+}
+
+// staleNotification событие, все наступившие уведомления которого уже отправлены или устарели.
+type staleNotification struct {
+	eventID string
+	// selected выбранное значение nextnotification, next - время следующего уведомления.
+	selected, next time.Time
+}
+
+// skipStaleNotifications переносит nextnotification событий stale на их следующее уведомление.
+// Событие, которое изменили или отметили после выборки, пропускается: его nextnotification
+// уже не совпадает с выбранным.
+func (s *Storage) skipStaleNotifications(ctx context.Context, stale []staleNotification) error {
+	sql := `UPDATE calendar.events SET nextnotification = $3 WHERE id = $1 AND nextnotification = $2;`
+
+	for _, notification := range stale {
+		_, err := s.Pool.Exec(ctx, sql, notification.eventID, notification.selected, nullTime(notification.next))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkNotified отмечает отправленными уведомления события со временем не позже notification
+// и переносит nextnotification на следующее уведомление.
+// Сообщения outbox сохраняются в той же транзакции, только если отметка сдвинулась.
+func (s *Storage) MarkNotified(
+	ctx context.Context, eventID string, notification time.Time, outbox ...model.OutboxMessage,
+) (err error) {
+	selectSQL := `SELECT ` + eventColumns + `, notifiedat FROM calendar.events WHERE id = $1 FOR UPDATE;`
+	updateSQL := `UPDATE calendar.events SET notifiedat = $2, nextnotification = $3 WHERE id = $1;`
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var notified *time.Time
+	event, err := scanEvent(tx.QueryRow(ctx, selectSQL, eventID), &notified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil || notified != nil && !notified.Before(notification) {
+		return err
+	}
+
+	next, err := event.NextNotification(notification)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, updateSQL, eventID, notification, nullTime(next)); err != nil {
+		return err
+	}
+	return insertOutbox(ctx, tx, outbox)
+}
+
+// notificationMarks возвращает значения notifiedat и nextnotification сохраняемого события.
+func notificationMarks(event model.Event) (notified, next *time.Time, err error) {
+	nextNotification, err := event.NextNotification(event.Notified)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nullTime(event.Notified), nullTime(nextNotification), nil
+}
+
+// checkDateBusy проверяет пересечения, в которых участвуют повторяющиеся события.
+// Пересечения однократных событий отсекает ограничение events_user_time_excl.
+// Строка пользователя блокируется до конца транзакции, чтобы параллельные проверки не разошлись.
+func checkDateBusy(ctx context.Context, tx pgx.Tx, event model.Event) error {
+	if event.UserID == "" {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM calendar.users WHERE id = $1 FOR UPDATE;`, event.UserID); err != nil {
+		return err
+	}
+
+	sql := `SELECT id, beginning, finish, recurrence, exdates, timezone
+			FROM calendar.events
+			WHERE userid = $1 AND id::text <> $2 AND ($3 <> '' OR recurrence <> '')
+				AND ($4::timestamptz IS NULL OR beginning < $4);`
+
+	// Однократное событие пересекается только с событиями, начавшимися до его окончания. Повторяющееся
+	// сравнивается со всеми событиями пользователя: окно model.ConflictHorizon отсчитывается
+	// от начала более позднего из двух событий.
+	var until *time.Time
+	if event.Recurrence == "" {
+		until = &event.Finish
+	}
+
+	rows, err := tx.Query(ctx, sql, event.UserID, event.ID, event.Recurrence, until)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var existing model.Event
+		if err := rows.Scan(&existing.ID, &existing.Beginning, &existing.Finish,
+			&existing.Recurrence, &existing.ExDates, &existing.TimeZone); err != nil {
+			return err
+		}
+
+		existing = inUTC(existing)
+		overlaps, err := event.Overlaps(&existing)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return model.ErrDateBusy
+		}
+	}
+
+	return rows.Err()
+}
+
+// scanEvent читает событие из строки результата со столбцами eventColumns,
+// в extra читаются следующие за ними столбцы.
+func scanEvent(row pgx.Row, extra ...interface{}) (model.Event, error) {
+	var (
+		event     model.Event
+		reminders []byte
+	)
+	dest := append([]interface{}{
+		&event.ID, &event.Title, &event.Description, &event.Beginning, &event.Finish,
+		&event.Notification, &event.UserID, &event.Recurrence, &event.ExDates, &event.CalendarID, &event.TimeZone,
+		&event.UID, &reminders,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return model.Event{}, err
+	}
+	if reminders != nil {
+		if err := json.Unmarshal(reminders, &event.Reminders); err != nil {
+			return model.Event{}, err
+		}
+		for i := range event.Reminders {
+			event.Reminders[i].EventID = event.ID
+		}
+	}
+	return inUTC(event), nil
+}
+
+// mapError преобразует ошибки PostgreSQL в ошибки предметной области.
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == exclusionViolation:
+		return model.ErrDateBusy
+	case pgErr.Code == foreignKeyViolation && strings.HasSuffix(pgErr.ConstraintName, "_calendarid_fkey"):
+		return model.ErrCalendarNotFound
+	case pgErr.Code == foreignKeyViolation && strings.HasSuffix(pgErr.ConstraintName, "_eventid_fkey"):
+		return model.ErrEventNotFound
+	case pgErr.Code == foreignKeyViolation:
+		return model.ErrUserNotFound
+	}
+	return err
+}
+
+// inUTC переводит время события в UTC. pgx возвращает timestamptz в часовом поясе сервера,
+// а местное время события определяется его собственным часовым поясом.
+func inUTC(event model.Event) model.Event {
+	event.Beginning = event.Beginning.UTC()
+	event.Finish = event.Finish.UTC()
+	event.Notification = event.Notification.UTC()
+	for i, exDate := range event.ExDates {
+		event.ExDates[i] = exDate.UTC()
+	}
+	for i, reminder := range event.Reminders {
+		event.Reminders[i].FireAt = reminder.FireAt.UTC()
+		event.Reminders[i].Occurrence = reminder.Occurrence.UTC()
+		event.Reminders[i].SentAt = reminder.SentAt.UTC()
+	}
+	return event
+}
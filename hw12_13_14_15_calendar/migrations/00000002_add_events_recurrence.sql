@@ -0,0 +1,13 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+ALTER TABLE calendar.events
+    ADD COLUMN IF NOT EXISTS Recurrence TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ExDates TIMESTAMP(0)[];
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+ALTER TABLE calendar.events
+    DROP COLUMN IF EXISTS ExDates,
+    DROP COLUMN IF EXISTS Recurrence;
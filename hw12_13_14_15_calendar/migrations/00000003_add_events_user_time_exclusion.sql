@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Однократные события пользователя не должны пересекаться по времени.
+-- Пересечения с повторяющимися сериями проверяет приложение.
+-- Миграции выполняются при каждом запуске, поэтому ограничение добавляется только один раз.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_constraint WHERE conname = 'events_user_time_excl') THEN
+        ALTER TABLE calendar.events
+            ADD CONSTRAINT events_user_time_excl
+            EXCLUDE USING gist (UserID WITH =, tsrange(Beginning, GREATEST(Beginning, Finish)) WITH &&)
+            WHERE (Recurrence = '');
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+ALTER TABLE calendar.events DROP CONSTRAINT IF EXISTS events_user_time_excl;
+
+DROP EXTENSION IF EXISTS btree_gist;
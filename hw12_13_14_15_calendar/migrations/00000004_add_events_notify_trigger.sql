@@ -0,0 +1,44 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Изменения событий публикуются в канал calendar_events, чтобы их видели все экземпляры сервиса.
+-- Размер уведомления ограничен, поэтому текстовые поля не передаются, а создание и обновление
+-- получатель дочитывает из таблицы по ID.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION calendar.notify_event_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed calendar.events%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('calendar_events', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        'id', changed.ID,
+        'userId', changed.UserID,
+        'beginning', changed.Beginning,
+        'finish', changed.Finish,
+        'recurrence', changed.Recurrence,
+        'exDates', changed.ExDates
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS events_notify_change ON calendar.events;
+
+CREATE TRIGGER events_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON calendar.events
+    FOR EACH ROW EXECUTE FUNCTION calendar.notify_event_change();
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TRIGGER IF EXISTS events_notify_change ON calendar.events;
+
+DROP FUNCTION IF EXISTS calendar.notify_event_change();
@@ -0,0 +1,16 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+CREATE TABLE IF NOT EXISTS calendar.api_keys (
+    ID UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    Name TEXT NOT NULL,
+    KeyHash TEXT NOT NULL UNIQUE,
+    Scopes TEXT[] NOT NULL,
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT now(),
+    RevokedAt TIMESTAMPTZ
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.api_keys;
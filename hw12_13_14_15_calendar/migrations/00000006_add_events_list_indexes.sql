@@ -0,0 +1,12 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Индексы для постраничной выборки событий по курсору (beginning, id), в том числе по владельцу.
+CREATE INDEX IF NOT EXISTS events_beginning_id_idx ON calendar.events (Beginning, ID);
+CREATE INDEX IF NOT EXISTS events_userid_beginning_id_idx ON calendar.events (UserID, Beginning, ID);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP INDEX IF EXISTS calendar.events_userid_beginning_id_idx;
+DROP INDEX IF EXISTS calendar.events_beginning_id_idx;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Поисковый вектор события: слова названия с весом A и описания с весом B.
+-- Конфигурация simple не зависит от языка: названия событий бывают на русском и английском.
+ALTER TABLE calendar.events
+    ADD COLUMN IF NOT EXISTS Search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(Title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(Description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS events_search_idx ON calendar.events USING gin (Search);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP INDEX IF EXISTS calendar.events_search_idx;
+
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS Search;
@@ -0,0 +1,100 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+CREATE TABLE IF NOT EXISTS calendar.calendars (
+    ID UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    Name TEXT NOT NULL,
+    OwnerID UUID NOT NULL,
+    TimeZone TEXT NOT NULL DEFAULT 'UTC',
+    Color TEXT NOT NULL DEFAULT '',
+
+    CONSTRAINT calendars_ownerid_fkey FOREIGN KEY (OwnerID) REFERENCES calendar.users (ID) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS calendars_owner_idx ON calendar.calendars (OwnerID);
+
+-- Доступ других пользователей к календарю. Роль владельца календаря не хранится.
+CREATE TABLE IF NOT EXISTS calendar.calendar_acl (
+    CalendarID UUID NOT NULL,
+    UserID UUID NOT NULL,
+    Role TEXT NOT NULL CHECK (Role IN ('read', 'write', 'owner')),
+
+    PRIMARY KEY (CalendarID, UserID),
+    CONSTRAINT calendar_acl_calendarid_fkey FOREIGN KEY (CalendarID)
+        REFERENCES calendar.calendars (ID) ON DELETE CASCADE,
+    CONSTRAINT calendar_acl_userid_fkey FOREIGN KEY (UserID) REFERENCES calendar.users (ID) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS calendar_acl_user_idx ON calendar.calendar_acl (UserID);
+
+-- События без календаря остаются личными событиями владельца.
+ALTER TABLE calendar.events
+    ADD COLUMN IF NOT EXISTS CalendarID UUID
+    CONSTRAINT events_calendarid_fkey REFERENCES calendar.calendars (ID) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS events_calendar_idx ON calendar.events (CalendarID, Beginning, ID);
+
+-- Подписчики фильтруют изменения по календарю, в том числе удаления, которые не дочитываются из таблицы.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION calendar.notify_event_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed calendar.events%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('calendar_events', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        'id', changed.ID,
+        'userId', changed.UserID,
+        'calendarId', changed.CalendarID,
+        'beginning', changed.Beginning,
+        'finish', changed.Finish,
+        'recurrence', changed.Recurrence,
+        'exDates', changed.ExDates
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION calendar.notify_event_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed calendar.events%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('calendar_events', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        'id', changed.ID,
+        'userId', changed.UserID,
+        'beginning', changed.Beginning,
+        'finish', changed.Finish,
+        'recurrence', changed.Recurrence,
+        'exDates', changed.ExDates
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS calendar.events_calendar_idx;
+
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS CalendarID;
+
+DROP TABLE IF EXISTS calendar.calendar_acl;
+
+DROP TABLE IF EXISTS calendar.calendars;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Участники событий. Владелец события участником не считается.
+CREATE TABLE IF NOT EXISTS calendar.event_attendees (
+    EventID UUID NOT NULL,
+    UserID UUID NOT NULL,
+    Status TEXT NOT NULL DEFAULT 'needs-action'
+        CHECK (Status IN ('needs-action', 'accepted', 'declined', 'tentative')),
+
+    PRIMARY KEY (EventID, UserID),
+    CONSTRAINT event_attendees_eventid_fkey FOREIGN KEY (EventID)
+        REFERENCES calendar.events (ID) ON DELETE CASCADE,
+    CONSTRAINT event_attendees_userid_fkey FOREIGN KEY (UserID) REFERENCES calendar.users (ID) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS event_attendees_user_idx ON calendar.event_attendees (UserID);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.event_attendees;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Рабочее время пользователей для поиска слотов встреч. Пользователь без записи доступен в любое время.
+-- Время 24:00 допускается как конец рабочего дня.
+CREATE TABLE IF NOT EXISTS calendar.working_hours (
+    UserID UUID PRIMARY KEY,
+    TimeZone TEXT NOT NULL,
+    StartTime TIME NOT NULL,
+    EndTime TIME NOT NULL CHECK (EndTime > StartTime),
+    Days SMALLINT[] NOT NULL CHECK (cardinality(Days) > 0 AND Days <@ ARRAY[1, 2, 3, 4, 5, 6, 7]::SMALLINT[]),
+
+    CONSTRAINT working_hours_userid_fkey FOREIGN KEY (UserID) REFERENCES calendar.users (ID) ON DELETE CASCADE
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.working_hours;
@@ -0,0 +1,101 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Существующие значения без часового пояса записывались в UTC.
+SET LOCAL TIME ZONE 'UTC';
+
+ALTER TABLE calendar.events DROP CONSTRAINT IF EXISTS events_user_time_excl;
+
+ALTER TABLE calendar.events
+    ALTER COLUMN Beginning TYPE TIMESTAMPTZ(0),
+    ALTER COLUMN Finish TYPE TIMESTAMPTZ(0),
+    ALTER COLUMN Notification TYPE TIMESTAMPTZ(0),
+    ALTER COLUMN ExDates TYPE TIMESTAMPTZ(0)[];
+
+ALTER TABLE calendar.events
+    ADD CONSTRAINT events_user_time_excl
+    EXCLUDE USING gist (UserID WITH =, tstzrange(Beginning, GREATEST(Beginning, Finish)) WITH &&)
+    WHERE (Recurrence = '');
+
+-- Часовой пояс IANA, в котором разворачиваются повторения события, пустой - UTC.
+ALTER TABLE calendar.events ADD COLUMN IF NOT EXISTS TimeZone TEXT NOT NULL DEFAULT '';
+
+-- Часовой пояс IANA, в котором пользователю показываются дни, недели и месяцы, пустой - UTC.
+ALTER TABLE calendar.users ADD COLUMN IF NOT EXISTS TimeZone TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION calendar.notify_event_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed calendar.events%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('calendar_events', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        'id', changed.ID,
+        'userId', changed.UserID,
+        'calendarId', changed.CalendarID,
+        'beginning', changed.Beginning,
+        'finish', changed.Finish,
+        'recurrence', changed.Recurrence,
+        'exDates', changed.ExDates,
+        'timeZone', changed.TimeZone
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+SET LOCAL TIME ZONE 'UTC';
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION calendar.notify_event_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed calendar.events%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('calendar_events', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        'id', changed.ID,
+        'userId', changed.UserID,
+        'calendarId', changed.CalendarID,
+        'beginning', changed.Beginning,
+        'finish', changed.Finish,
+        'recurrence', changed.Recurrence,
+        'exDates', changed.ExDates
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+ALTER TABLE calendar.users DROP COLUMN IF EXISTS TimeZone;
+
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS TimeZone;
+
+ALTER TABLE calendar.events DROP CONSTRAINT IF EXISTS events_user_time_excl;
+
+ALTER TABLE calendar.events
+    ALTER COLUMN Beginning TYPE TIMESTAMP(0),
+    ALTER COLUMN Finish TYPE TIMESTAMP(0),
+    ALTER COLUMN Notification TYPE TIMESTAMP(0),
+    ALTER COLUMN ExDates TYPE TIMESTAMP(0)[];
+
+ALTER TABLE calendar.events
+    ADD CONSTRAINT events_user_time_excl
+    EXCLUDE USING gist (UserID WITH =, tsrange(Beginning, GREATEST(Beginning, Finish)) WITH &&)
+    WHERE (Recurrence = '');
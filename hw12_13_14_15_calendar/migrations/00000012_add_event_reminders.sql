@@ -0,0 +1,25 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Напоминания за MinutesBefore минут до начала каждого повторения события.
+-- FireAt время следующего напоминания и Occurrence начало его повторения вычисляет приложение,
+-- NULL - повторений больше нет. SentAt время последней отправки.
+CREATE TABLE IF NOT EXISTS calendar.event_reminders (
+    ID UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    EventID UUID NOT NULL,
+    MinutesBefore INTEGER NOT NULL CHECK (MinutesBefore >= 0),
+    Channel TEXT NOT NULL DEFAULT '' CHECK (Channel IN ('', 'email', 'webhook', 'log')),
+    FireAt TIMESTAMPTZ(0),
+    Occurrence TIMESTAMPTZ(0),
+    SentAt TIMESTAMPTZ(0),
+
+    CONSTRAINT event_reminders_eventid_fkey FOREIGN KEY (EventID) REFERENCES calendar.events (ID) ON DELETE CASCADE,
+    CONSTRAINT event_reminders_unique UNIQUE (EventID, MinutesBefore, Channel)
+);
+
+CREATE INDEX IF NOT EXISTS event_reminders_fireat_idx ON calendar.event_reminders (FireAt) WHERE FireAt IS NOT NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.event_reminders;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- NotifiedAt время уведомления последнего повторения, о котором уведомление отправлено.
+-- Планировщик выбирает уведомления со временем не позже текущего и позже NotifiedAt,
+-- поэтому пропущенные такты и перезапуски не теряют уведомления.
+ALTER TABLE calendar.events ADD COLUMN IF NOT EXISTS NotifiedAt TIMESTAMPTZ(0);
+
+CREATE INDEX IF NOT EXISTS events_notification_idx ON calendar.events (Notification);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP INDEX IF EXISTS calendar.events_notification_idx;
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS NotifiedAt;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Каналы доставки уведомлений пользователей. Пользователю без записи сообщения доставляются по электронной почте.
+CREATE TABLE IF NOT EXISTS calendar.notification_settings (
+    UserID UUID PRIMARY KEY,
+    Channels TEXT[] NOT NULL CHECK (cardinality(Channels) > 0 AND Channels <@ ARRAY['email', 'webhook', 'log']::TEXT[]),
+    WebhookURL TEXT NOT NULL DEFAULT '',
+
+    CONSTRAINT notification_settings_userid_fkey FOREIGN KEY (UserID) REFERENCES calendar.users (ID) ON DELETE CASCADE
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.notification_settings;
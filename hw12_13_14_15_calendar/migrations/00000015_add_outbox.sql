@@ -0,0 +1,28 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- Сообщения для брокера, сохраненные в одной транзакции с изменением, которое их порождает.
+-- Ретранслятор планировщика публикует их и удаляет; Key служит ключом идемпотентности.
+CREATE TABLE IF NOT EXISTS calendar.outbox (
+    ID BIGSERIAL PRIMARY KEY,
+    Key TEXT NOT NULL UNIQUE,
+    Body BYTEA NOT NULL,
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Доставленные рассыльщиком сообщения по ключу идемпотентности и каналу.
+CREATE TABLE IF NOT EXISTS calendar.deliveries (
+    Key TEXT NOT NULL,
+    Channel TEXT NOT NULL,
+    DeliveredAt TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (Key, Channel)
+);
+
+CREATE INDEX IF NOT EXISTS deliveries_deliveredat_idx ON calendar.deliveries (DeliveredAt);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP TABLE IF EXISTS calendar.deliveries;
+DROP TABLE IF EXISTS calendar.outbox;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- NextNotification время ближайшего уведомления события позже NotifiedAt, NULL - уведомлений не осталось.
+-- Планировщик выбирает только события с наступившим NextNotification, а не все серии на каждом такте.
+-- Миграции календаря выполняются при каждом запуске без учета версий, поэтому столбец
+-- заполняется только при добавлении: повторное заполнение отметило бы ожидающие уведомления серий как отправленные.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT FROM information_schema.columns
+        WHERE table_schema = 'calendar' AND table_name = 'events' AND column_name = 'nextnotification'
+    ) THEN
+        RETURN;
+    END IF;
+
+    ALTER TABLE calendar.events ADD COLUMN NextNotification TIMESTAMPTZ(0);
+
+    -- Уведомления о повторениях серий, начавшихся до миграции, пропускаются, как у новых серий.
+    UPDATE calendar.events SET NotifiedAt = now() - (Beginning - Notification)
+        WHERE Recurrence <> '' AND EXTRACT(YEAR FROM Notification) > 1
+            AND (NotifiedAt IS NULL OR NotifiedAt < now() - (Beginning - Notification));
+
+    -- Для серии NotifiedAt лишь нижняя граница следующего уведомления: планировщик уточнит ее на ближайшем такте.
+    UPDATE calendar.events SET NextNotification = CASE
+            WHEN Recurrence <> '' THEN NotifiedAt
+            WHEN NotifiedAt IS NULL OR NotifiedAt < Notification THEN Notification
+        END
+        WHERE EXTRACT(YEAR FROM Notification) > 1;
+END
+$$;
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS events_nextnotification_idx ON calendar.events (NextNotification);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP INDEX IF EXISTS calendar.events_nextnotification_idx;
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS NextNotification;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied
+
+-- UID идентификатор события iCalendar, из которого загружено событие, пустой - событие создано в календаре.
+-- По нему повторная загрузка того же календаря пропускает уже загруженные события.
+ALTER TABLE calendar.events ADD COLUMN IF NOT EXISTS UID TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS events_user_uid_idx ON calendar.events (UserID, UID) WHERE (UID <> '');
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back
+
+DROP INDEX IF EXISTS calendar.events_user_uid_idx;
+ALTER TABLE calendar.events DROP COLUMN IF EXISTS UID;